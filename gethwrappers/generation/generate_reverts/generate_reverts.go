@@ -0,0 +1,159 @@
+// package main is a script for generating the list of the ABIs of all the
+// generated contract wrappers, from which the reverts package builds its
+// registry of custom errors.
+//
+//	Usage:
+//
+// With gethwrappers/reverts as your working directory, run
+//
+//	go run ../generation/generate_reverts
+//
+// This will output the generated file to reverts/abis_generated.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers"
+)
+
+const (
+	modulePath = "github.com/smartcontractkit/chainlink-evm/gethwrappers"
+	outputFile = "abis_generated.go"
+)
+
+// wrapper is a generated wrapper package and the exported identifiers of its ABIs.
+type wrapper struct {
+	dir   string // relative to gethwrappers
+	alias string
+	abis  []string
+}
+
+func main() {
+	root := filepath.Join(gethwrappers.GetProjectRoot(), "gethwrappers")
+	wrappers, err := findWrappers(root)
+	if err != nil {
+		gethwrappers.Exit("could not find generated wrappers", err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by generation/generate_reverts. DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "package reverts")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "import (")
+	for _, w := range wrappers {
+		fmt.Fprintf(&b, "\t%s %q\n", w.alias, modulePath+"/"+filepath.ToSlash(w.dir))
+	}
+	fmt.Fprintln(&b, ")")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "// wrapperABIs are the ABIs of all the generated contract wrappers.")
+	fmt.Fprintln(&b, "var wrapperABIs = []string{")
+	for _, w := range wrappers {
+		for _, abi := range w.abis {
+			fmt.Fprintf(&b, "\t%s.%s,\n", w.alias, abi)
+		}
+	}
+	fmt.Fprintln(&b, "}")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		gethwrappers.Exit("could not format generated source", err)
+	}
+	if err = os.WriteFile(outputFile, src, 0600); err != nil {
+		gethwrappers.Exit("could not write "+outputFile, err)
+	}
+}
+
+// findWrappers returns the wrappers in the generated directories under root, sorted by directory.
+func findWrappers(root string) ([]wrapper, error) {
+	byDir := make(map[string]*wrapper)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isWrapperFile(path) {
+			return err
+		}
+		abis, err := fileABIs(path)
+		if err != nil || len(abis) == 0 {
+			return err
+		}
+		dir, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		w, ok := byDir[dir]
+		if !ok {
+			w = &wrapper{dir: dir, alias: importAlias(dir)}
+			byDir[dir] = w
+		}
+		w.abis = append(w.abis, abis...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	wrappers := make([]wrapper, 0, len(byDir))
+	for _, w := range byDir {
+		slices.Sort(w.abis)
+		wrappers = append(wrappers, *w)
+	}
+	slices.SortFunc(wrappers, func(a, b wrapper) int { return strings.Compare(a.dir, b.dir) })
+	return wrappers, nil
+}
+
+// isWrapperFile returns whether path is a source file of a generated/<package> directory. The zksync variants share
+// the ABI of the regular wrapper.
+func isWrapperFile(path string) bool {
+	return filepath.Base(filepath.Dir(filepath.Dir(path))) == "generated" &&
+		strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") && !strings.HasSuffix(path, "_zksync.go")
+}
+
+// fileABIs returns the exported expressions holding the ABIs of a wrapper file: <Contract>MetaData.ABI, or
+// <Contract>ABI for the wrappers generated before abigen had MetaData.
+func fileABIs(path string) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	var metaData, consts []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.VAR && gen.Tok != token.CONST) {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				switch {
+				case gen.Tok == token.VAR && strings.HasSuffix(name.Name, "MetaData") && name.IsExported():
+					metaData = append(metaData, name.Name+".ABI")
+				case gen.Tok == token.CONST && strings.HasSuffix(name.Name, "ABI") && name.IsExported():
+					consts = append(consts, name.Name)
+				}
+			}
+		}
+	}
+	if len(metaData) > 0 {
+		return metaData, nil
+	}
+	return consts, nil
+}
+
+// importAlias returns a unique package alias for the wrapper directory, e.g. llo_feeds_verifier for
+// llo-feeds/generated/verifier and verifier for generated/verifier.
+func importAlias(dir string) string {
+	parts := strings.Split(filepath.ToSlash(dir), "/")
+	name := parts[len(parts)-1]
+	if parts[0] != "generated" {
+		name = parts[0] + "_" + name
+	}
+	return strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
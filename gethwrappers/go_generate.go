@@ -16,3 +16,6 @@ package gethwrappers
 //go:generate go generate ./operatorforwarder
 //go:generate go generate ./shared
 //go:generate go generate ./workflow
+
+// Register the custom errors of the wrappers generated above for revert decoding.
+//go:generate go generate ./reverts
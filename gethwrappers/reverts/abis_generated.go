@@ -0,0 +1,372 @@
+// Code generated by generation/generate_reverts. DO NOT EDIT.
+
+package reverts
+
+import (
+	data_feeds_aggregator_proxy "github.com/smartcontractkit/chainlink-evm/gethwrappers/data-feeds/generated/aggregator_proxy"
+	data_feeds_bundle_aggregator_proxy "github.com/smartcontractkit/chainlink-evm/gethwrappers/data-feeds/generated/bundle_aggregator_proxy"
+	data_feeds_data_feeds_cache "github.com/smartcontractkit/chainlink-evm/gethwrappers/data-feeds/generated/data_feeds_cache"
+	functions_functions_allow_list "github.com/smartcontractkit/chainlink-evm/gethwrappers/functions/generated/functions_allow_list"
+	functions_functions_client "github.com/smartcontractkit/chainlink-evm/gethwrappers/functions/generated/functions_client"
+	functions_functions_client_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/functions/generated/functions_client_example"
+	functions_functions_coordinator "github.com/smartcontractkit/chainlink-evm/gethwrappers/functions/generated/functions_coordinator"
+	functions_functions_load_test_client "github.com/smartcontractkit/chainlink-evm/gethwrappers/functions/generated/functions_load_test_client"
+	functions_functions_router "github.com/smartcontractkit/chainlink-evm/gethwrappers/functions/generated/functions_router"
+	functions_functions_v1_events_mock "github.com/smartcontractkit/chainlink-evm/gethwrappers/functions/generated/functions_v1_events_mock"
+	arbitrum_module "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/arbitrum_module"
+	automation_compatible_utils "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_compatible_utils"
+	automation_consumer_benchmark "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_consumer_benchmark"
+	automation_forwarder_logic "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_forwarder_logic"
+	automation_registrar_wrapper2_1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registrar_wrapper2_1"
+	automation_registrar_wrapper2_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registrar_wrapper2_3"
+	automation_registry_logic_a_wrapper_2_2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registry_logic_a_wrapper_2_2"
+	automation_registry_logic_a_wrapper_2_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registry_logic_a_wrapper_2_3"
+	automation_registry_logic_b_wrapper_2_2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registry_logic_b_wrapper_2_2"
+	automation_registry_logic_b_wrapper_2_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registry_logic_b_wrapper_2_3"
+	automation_registry_logic_c_wrapper_2_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registry_logic_c_wrapper_2_3"
+	automation_registry_wrapper_2_2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registry_wrapper_2_2"
+	automation_registry_wrapper_2_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/automation_registry_wrapper_2_3"
+	basic_upkeep_contract "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/basic_upkeep_contract"
+	batch_blockhash_store "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/batch_blockhash_store"
+	batch_vrf_coordinator_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/batch_vrf_coordinator_v2"
+	batch_vrf_coordinator_v2plus "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/batch_vrf_coordinator_v2plus"
+	blockhash_store "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/blockhash_store"
+	chain_module_base "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/chain_module_base"
+	chain_specific_util_helper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/chain_specific_util_helper"
+	consumer_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/consumer_wrapper"
+	counter "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/counter"
+	dummy_protocol_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/dummy_protocol_wrapper"
+	flags_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/flags_wrapper"
+	flux_aggregator_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/flux_aggregator_wrapper"
+	functions_billing_registry_events_mock "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/functions_billing_registry_events_mock"
+	gas_wrapper_mock "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/gas_wrapper_mock"
+	i_automation_registry_master_wrapper_2_2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/i_automation_registry_master_wrapper_2_2"
+	i_automation_registry_master_wrapper_2_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/i_automation_registry_master_wrapper_2_3"
+	i_automation_v21_plus_common "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/i_automation_v21_plus_common"
+	i_chain_module "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/i_chain_module"
+	i_keeper_registry_master_wrapper_2_1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/i_keeper_registry_master_wrapper_2_1"
+	i_log_automation "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/i_log_automation"
+	keeper_consumer_performance_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_consumer_performance_wrapper"
+	keeper_registrar_wrapper1_2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registrar_wrapper1_2"
+	keeper_registrar_wrapper1_2_mock "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registrar_wrapper1_2_mock"
+	keeper_registrar_wrapper2_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registrar_wrapper2_0"
+	keeper_registry_logic1_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_logic1_3"
+	keeper_registry_logic2_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_logic2_0"
+	keeper_registry_logic_a_wrapper_2_1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_logic_a_wrapper_2_1"
+	keeper_registry_logic_b_wrapper_2_1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_logic_b_wrapper_2_1"
+	keeper_registry_wrapper1_1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_wrapper1_1"
+	keeper_registry_wrapper1_1_mock "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_wrapper1_1_mock"
+	keeper_registry_wrapper1_2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_wrapper1_2"
+	keeper_registry_wrapper1_3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_wrapper1_3"
+	keeper_registry_wrapper2_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_wrapper2_0"
+	keeper_registry_wrapper_2_1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keeper_registry_wrapper_2_1"
+	keepers_vrf_consumer "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/keepers_vrf_consumer"
+	link_token_interface "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/link_token_interface"
+	llo_feeds "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/llo_feeds"
+	log_triggered_streams_lookup_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/log_triggered_streams_lookup_wrapper"
+	log_upkeep_counter_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/log_upkeep_counter_wrapper"
+	mock_ethlink_aggregator_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/mock_ethlink_aggregator_wrapper"
+	mock_ethusd_aggregator_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/mock_ethusd_aggregator_wrapper"
+	mock_gas_aggregator_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/mock_gas_aggregator_wrapper"
+	mock_v3_aggregator_contract "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/mock_v3_aggregator_contract"
+	multiwordconsumer_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/multiwordconsumer_wrapper"
+	offchain_aggregator_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/offchain_aggregator_wrapper"
+	optimism_module "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/optimism_module"
+	oracle_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/oracle_wrapper"
+	perform_data_checker_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/perform_data_checker_wrapper"
+	scroll_module "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/scroll_module"
+	simple_log_upkeep_counter_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/simple_log_upkeep_counter_wrapper"
+	solidity_vrf_consumer_interface "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_consumer_interface"
+	solidity_vrf_consumer_interface_v08 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_consumer_interface_v08"
+	solidity_vrf_coordinator_interface "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_coordinator_interface"
+	solidity_vrf_request_id "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_request_id"
+	solidity_vrf_request_id_v08 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_request_id_v08"
+	solidity_vrf_v08_verifier_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_v08_verifier_wrapper"
+	solidity_vrf_verifier_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_verifier_wrapper"
+	solidity_vrf_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/solidity_vrf_wrapper"
+	streams_lookup_compatible_interface "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/streams_lookup_compatible_interface"
+	streams_lookup_upkeep_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/streams_lookup_upkeep_wrapper"
+	test_api_consumer_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/test_api_consumer_wrapper"
+	trusted_blockhash_store "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/trusted_blockhash_store"
+	upkeep_counter_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/upkeep_counter_wrapper"
+	upkeep_perform_counter_restrictive_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/upkeep_perform_counter_restrictive_wrapper"
+	upkeep_transcoder "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/upkeep_transcoder"
+	verifiable_load_streams_lookup_upkeep_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/verifiable_load_streams_lookup_upkeep_wrapper"
+	verifiable_load_upkeep_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/verifiable_load_upkeep_wrapper"
+	vrf_consumer_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_consumer_v2"
+	vrf_consumer_v2_plus_upgradeable_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_consumer_v2_plus_upgradeable_example"
+	vrf_consumer_v2_upgradeable_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_consumer_v2_upgradeable_example"
+	vrf_coordinator_mock "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_mock"
+	vrf_coordinator_test_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_test_v2"
+	vrf_coordinator_test_v2_5 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_test_v2_5"
+	vrf_coordinator_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	vrf_coordinator_v2_5 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_5"
+	vrf_coordinator_v2_5_arbitrum "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_5_arbitrum"
+	vrf_coordinator_v2_5_optimism "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_5_optimism"
+	vrf_coordinator_v2_plus_v2_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_plus_v2_example"
+	vrf_coordinator_v2plus_interface "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2plus_interface"
+	vrf_external_sub_owner_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_external_sub_owner_example"
+	vrf_load_test_external_sub_owner "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_load_test_external_sub_owner"
+	vrf_load_test_ownerless_consumer "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_load_test_ownerless_consumer"
+	vrf_load_test_with_metrics "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_load_test_with_metrics"
+	vrf_malicious_consumer_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_malicious_consumer_v2"
+	vrf_malicious_consumer_v2_plus "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_malicious_consumer_v2_plus"
+	vrf_mock_ethlink_aggregator "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_mock_ethlink_aggregator"
+	vrf_owner "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_owner"
+	vrf_owner_test_consumer "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_owner_test_consumer"
+	vrf_ownerless_consumer_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_ownerless_consumer_example"
+	vrf_single_consumer_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_single_consumer_example"
+	vrf_v2_consumer_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_v2_consumer_wrapper"
+	vrf_v2plus_load_test_with_metrics "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_v2plus_load_test_with_metrics"
+	vrf_v2plus_single_consumer "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_v2plus_single_consumer"
+	vrf_v2plus_sub_owner "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_v2plus_sub_owner"
+	vrf_v2plus_upgraded_version "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_v2plus_upgraded_version"
+	vrfv2_proxy_admin "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2_proxy_admin"
+	vrfv2_reverting_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2_reverting_example"
+	vrfv2_transparent_upgradeable_proxy "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2_transparent_upgradeable_proxy"
+	vrfv2_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2_wrapper"
+	vrfv2_wrapper_consumer_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2_wrapper_consumer_example"
+	vrfv2_wrapper_interface "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2_wrapper_interface"
+	vrfv2_wrapper_load_test_consumer "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2_wrapper_load_test_consumer"
+	vrfv2plus_client "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_client"
+	vrfv2plus_consumer_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_consumer_example"
+	vrfv2plus_malicious_migrator "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_malicious_migrator"
+	vrfv2plus_reverting_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_reverting_example"
+	vrfv2plus_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_wrapper"
+	vrfv2plus_wrapper_arbitrum "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_wrapper_arbitrum"
+	vrfv2plus_wrapper_consumer_example "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_wrapper_consumer_example"
+	vrfv2plus_wrapper_load_test_consumer "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_wrapper_load_test_consumer"
+	vrfv2plus_wrapper_optimism "github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_wrapper_optimism"
+	keystone_balance_reader "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/balance_reader"
+	keystone_capabilities_registry "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry"
+	keystone_capabilities_registry_1_1_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry_1_1_0"
+	keystone_feeds_consumer "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/feeds_consumer"
+	keystone_feeds_consumer_1_0_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/feeds_consumer_1_0_0"
+	keystone_forwarder "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/forwarder"
+	keystone_forwarder_1_0_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/forwarder_1_0_0"
+	keystone_ocr3_capability "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/ocr3_capability"
+	keystone_ocr3_capability_1_0_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/ocr3_capability_1_0_0"
+	llo_feeds_channel_config_store "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/channel_config_store"
+	llo_feeds_configurator "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/configurator"
+	llo_feeds_destination_fee_manager "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/destination_fee_manager"
+	llo_feeds_destination_reward_manager "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/destination_reward_manager"
+	llo_feeds_destination_verifier "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/destination_verifier"
+	llo_feeds_destination_verifier_proxy "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/destination_verifier_proxy"
+	llo_feeds_errored_verifier "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/errored_verifier"
+	llo_feeds_exposed_configurator "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/exposed_configurator"
+	llo_feeds_exposed_verifier "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/exposed_verifier"
+	llo_feeds_fee_manager "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/fee_manager"
+	llo_feeds_fee_manager_v0_5_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/fee_manager_v0_5_0"
+	llo_feeds_mock_fee_manager_v0_5_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/mock_fee_manager_v0_5_0"
+	llo_feeds_reward_manager "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/reward_manager"
+	llo_feeds_reward_manager_v0_5_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/reward_manager_v0_5_0"
+	llo_feeds_verifier "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/verifier"
+	llo_feeds_verifier_proxy "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/verifier_proxy"
+	llo_feeds_verifier_proxy_v0_5_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/verifier_proxy_v0_5_0"
+	llo_feeds_verifier_v0_5_0 "github.com/smartcontractkit/chainlink-evm/gethwrappers/llo-feeds/generated/verifier_v0_5_0"
+	operatorforwarder_authorized_forwarder "github.com/smartcontractkit/chainlink-evm/gethwrappers/operatorforwarder/generated/authorized_forwarder"
+	operatorforwarder_authorized_receiver "github.com/smartcontractkit/chainlink-evm/gethwrappers/operatorforwarder/generated/authorized_receiver"
+	operatorforwarder_link_token_receiver "github.com/smartcontractkit/chainlink-evm/gethwrappers/operatorforwarder/generated/link_token_receiver"
+	operatorforwarder_operator "github.com/smartcontractkit/chainlink-evm/gethwrappers/operatorforwarder/generated/operator"
+	operatorforwarder_operator_factory "github.com/smartcontractkit/chainlink-evm/gethwrappers/operatorforwarder/generated/operator_factory"
+	shared_aggregator_v3_interface "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/aggregator_v3_interface"
+	shared_burn_mint_erc20 "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/burn_mint_erc20"
+	shared_burn_mint_erc677 "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/burn_mint_erc677"
+	shared_chain_reader_tester "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/chain_reader_tester"
+	shared_erc20 "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/erc20"
+	shared_erc677 "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/erc677"
+	shared_link_token "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/link_token"
+	shared_log_emitter "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/log_emitter"
+	shared_mock_v3_aggregator_contract "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/mock_v3_aggregator_contract"
+	shared_multicall3 "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/multicall3"
+	shared_type_and_version "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/type_and_version"
+	shared_vrf_log_emitter "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/vrf_log_emitter"
+	shared_werc20_mock "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/werc20_mock"
+	shared_weth9 "github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/weth9"
+	workflow_workflow_registry_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/workflow_registry_wrapper"
+)
+
+// wrapperABIs are the ABIs of all the generated contract wrappers.
+var wrapperABIs = []string{
+	data_feeds_aggregator_proxy.AggregatorProxyMetaData.ABI,
+	data_feeds_bundle_aggregator_proxy.BundleAggregatorProxyMetaData.ABI,
+	data_feeds_data_feeds_cache.DataFeedsCacheMetaData.ABI,
+	functions_functions_allow_list.TermsOfServiceAllowListMetaData.ABI,
+	functions_functions_client.FunctionsClientMetaData.ABI,
+	functions_functions_client_example.FunctionsClientExampleMetaData.ABI,
+	functions_functions_coordinator.FunctionsCoordinatorMetaData.ABI,
+	functions_functions_load_test_client.FunctionsLoadTestClientMetaData.ABI,
+	functions_functions_router.FunctionsRouterMetaData.ABI,
+	functions_functions_v1_events_mock.FunctionsV1EventsMockMetaData.ABI,
+	arbitrum_module.ArbitrumModuleMetaData.ABI,
+	automation_compatible_utils.AutomationCompatibleUtilsMetaData.ABI,
+	automation_consumer_benchmark.AutomationConsumerBenchmarkMetaData.ABI,
+	automation_forwarder_logic.AutomationForwarderLogicMetaData.ABI,
+	automation_registrar_wrapper2_1.AutomationRegistrarMetaData.ABI,
+	automation_registrar_wrapper2_3.AutomationRegistrarMetaData.ABI,
+	automation_registry_logic_a_wrapper_2_2.AutomationRegistryLogicAMetaData.ABI,
+	automation_registry_logic_a_wrapper_2_3.AutomationRegistryLogicAMetaData.ABI,
+	automation_registry_logic_b_wrapper_2_2.AutomationRegistryLogicBMetaData.ABI,
+	automation_registry_logic_b_wrapper_2_3.AutomationRegistryLogicBMetaData.ABI,
+	automation_registry_logic_c_wrapper_2_3.AutomationRegistryLogicCMetaData.ABI,
+	automation_registry_wrapper_2_2.AutomationRegistryMetaData.ABI,
+	automation_registry_wrapper_2_3.AutomationRegistryMetaData.ABI,
+	basic_upkeep_contract.BasicUpkeepContractABI,
+	batch_blockhash_store.BatchBlockhashStoreMetaData.ABI,
+	batch_vrf_coordinator_v2.BatchVRFCoordinatorV2MetaData.ABI,
+	batch_vrf_coordinator_v2plus.BatchVRFCoordinatorV2PlusMetaData.ABI,
+	blockhash_store.BlockhashStoreMetaData.ABI,
+	chain_module_base.ChainModuleBaseMetaData.ABI,
+	chain_specific_util_helper.ChainSpecificUtilHelperMetaData.ABI,
+	consumer_wrapper.ConsumerMetaData.ABI,
+	counter.CounterMetaData.ABI,
+	dummy_protocol_wrapper.DummyProtocolMetaData.ABI,
+	flags_wrapper.FlagsMetaData.ABI,
+	flux_aggregator_wrapper.FluxAggregatorMetaData.ABI,
+	functions_billing_registry_events_mock.FunctionsBillingRegistryEventsMockMetaData.ABI,
+	gas_wrapper_mock.KeeperRegistryCheckUpkeepGasUsageWrapperMockMetaData.ABI,
+	i_automation_registry_master_wrapper_2_2.IAutomationRegistryMasterMetaData.ABI,
+	i_automation_registry_master_wrapper_2_3.IAutomationRegistryMaster23MetaData.ABI,
+	i_automation_v21_plus_common.IAutomationV21PlusCommonMetaData.ABI,
+	i_chain_module.IChainModuleMetaData.ABI,
+	i_keeper_registry_master_wrapper_2_1.IKeeperRegistryMasterMetaData.ABI,
+	i_log_automation.ILogAutomationMetaData.ABI,
+	keeper_consumer_performance_wrapper.KeeperConsumerPerformanceMetaData.ABI,
+	keeper_registrar_wrapper1_2.KeeperRegistrarMetaData.ABI,
+	keeper_registrar_wrapper1_2_mock.KeeperRegistrarMockMetaData.ABI,
+	keeper_registrar_wrapper2_0.KeeperRegistrarMetaData.ABI,
+	keeper_registry_logic1_3.KeeperRegistryLogicMetaData.ABI,
+	keeper_registry_logic2_0.KeeperRegistryLogicMetaData.ABI,
+	keeper_registry_logic_a_wrapper_2_1.KeeperRegistryLogicAMetaData.ABI,
+	keeper_registry_logic_b_wrapper_2_1.KeeperRegistryLogicBMetaData.ABI,
+	keeper_registry_wrapper1_1.KeeperRegistryMetaData.ABI,
+	keeper_registry_wrapper1_1_mock.KeeperRegistryMockMetaData.ABI,
+	keeper_registry_wrapper1_2.KeeperRegistryMetaData.ABI,
+	keeper_registry_wrapper1_3.KeeperRegistryMetaData.ABI,
+	keeper_registry_wrapper2_0.KeeperRegistryMetaData.ABI,
+	keeper_registry_wrapper_2_1.KeeperRegistryMetaData.ABI,
+	keepers_vrf_consumer.KeepersVRFConsumerMetaData.ABI,
+	link_token_interface.LinkTokenMetaData.ABI,
+	llo_feeds.LLOVerifierProxyMetaData.ABI,
+	log_triggered_streams_lookup_wrapper.LogTriggeredStreamsLookupMetaData.ABI,
+	log_upkeep_counter_wrapper.LogUpkeepCounterMetaData.ABI,
+	mock_ethlink_aggregator_wrapper.MockETHLINKAggregatorMetaData.ABI,
+	mock_ethusd_aggregator_wrapper.MockETHUSDAggregatorMetaData.ABI,
+	mock_gas_aggregator_wrapper.MockGASAggregatorMetaData.ABI,
+	mock_v3_aggregator_contract.MockV3AggregatorContractABI,
+	multiwordconsumer_wrapper.MultiWordConsumerMetaData.ABI,
+	offchain_aggregator_wrapper.OffchainAggregatorMetaData.ABI,
+	optimism_module.OptimismModuleMetaData.ABI,
+	oracle_wrapper.OracleMetaData.ABI,
+	perform_data_checker_wrapper.PerformDataCheckerMetaData.ABI,
+	scroll_module.ScrollModuleMetaData.ABI,
+	simple_log_upkeep_counter_wrapper.SimpleLogUpkeepCounterMetaData.ABI,
+	solidity_vrf_consumer_interface.VRFConsumerMetaData.ABI,
+	solidity_vrf_consumer_interface_v08.VRFConsumerMetaData.ABI,
+	solidity_vrf_coordinator_interface.VRFCoordinatorMetaData.ABI,
+	solidity_vrf_request_id.VRFRequestIDBaseTestHelperMetaData.ABI,
+	solidity_vrf_request_id_v08.VRFRequestIDBaseTestHelperMetaData.ABI,
+	solidity_vrf_v08_verifier_wrapper.VRFTestHelperMetaData.ABI,
+	solidity_vrf_verifier_wrapper.VRFTestHelperMetaData.ABI,
+	solidity_vrf_wrapper.VRFMetaData.ABI,
+	streams_lookup_compatible_interface.StreamsLookupCompatibleInterfaceMetaData.ABI,
+	streams_lookup_upkeep_wrapper.StreamsLookupUpkeepMetaData.ABI,
+	test_api_consumer_wrapper.TestAPIConsumerMetaData.ABI,
+	trusted_blockhash_store.TrustedBlockhashStoreMetaData.ABI,
+	upkeep_counter_wrapper.UpkeepCounterMetaData.ABI,
+	upkeep_perform_counter_restrictive_wrapper.UpkeepPerformCounterRestrictiveMetaData.ABI,
+	upkeep_transcoder.UpkeepTranscoderMetaData.ABI,
+	verifiable_load_streams_lookup_upkeep_wrapper.VerifiableLoadStreamsLookupUpkeepMetaData.ABI,
+	verifiable_load_upkeep_wrapper.VerifiableLoadUpkeepMetaData.ABI,
+	vrf_consumer_v2.VRFConsumerV2MetaData.ABI,
+	vrf_consumer_v2_plus_upgradeable_example.VRFConsumerV2PlusUpgradeableExampleMetaData.ABI,
+	vrf_consumer_v2_upgradeable_example.VRFConsumerV2UpgradeableExampleMetaData.ABI,
+	vrf_coordinator_mock.VRFCoordinatorMockMetaData.ABI,
+	vrf_coordinator_test_v2.VRFCoordinatorTestV2MetaData.ABI,
+	vrf_coordinator_test_v2_5.VRFCoordinatorTestV25MetaData.ABI,
+	vrf_coordinator_v2.VRFCoordinatorV2MetaData.ABI,
+	vrf_coordinator_v2_5.VRFCoordinatorV25MetaData.ABI,
+	vrf_coordinator_v2_5_arbitrum.VRFCoordinatorV25ArbitrumMetaData.ABI,
+	vrf_coordinator_v2_5_optimism.VRFCoordinatorV25OptimismMetaData.ABI,
+	vrf_coordinator_v2_plus_v2_example.VRFCoordinatorV2PlusV2ExampleMetaData.ABI,
+	vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalMetaData.ABI,
+	vrf_external_sub_owner_example.VRFExternalSubOwnerExampleMetaData.ABI,
+	vrf_load_test_external_sub_owner.VRFLoadTestExternalSubOwnerMetaData.ABI,
+	vrf_load_test_ownerless_consumer.VRFLoadTestOwnerlessConsumerMetaData.ABI,
+	vrf_load_test_with_metrics.VRFV2LoadTestWithMetricsMetaData.ABI,
+	vrf_malicious_consumer_v2.VRFMaliciousConsumerV2MetaData.ABI,
+	vrf_malicious_consumer_v2_plus.VRFMaliciousConsumerV2PlusMetaData.ABI,
+	vrf_mock_ethlink_aggregator.VRFMockETHLINKAggregatorMetaData.ABI,
+	vrf_owner.VRFOwnerMetaData.ABI,
+	vrf_owner_test_consumer.VRFV2OwnerTestConsumerMetaData.ABI,
+	vrf_ownerless_consumer_example.VRFOwnerlessConsumerExampleMetaData.ABI,
+	vrf_single_consumer_example.VRFSingleConsumerExampleMetaData.ABI,
+	vrf_v2_consumer_wrapper.VRFv2ConsumerMetaData.ABI,
+	vrf_v2plus_load_test_with_metrics.VRFV2PlusLoadTestWithMetricsMetaData.ABI,
+	vrf_v2plus_single_consumer.VRFV2PlusSingleConsumerExampleMetaData.ABI,
+	vrf_v2plus_sub_owner.VRFV2PlusExternalSubOwnerExampleMetaData.ABI,
+	vrf_v2plus_upgraded_version.VRFCoordinatorV2PlusUpgradedVersionMetaData.ABI,
+	vrfv2_proxy_admin.VRFV2ProxyAdminMetaData.ABI,
+	vrfv2_reverting_example.VRFV2RevertingExampleMetaData.ABI,
+	vrfv2_transparent_upgradeable_proxy.VRFV2TransparentUpgradeableProxyMetaData.ABI,
+	vrfv2_wrapper.VRFV2WrapperMetaData.ABI,
+	vrfv2_wrapper_consumer_example.VRFV2WrapperConsumerExampleMetaData.ABI,
+	vrfv2_wrapper_interface.VRFV2WrapperInterfaceMetaData.ABI,
+	vrfv2_wrapper_load_test_consumer.VRFV2WrapperLoadTestConsumerMetaData.ABI,
+	vrfv2plus_client.VRFV2PlusClientMetaData.ABI,
+	vrfv2plus_consumer_example.VRFV2PlusConsumerExampleMetaData.ABI,
+	vrfv2plus_malicious_migrator.VRFV2PlusMaliciousMigratorMetaData.ABI,
+	vrfv2plus_reverting_example.VRFV2PlusRevertingExampleMetaData.ABI,
+	vrfv2plus_wrapper.VRFV2PlusWrapperMetaData.ABI,
+	vrfv2plus_wrapper_arbitrum.VRFV2PlusWrapperArbitrumMetaData.ABI,
+	vrfv2plus_wrapper_consumer_example.VRFV2PlusWrapperConsumerExampleMetaData.ABI,
+	vrfv2plus_wrapper_load_test_consumer.VRFV2PlusWrapperLoadTestConsumerMetaData.ABI,
+	vrfv2plus_wrapper_optimism.VRFV2PlusWrapperOptimismMetaData.ABI,
+	keystone_balance_reader.BalanceReaderMetaData.ABI,
+	keystone_capabilities_registry.CapabilitiesRegistryMetaData.ABI,
+	keystone_capabilities_registry_1_1_0.CapabilitiesRegistryMetaData.ABI,
+	keystone_feeds_consumer.KeystoneFeedsConsumerMetaData.ABI,
+	keystone_feeds_consumer_1_0_0.KeystoneFeedsConsumerMetaData.ABI,
+	keystone_forwarder.KeystoneForwarderMetaData.ABI,
+	keystone_forwarder_1_0_0.KeystoneForwarderMetaData.ABI,
+	keystone_ocr3_capability.OCR3CapabilityMetaData.ABI,
+	keystone_ocr3_capability_1_0_0.OCR3CapabilityMetaData.ABI,
+	llo_feeds_channel_config_store.ChannelConfigStoreMetaData.ABI,
+	llo_feeds_configurator.ConfiguratorMetaData.ABI,
+	llo_feeds_destination_fee_manager.DestinationFeeManagerMetaData.ABI,
+	llo_feeds_destination_reward_manager.DestinationRewardManagerMetaData.ABI,
+	llo_feeds_destination_verifier.DestinationVerifierMetaData.ABI,
+	llo_feeds_destination_verifier_proxy.DestinationVerifierProxyMetaData.ABI,
+	llo_feeds_errored_verifier.ErroredVerifierMetaData.ABI,
+	llo_feeds_exposed_configurator.ExposedConfiguratorMetaData.ABI,
+	llo_feeds_exposed_verifier.ExposedVerifierMetaData.ABI,
+	llo_feeds_fee_manager.FeeManagerMetaData.ABI,
+	llo_feeds_fee_manager_v0_5_0.FeeManagerMetaData.ABI,
+	llo_feeds_mock_fee_manager_v0_5_0.MockFeeManagerMetaData.ABI,
+	llo_feeds_reward_manager.RewardManagerMetaData.ABI,
+	llo_feeds_reward_manager_v0_5_0.RewardManagerMetaData.ABI,
+	llo_feeds_verifier.VerifierMetaData.ABI,
+	llo_feeds_verifier_proxy.VerifierProxyMetaData.ABI,
+	llo_feeds_verifier_proxy_v0_5_0.VerifierProxyMetaData.ABI,
+	llo_feeds_verifier_v0_5_0.VerifierMetaData.ABI,
+	operatorforwarder_authorized_forwarder.AuthorizedForwarderMetaData.ABI,
+	operatorforwarder_authorized_receiver.AuthorizedReceiverMetaData.ABI,
+	operatorforwarder_link_token_receiver.LinkTokenReceiverMetaData.ABI,
+	operatorforwarder_operator.OperatorMetaData.ABI,
+	operatorforwarder_operator_factory.OperatorFactoryMetaData.ABI,
+	shared_aggregator_v3_interface.AggregatorV3InterfaceMetaData.ABI,
+	shared_burn_mint_erc20.BurnMintERC20MetaData.ABI,
+	shared_burn_mint_erc677.BurnMintERC677MetaData.ABI,
+	shared_chain_reader_tester.ChainReaderTesterMetaData.ABI,
+	shared_erc20.ERC20MetaData.ABI,
+	shared_erc677.ERC677MetaData.ABI,
+	shared_link_token.LinkTokenMetaData.ABI,
+	shared_log_emitter.LogEmitterMetaData.ABI,
+	shared_mock_v3_aggregator_contract.MockV3AggregatorMetaData.ABI,
+	shared_multicall3.Multicall3MetaData.ABI,
+	shared_type_and_version.ITypeAndVersionMetaData.ABI,
+	shared_vrf_log_emitter.VRFLogEmitterMetaData.ABI,
+	shared_werc20_mock.WERC20MockMetaData.ABI,
+	shared_weth9.WETH9MetaData.ABI,
+	workflow_workflow_registry_wrapper.WorkflowRegistryMetaData.ABI,
+}
@@ -0,0 +1,28 @@
+// Package reverts decodes revert data with the custom errors of all the generated contract wrappers, along with
+// Error(string) and Panic(uint256).
+package reverts
+
+//go:generate go run ../generation/generate_reverts
+
+import (
+	"sync"
+
+	evmabi "github.com/smartcontractkit/chainlink-evm/pkg/abi"
+)
+
+// Registry returns the registry of the errors of all the generated contract wrappers. It's built on first use.
+var Registry = sync.OnceValue(func() *evmabi.ErrorRegistry {
+	r := evmabi.NewErrorRegistry()
+	for _, abi := range wrapperABIs {
+		if err := r.RegisterABI(abi); err != nil {
+			// The ABIs are generated from compiled contracts, and are checked by TestRegistry.
+			panic(err)
+		}
+	}
+	return r
+})
+
+// Decode decodes revert data with Registry.
+func Decode(data []byte) (*evmabi.DecodedError, error) {
+	return Registry().Decode(data)
+}
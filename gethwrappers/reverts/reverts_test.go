@@ -0,0 +1,29 @@
+package reverts
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/forwarder"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	// Panics if an ABI is invalid.
+	assert.Greater(t, Registry().Len(), len(wrapperABIs))
+
+	abi, err := forwarder.KeystoneForwarderMetaData.GetAbi()
+	require.NoError(t, err)
+	transmissionID := common.HexToHash("0x1234")
+	alreadyAttempted := abi.Errors["AlreadyAttempted"]
+	data, err := alreadyAttempted.Inputs.Pack(transmissionID)
+	require.NoError(t, err)
+	data = append(alreadyAttempted.ID[:4:4], data...)
+
+	decoded, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, "AlreadyAttempted(bytes32 transmissionId: "+transmissionID.Hex()+")", decoded.String())
+}
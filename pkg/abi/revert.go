@@ -0,0 +1,194 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	errorStringError = abi.NewError("Error", abi.Arguments{{Name: "reason", Type: mustNewType("string")}})
+	panicError       = abi.NewError("Panic", abi.Arguments{{Name: "code", Type: mustNewType("uint256")}})
+
+	// panicReasons are the descriptions of the Solidity panic codes.
+	// See https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+	panicReasons = map[uint64]string{
+		0x00: "generic compiler inserted panic",
+		0x01: "assert(false)",
+		0x11: "arithmetic underflow or overflow",
+		0x12: "division or modulo by zero",
+		0x21: "enum overflow",
+		0x22: "invalid encoded storage byte array accessed",
+		0x31: "out-of-bounds array access; popping an empty array",
+		0x32: "out-of-bounds access of an array or bytesN",
+		0x41: "out of memory",
+		0x51: "uninitialized function",
+	}
+)
+
+// DecodedArg is an argument of a DecodedError.
+type DecodedArg struct {
+	Name  string
+	Type  string
+	Value any
+}
+
+// DecodedError is revert data decoded as Error(string), Panic(uint256) or a Solidity custom error.
+type DecodedError struct {
+	Name string
+	// Signature is the canonical signature of the error, e.g. InvalidReport(bytes32).
+	Signature string
+	Args      []DecodedArg
+}
+
+// String returns the error with its named arguments, e.g. InvalidReport(bytes32 reportId: 0x01...).
+func (d DecodedError) String() string {
+	args := make([]string, len(d.Args))
+	for i, arg := range d.Args {
+		args[i] = strings.TrimSpace(arg.Type+" "+arg.Name) + ": " + formatArg(arg.Value)
+		if d.Signature == panicError.Sig {
+			if code, ok := arg.Value.(*big.Int); ok && code.IsUint64() {
+				if reason, ok := panicReasons[code.Uint64()]; ok {
+					args[i] += " (" + reason + ")"
+				}
+			}
+		}
+	}
+	return d.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func formatArg(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []byte:
+		return hexutil.Encode(v)
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	}
+	// bytesN
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// ErrorRegistry decodes revert data with the errors registered by selector. Error(string) and Panic(uint256) are
+// always registered. It's safe for concurrent use.
+type ErrorRegistry struct {
+	mu     sync.RWMutex
+	errors map[[4]byte][]abi.Error // errors with distinct signatures can share a selector
+}
+
+// NewErrorRegistry returns a registry of Error(string), Panic(uint256) and errs.
+func NewErrorRegistry(errs ...abi.Error) *ErrorRegistry {
+	r := &ErrorRegistry{errors: make(map[[4]byte][]abi.Error)}
+	r.Register(errorStringError, panicError)
+	r.Register(errs...)
+	return r
+}
+
+// Register adds errs to the registry. Errors already registered with the same signature are ignored.
+func (r *ErrorRegistry) Register(errs ...abi.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range errs {
+		selector := [4]byte(e.ID[:4])
+		registered := r.errors[selector]
+		if !containsSig(registered, e.Sig) {
+			r.errors[selector] = append(registered, e)
+		}
+	}
+}
+
+// RegisterABI adds the errors of the JSON ABI to the registry.
+func (r *ErrorRegistry) RegisterABI(abiJSON string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	errs := make([]abi.Error, 0, len(parsed.Errors))
+	for _, e := range parsed.Errors {
+		errs = append(errs, e)
+	}
+	r.Register(errs...)
+	return nil
+}
+
+// Len returns the number of errors registered.
+func (r *ErrorRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var n int
+	for _, errs := range r.errors {
+		n += len(errs)
+	}
+	return n
+}
+
+// Decode decodes the revert data with the error registered for its selector.
+func (r *ErrorRegistry) Decode(data []byte) (*DecodedError, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("revert data too short: %s", hexutil.Encode(data))
+	}
+	r.mu.RLock()
+	errs := r.errors[[4]byte(data[:4])]
+	r.mu.RUnlock()
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("unknown error selector %s", hexutil.Encode(data[:4]))
+	}
+
+	var unpackErr error
+	for _, e := range errs {
+		values, err := e.Inputs.Unpack(data[4:])
+		if err != nil {
+			unpackErr = errors.Join(unpackErr, fmt.Errorf("failed to unpack %s: %w", e.Sig, err))
+			continue
+		}
+		// Tell apart errors sharing a selector by only accepting the one the data is exactly the encoding of.
+		if len(errs) > 1 && !isEncoding(e.Inputs, values, data[4:]) {
+			unpackErr = errors.Join(unpackErr, fmt.Errorf("revert data is not a valid encoding of %s", e.Sig))
+			continue
+		}
+		decoded := &DecodedError{Name: e.Name, Signature: e.Sig, Args: make([]DecodedArg, len(values))}
+		for i, v := range values {
+			decoded.Args[i] = DecodedArg{Name: e.Inputs[i].Name, Type: e.Inputs[i].Type.String(), Value: v}
+		}
+		return decoded, nil
+	}
+	return nil, unpackErr
+}
+
+func isEncoding(args abi.Arguments, values []any, data []byte) bool {
+	packed, err := args.Pack(values...)
+	return err == nil && bytes.Equal(packed, data)
+}
+
+func containsSig(errs []abi.Error, sig string) bool {
+	for _, e := range errs {
+		if e.Sig == sig {
+			return true
+		}
+	}
+	return false
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
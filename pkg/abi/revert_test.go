@@ -0,0 +1,89 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const revertTestABI = `[
+	{"type":"error","name":"InvalidReport","inputs":[{"name":"reportId","type":"bytes32"},{"name":"sender","type":"address"}]},
+	{"type":"error","name":"InvalidSelector","inputs":[{"name":"selector","type":"bytes4"}]},
+	{"type":"error","name":"Unauthorized","inputs":[]}
+]`
+
+func TestErrorRegistry(t *testing.T) {
+	t.Parallel()
+	r := NewErrorRegistry()
+	require.NoError(t, r.RegisterABI(revertTestABI))
+	require.NoError(t, r.RegisterABI(revertTestABI), "registering the same errors again is a no-op")
+	assert.Equal(t, 5, r.Len())
+
+	pack := func(t *testing.T, sig string, args ...any) []byte {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for _, errs := range r.errors {
+			for _, e := range errs {
+				if e.Sig == sig {
+					data, err := e.Inputs.Pack(args...)
+					require.NoError(t, err)
+					return append(e.ID[:4:4], data...)
+				}
+			}
+		}
+		t.Fatalf("error %s not registered", sig)
+		return nil
+	}
+
+	t.Run("Error(string)", func(t *testing.T) {
+		decoded, err := r.Decode(pack(t, "Error(string)", "not enough balance"))
+		require.NoError(t, err)
+		assert.Equal(t, "Error", decoded.Name)
+		assert.Equal(t, `Error(string reason: "not enough balance")`, decoded.String())
+	})
+
+	t.Run("Panic(uint256)", func(t *testing.T) {
+		decoded, err := r.Decode(pack(t, "Panic(uint256)", big.NewInt(0x11)))
+		require.NoError(t, err)
+		assert.Equal(t, "Panic(uint256 code: 17 (arithmetic underflow or overflow))", decoded.String())
+
+		decoded, err = r.Decode(pack(t, "Panic(uint256)", big.NewInt(0x99)))
+		require.NoError(t, err)
+		assert.Equal(t, "Panic(uint256 code: 153)", decoded.String())
+	})
+
+	t.Run("custom errors", func(t *testing.T) {
+		reportID := common.HexToHash("0x01")
+		sender := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+		decoded, err := r.Decode(pack(t, "InvalidReport(bytes32,address)", reportID, sender))
+		require.NoError(t, err)
+		assert.Equal(t, "InvalidReport(bytes32,address)", decoded.Signature)
+		require.Len(t, decoded.Args, 2)
+		assert.Equal(t, DecodedArg{Name: "reportId", Type: "bytes32", Value: [32]byte(reportID)}, decoded.Args[0])
+		assert.Equal(t, "InvalidReport(bytes32 reportId: "+reportID.Hex()+", address sender: "+sender.Hex()+")", decoded.String())
+
+		decoded, err = r.Decode(pack(t, "InvalidSelector(bytes4)", [4]byte{0xde, 0xad, 0xbe, 0xef}))
+		require.NoError(t, err)
+		assert.Equal(t, "InvalidSelector(bytes4 selector: 0xdeadbeef)", decoded.String())
+
+		decoded, err = r.Decode(pack(t, "Unauthorized()"))
+		require.NoError(t, err)
+		assert.Equal(t, "Unauthorized()", decoded.String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := r.Decode([]byte{0x01})
+		require.ErrorContains(t, err, "revert data too short")
+
+		_, err = r.Decode([]byte{0xde, 0xad, 0xbe, 0xef})
+		require.ErrorContains(t, err, "unknown error selector 0xdeadbeef")
+
+		_, err = r.Decode(pack(t, "Error(string)", "truncated")[:40])
+		require.ErrorContains(t, err, "failed to unpack Error(string)")
+
+		require.Error(t, r.RegisterABI("not an abi"))
+	})
+}
@@ -2,12 +2,15 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink-framework/metrics"
 	"github.com/smartcontractkit/chainlink-framework/multinode"
 
+	evmabi "github.com/smartcontractkit/chainlink-evm/pkg/abi"
 	evmconfig "github.com/smartcontractkit/chainlink-evm/pkg/config"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
 	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
@@ -82,6 +86,8 @@ type Client interface {
 	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	// BlobBaseFee returns the blob base fee of the next block. It's only supported by chains that activated EIP-4844.
+	BlobBaseFee(ctx context.Context) (*big.Int, error)
 	LatestBlockHeight(ctx context.Context) (*big.Int, error)
 	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (feeHistory *ethereum.FeeHistory, err error)
 
@@ -106,6 +112,8 @@ type chainClient struct {
 	logger       logger.SugaredLogger
 	chainType    chaintype.ChainType
 	clientErrors evmconfig.ClientErrors
+	quorumReads  quorumReadConfig
+	multicall    *multicallBatcher // nil if disabled
 }
 
 func NewChainClient(
@@ -119,6 +127,9 @@ func NewChainClient(
 	clientErrors evmconfig.ClientErrors,
 	deathDeclarationDelay time.Duration,
 	chainType chaintype.ChainType,
+	quorumReads evmconfig.QuorumReads,
+	multicall evmconfig.Multicall,
+	revertErrors *evmabi.ErrorRegistry,
 ) Client {
 	chainFamily := "EVM"
 	multiNode := multinode.NewMultiNode[*big.Int, *RPCClient](
@@ -143,16 +154,33 @@ func NewChainClient(
 		0, // use the default value provided by the implementation
 	)
 
-	return &chainClient{
+	c := &chainClient{
 		multiNode:    multiNode,
 		txSender:     txSender,
 		logger:       logger.Sugared(lggr),
 		chainType:    chainType,
 		clientErrors: clientErrors,
+		quorumReads:  newQuorumReadConfig(quorumReads),
 	}
+	c.multicall = newMulticallBatcher(multicall, c.logger, chainID, revertErrors, c.callContract)
+	return c
 }
 
 func (c *chainClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if nodes := c.quorumReadNodes(ctx, "BalanceAt"); nodes > 0 {
+		return quorumRead(ctx, c, "BalanceAt", nodes, blockNumber, isLatestBlock(blockNumber), func(ctx context.Context, r *RPCClient, blockNumber *big.Int) (*big.Int, error) {
+			return r.BalanceAt(ctx, account, blockNumber)
+		}, bigKey)
+	}
+	if c.multicall != nil {
+		data, err := c.multicall.read(ctx, c.multicall.address, getEthBalanceCallData(account), blockNumber)
+		if !errors.Is(err, errNotBatched) {
+			if err != nil {
+				return nil, err
+			}
+			return new(big.Int).SetBytes(data), nil
+		}
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return nil, err
@@ -247,6 +275,22 @@ func (c *chainClient) CallContext(ctx context.Context, result interface{}, metho
 }
 
 func (c *chainClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if nodes := c.quorumReadNodes(ctx, "CallContract"); nodes > 0 {
+		return quorumRead(ctx, c, "CallContract", nodes, blockNumber, isLatestBlock(blockNumber), func(ctx context.Context, r *RPCClient, blockNumber *big.Int) ([]byte, error) {
+			return r.CallContract(ctx, msg, blockNumber)
+		}, bytesKey)
+	}
+	if c.multicall.batchable(msg) {
+		data, err := c.multicall.read(ctx, *msg.To, msg.Data, blockNumber)
+		if !errors.Is(err, errNotBatched) {
+			return data, err
+		}
+	}
+	return c.callContract(ctx, msg, blockNumber)
+}
+
+// callContract sends the call to the selected RPC, without batching.
+func (c *chainClient) callContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return nil, err
@@ -268,6 +312,11 @@ func (c *chainClient) Close() {
 }
 
 func (c *chainClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if nodes := c.quorumReadNodes(ctx, "CodeAt"); nodes > 0 {
+		return quorumRead(ctx, c, "CodeAt", nodes, blockNumber, isLatestBlock(blockNumber), func(ctx context.Context, r *RPCClient, blockNumber *big.Int) ([]byte, error) {
+			return r.CodeAt(ctx, account, blockNumber)
+		}, bytesKey)
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return nil, err
@@ -294,7 +343,16 @@ func (c *chainClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (u
 	}
 	return r.EstimateGas(ctx, call)
 }
+
 func (c *chainClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if nodes := c.quorumReadNodes(ctx, "FilterLogs"); nodes > 0 {
+		pin := q.BlockHash == nil && isLatestBlock(q.ToBlock)
+		return quorumRead(ctx, c, "FilterLogs", nodes, q.ToBlock, pin, func(ctx context.Context, r *RPCClient, toBlock *big.Int) ([]types.Log, error) {
+			q := q
+			q.ToBlock = toBlock
+			return r.FilterEvents(ctx, q)
+		}, logsKey)
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return nil, err
@@ -303,6 +361,11 @@ func (c *chainClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([
 }
 
 func (c *chainClient) HeaderByHash(ctx context.Context, h common.Hash) (head *types.Header, err error) {
+	if nodes := c.quorumReadNodes(ctx, "HeaderByHash"); nodes > 0 {
+		return quorumRead(ctx, c, "HeaderByHash", nodes, nil, false, func(ctx context.Context, r *RPCClient, _ *big.Int) (*types.Header, error) {
+			return r.HeaderByHash(ctx, h)
+		}, headerKey)
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return head, err
@@ -311,6 +374,11 @@ func (c *chainClient) HeaderByHash(ctx context.Context, h common.Hash) (head *ty
 }
 
 func (c *chainClient) HeaderByNumber(ctx context.Context, n *big.Int) (head *types.Header, err error) {
+	if nodes := c.quorumReadNodes(ctx, "HeaderByNumber"); nodes > 0 {
+		return quorumRead(ctx, c, "HeaderByNumber", nodes, n, isLatestBlock(n), func(ctx context.Context, r *RPCClient, n *big.Int) (*types.Header, error) {
+			return r.HeaderByNumber(ctx, n)
+		}, headerKey)
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return head, err
@@ -339,6 +407,13 @@ func (c *chainClient) IsL2() bool {
 }
 
 func (c *chainClient) LINKBalance(ctx context.Context, address common.Address, linkAddress common.Address) (*commonassets.Link, error) {
+	if c.multicall != nil {
+		balance, err := c.TokenBalance(ctx, address, linkAddress)
+		if err != nil {
+			return commonassets.NewLinkFromJuels(0), err
+		}
+		return (*commonassets.Link)(balance), nil
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return nil, err
@@ -396,6 +471,11 @@ func (c *chainClient) SendTransactionReturnCode(ctx context.Context, tx *types.T
 }
 
 func (c *chainClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if nodes := c.quorumReadNodes(ctx, "NonceAt"); nodes > 0 {
+		return quorumRead(ctx, c, "NonceAt", nodes, blockNumber, isLatestBlock(blockNumber), func(ctx context.Context, r *RPCClient, blockNumber *big.Int) (uint64, error) {
+			return r.NonceAt(ctx, account, blockNumber)
+		}, uint64Key)
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return 0, err
@@ -441,7 +521,27 @@ func (c *chainClient) SuggestGasTipCap(ctx context.Context) (t *big.Int, err err
 	return r.SuggestGasTipCap(ctx)
 }
 
+func (c *chainClient) BlobBaseFee(ctx context.Context) (f *big.Int, err error) {
+	r, err := c.multiNode.SelectRPC(ctx)
+	if err != nil {
+		return f, err
+	}
+	return r.BlobBaseFee(ctx)
+}
+
 func (c *chainClient) TokenBalance(ctx context.Context, address common.Address, contractAddress common.Address) (*big.Int, error) {
+	if c.multicall != nil {
+		data, err := c.multicall.read(ctx, contractAddress, balanceOfCallData(address), nil)
+		if !errors.Is(err, errNotBatched) {
+			if err != nil {
+				return nil, err
+			}
+			if len(data) == 0 {
+				return nil, fmt.Errorf("failed to parse int: %s", hexutil.Encode(data))
+			}
+			return new(big.Int).SetBytes(data), nil
+		}
+	}
 	r, err := c.multiNode.SelectRPC(ctx)
 	if err != nil {
 		return nil, err
@@ -9,11 +9,13 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -26,6 +28,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/chainlink-framework/multinode"
 
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/multicall3"
 	"github.com/smartcontractkit/chainlink-evm/pkg/client"
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
 	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
@@ -862,3 +865,355 @@ func TestEthClient_ErroringClient(t *testing.T) {
 }
 
 const headResult = client.HeadResult
+
+func TestEthClient_QuorumReads(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, callResults []string, quorumReads client.TestQuorumReads) (client.Client, <-chan string) {
+		blocks := make(chan string, 10)
+		var wsURLs []string
+		for _, callResult := range callResults {
+			wsURLs = append(wsURLs, testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+				switch method {
+				case "eth_subscribe":
+					resp.Result = `"0x00"`
+					resp.Notify = headResult
+				case "eth_unsubscribe":
+					resp.Result = "true"
+				case "eth_blockNumber":
+					resp.Result = `"0x1"`
+				case "eth_call":
+					blocks <- params.Array()[1].String()
+					if msg, ok := strings.CutPrefix(callResult, "error:"); ok {
+						resp.Error.Code, resp.Error.Message = 3, msg
+					} else {
+						resp.Result = callResult
+					}
+				}
+				return
+			}).WSURL().String())
+		}
+		cfg := client.TestNodePoolConfig{
+			NodeSelectionMode: multinode.NodeSelectionModeRoundRobin,
+			NodeQuorumReads:   quorumReads,
+		}
+		c := client.NewChainClientWithTestNodes(t, cfg, wsURLs, testutils.FixtureChainID)
+		require.NoError(t, c.Dial(tests.Context(t)))
+		require.Eventually(t, func() bool {
+			for _, state := range c.NodeStates() {
+				if state != "Alive" {
+					return false
+				}
+			}
+			return true
+		}, time.Minute, 100*time.Millisecond, "nodes aren't all alive")
+		return c, blocks
+	}
+	quorumReads := client.TestQuorumReads{NodesVal: 3, MethodsVal: []string{"CallContract"}}
+	msg := ethereum.CallMsg{To: ptr(testutils.NewAddress())}
+
+	t.Run("returns the result of the majority at a pinned block", func(t *testing.T) {
+		c, blocks := setup(t, []string{`"0x01"`, `"0x01"`, `"0x02"`}, quorumReads)
+		result, err := c.CallContract(tests.Context(t), msg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1}, result)
+		for range 3 {
+			assert.Equal(t, "0x1", <-blocks)
+		}
+	})
+
+	t.Run("fails without a majority", func(t *testing.T) {
+		c, _ := setup(t, []string{`"0x01"`, `"0x02"`, `"0x03"`}, quorumReads)
+		_, err := c.CallContract(tests.Context(t), msg, nil)
+		var qErr *client.QuorumDisagreementError
+		require.ErrorAs(t, err, &qErr)
+		assert.Equal(t, "CallContract", qErr.Method)
+		assert.Equal(t, 3, qErr.Nodes)
+		assert.Equal(t, 1, qErr.Agreeing)
+		assert.Equal(t, big.NewInt(1), qErr.BlockNumber)
+	})
+
+	t.Run("returns the error of the majority", func(t *testing.T) {
+		c, _ := setup(t, []string{`error:execution reverted`, `"0x01"`, `error:execution reverted`}, quorumReads)
+		_, err := c.CallContract(tests.Context(t), msg, nil)
+		var rpcErr rpc.Error
+		require.ErrorAs(t, err, &rpcErr)
+		assert.Equal(t, 3, rpcErr.ErrorCode())
+		assert.Equal(t, "execution reverted", rpcErr.Error())
+		var qErr *client.QuorumDisagreementError
+		assert.False(t, errors.As(err, &qErr))
+	})
+
+	t.Run("fails without a majority of results or errors", func(t *testing.T) {
+		c, _ := setup(t, []string{`error:execution reverted`, `"0x01"`, `error:header not found`}, quorumReads)
+		_, err := c.CallContract(tests.Context(t), msg, nil)
+		var qErr *client.QuorumDisagreementError
+		require.ErrorAs(t, err, &qErr)
+		assert.Equal(t, 1, qErr.Agreeing)
+		assert.Len(t, qErr.Errors, 2)
+	})
+
+	t.Run("disabled per call", func(t *testing.T) {
+		c, blocks := setup(t, []string{`"0x01"`, `"0x02"`, `"0x03"`}, quorumReads)
+		_, err := c.CallContract(client.WithQuorumRead(tests.Context(t), 0), msg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "latest", <-blocks)
+		assert.Empty(t, blocks)
+	})
+
+	t.Run("enabled per call", func(t *testing.T) {
+		c, blocks := setup(t, []string{`"0x01"`, `"0x02"`, `"0x02"`}, client.TestQuorumReads{})
+		result, err := c.CallContract(client.WithQuorumRead(tests.Context(t), 3), msg, big.NewInt(5))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{2}, result)
+		for range 3 {
+			assert.Equal(t, "0x5", <-blocks)
+		}
+	})
+}
+
+func TestEthClient_Multicall(t *testing.T) {
+	t.Parallel()
+
+	multicallAddr := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+	token := testutils.NewAddress()
+	reverting := testutils.NewAddress()
+	outOfGas := testutils.NewAddress()
+	multicallABI, err := multicall3.Multicall3MetaData.GetAbi()
+	require.NoError(t, err)
+	uint256 := func(n int64) []byte { return common.LeftPadBytes(big.NewInt(n).Bytes(), 32) }
+	revertData, err := utils.ABIEncode(`[{"type":"string"}]`, "nope")
+	require.NoError(t, err)
+	revertData = append(hexutil.MustDecode("0x08c379a0"), revertData...)
+
+	// aggregate3 serves the calls of a batch: getEthBalance returns 1000, the reverting target reverts, the outOfGas
+	// target fails without data, and others return 42.
+	aggregate3 := func(t *testing.T, data []byte) string {
+		in, err := multicallABI.Methods["aggregate3"].Inputs.Unpack(data[4:])
+		require.NoError(t, err)
+		calls := *abi.ConvertType(in[0], new([]multicall3.Multicall3Call3)).(*[]multicall3.Multicall3Call3)
+		results := make([]multicall3.Multicall3Result, len(calls))
+		for i, call := range calls {
+			switch call.Target {
+			case multicallAddr:
+				results[i] = multicall3.Multicall3Result{Success: true, ReturnData: uint256(1000)}
+			case reverting:
+				results[i] = multicall3.Multicall3Result{Success: false, ReturnData: revertData}
+			case outOfGas:
+				results[i] = multicall3.Multicall3Result{Success: false, ReturnData: []byte{}}
+			default:
+				results[i] = multicall3.Multicall3Result{Success: true, ReturnData: uint256(42)}
+			}
+		}
+		out, err := multicallABI.Methods["aggregate3"].Outputs.Pack(results)
+		require.NoError(t, err)
+		return `"` + hexutil.Encode(out) + `"`
+	}
+
+	type call struct {
+		to  common.Address
+		gas string
+	}
+	setup := func(t *testing.T, deployed bool) (client.Client, <-chan call) {
+		calls := make(chan call, 100)
+		wsURL := testutils.NewWSServer(t, testutils.FixtureChainID, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+			switch method {
+			case "eth_subscribe":
+				resp.Result = `"0x00"`
+				resp.Notify = headResult
+			case "eth_unsubscribe":
+				resp.Result = "true"
+			case "eth_call":
+				arg := params.Array()[0]
+				to := common.HexToAddress(arg.Get("to").String())
+				calls <- call{to: to, gas: arg.Get("gas").String()}
+				data := arg.Get("input").String()
+				if data == "" {
+					data = arg.Get("data").String()
+				}
+				switch {
+				case to == multicallAddr && deployed && params.Array()[1].String() != "0x1": // deployed after block 1
+					resp.Result = aggregate3(t, hexutil.MustDecode(data))
+				case to == multicallAddr:
+					resp.Result = `"0x"`
+				default:
+					resp.Result = `"0x000000000000000000000000000000000000000000000000000000000000002a"`
+				}
+			case "eth_getBalance":
+				resp.Result = `"0x3e8"`
+			}
+			return
+		}).WSURL().String()
+		cfg := client.TestNodePoolConfig{
+			NodeSelectionMode: multinode.NodeSelectionModeRoundRobin,
+			NodeMulticall: client.TestMulticall{
+				EnabledVal:      true,
+				AddressVal:      multicallAddr,
+				BatchWindowVal:  100 * time.Millisecond,
+				MaxBatchSizeVal: 10,
+				GasCapVal:       1_000_000,
+			},
+		}
+		c := client.NewChainClientWithTestNodes(t, cfg, []string{wsURL}, testutils.FixtureChainID)
+		require.NoError(t, c.Dial(tests.Context(t)))
+		require.Eventually(t, func() bool {
+			return c.NodeStates()["eth-primary-node-0"] == "Alive"
+		}, time.Minute, 100*time.Millisecond, "node isn't alive")
+		return c, calls
+	}
+	// readConcurrently reads the balances of account, and calls token and the reverting contract concurrently.
+	readConcurrently := func(t *testing.T, c client.Client) {
+		ctx := tests.Context(t)
+		account := testutils.NewAddress()
+		var wg sync.WaitGroup
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			balance, err := c.BalanceAt(ctx, account, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, big.NewInt(1000), balance)
+		}()
+		go func() {
+			defer wg.Done()
+			balance, err := c.TokenBalance(ctx, account, token)
+			assert.NoError(t, err)
+			assert.Equal(t, big.NewInt(42), balance)
+		}()
+		go func() {
+			defer wg.Done()
+			result, err := c.CallContract(ctx, ethereum.CallMsg{To: &token, Data: []byte{1}}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, uint256(42), result)
+		}()
+		go func() {
+			defer wg.Done()
+			result, err := c.CallContract(ctx, ethereum.CallMsg{To: &reverting, Data: []byte{1}}, nil)
+			assert.NoError(t, err, "the server only reverts in batches")
+			assert.Equal(t, uint256(42), result)
+		}()
+		wg.Wait()
+	}
+
+	t.Run("batches concurrent reads", func(t *testing.T) {
+		c, calls := setup(t, true)
+		ctx := tests.Context(t)
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			balance, err := c.BalanceAt(ctx, testutils.NewAddress(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, big.NewInt(1000), balance)
+		}()
+		go func() {
+			defer wg.Done()
+			balance, err := c.LINKBalance(ctx, testutils.NewAddress(), token)
+			assert.NoError(t, err)
+			assert.Equal(t, "42", balance.ToInt().String())
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := c.CallContract(ctx, ethereum.CallMsg{To: &reverting, Data: []byte{1}}, nil)
+			assert.ErrorContains(t, err, `execution reverted: Error(string reason: "nope")`)
+			assert.NotNil(t, client.RevertReason(err))
+		}()
+		wg.Wait()
+
+		require.Len(t, calls, 1)
+		batch := <-calls
+		assert.Equal(t, multicallAddr, batch.to)
+		assert.Equal(t, "0xf4240", batch.gas)
+	})
+
+	t.Run("retries reads failing without data individually", func(t *testing.T) {
+		c, calls := setup(t, true)
+		ctx := tests.Context(t)
+		var wg sync.WaitGroup
+		for _, to := range []common.Address{token, outOfGas} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := c.CallContract(ctx, ethereum.CallMsg{To: &to, Data: []byte{1}}, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, uint256(42), result)
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, calls, 2)
+		assert.Equal(t, multicallAddr, (<-calls).to)
+		assert.Equal(t, outOfGas, (<-calls).to)
+	})
+
+	t.Run("falls back for blocks before Multicall3", func(t *testing.T) {
+		c, calls := setup(t, true)
+		callConcurrently := func(t *testing.T, blockNumber *big.Int) {
+			var wg sync.WaitGroup
+			for range 2 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					result, err := c.CallContract(tests.Context(t), ethereum.CallMsg{To: &token, Data: []byte{1}}, blockNumber)
+					assert.NoError(t, err)
+					assert.Equal(t, uint256(42), result)
+				}()
+			}
+			wg.Wait()
+		}
+
+		callConcurrently(t, big.NewInt(1))
+		require.Len(t, calls, 3)
+		assert.Equal(t, multicallAddr, (<-calls).to)
+		assert.Equal(t, token, (<-calls).to)
+		assert.Equal(t, token, (<-calls).to)
+
+		// Not batched anymore at block 1, but still at later blocks.
+		callConcurrently(t, big.NewInt(1))
+		require.Len(t, calls, 2)
+		assert.Equal(t, token, (<-calls).to)
+		assert.Equal(t, token, (<-calls).to)
+		callConcurrently(t, nil)
+		require.Len(t, calls, 1)
+		assert.Equal(t, multicallAddr, (<-calls).to)
+	})
+
+	t.Run("sends single and non batchable reads individually", func(t *testing.T) {
+		c, calls := setup(t, true)
+		ctx := tests.Context(t)
+		result, err := c.CallContract(ctx, ethereum.CallMsg{To: &token}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint256(42), result)
+		assert.Equal(t, token, (<-calls).to)
+
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.CallContract(ctx, ethereum.CallMsg{From: testutils.NewAddress(), To: &token}, nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		require.Len(t, calls, 2)
+		assert.Equal(t, token, (<-calls).to)
+		assert.Equal(t, token, (<-calls).to)
+	})
+
+	t.Run("falls back without Multicall3", func(t *testing.T) {
+		c, calls := setup(t, false)
+		readConcurrently(t, c)
+		assert.Equal(t, multicallAddr, (<-calls).to)
+		assert.Len(t, calls, 3, "reads sent individually, BalanceAt with eth_getBalance")
+
+		// Not batched anymore.
+		for len(calls) > 0 {
+			<-calls
+		}
+		readConcurrently(t, c)
+		require.Len(t, calls, 3)
+		for range 3 {
+			assert.NotEqual(t, multicallAddr, (<-calls).to)
+		}
+	})
+}
@@ -193,6 +193,64 @@ func (_c *Client_BatchCallContextAll_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// BlobBaseFee provides a mock function with given fields: ctx
+func (_m *Client) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlobBaseFee")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*big.Int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_BlobBaseFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlobBaseFee'
+type Client_BlobBaseFee_Call struct {
+	*mock.Call
+}
+
+// BlobBaseFee is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) BlobBaseFee(ctx interface{}) *Client_BlobBaseFee_Call {
+	return &Client_BlobBaseFee_Call{Call: _e.mock.On("BlobBaseFee", ctx)}
+}
+
+func (_c *Client_BlobBaseFee_Call) Run(run func(ctx context.Context)) *Client_BlobBaseFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_BlobBaseFee_Call) Return(_a0 *big.Int, _a1 error) *Client_BlobBaseFee_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_BlobBaseFee_Call) RunAndReturn(run func(context.Context) (*big.Int, error)) *Client_BlobBaseFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BlockByHash provides a mock function with given fields: ctx, hash
 func (_m *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
 	ret := _m.Called(ctx, hash)
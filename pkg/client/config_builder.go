@@ -49,6 +49,7 @@ func NewClientConfigs(
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	defaults := toml.Defaults(nil)
 	nodePool := toml.NodePool{
 		SelectionMode:              selectionMode,
 		LeaseDuration:              commonconfig.MustNewDuration(leaseDuration),
@@ -60,6 +61,9 @@ func NewClientConfigs(
 		DeathDeclarationDelay:      commonconfig.MustNewDuration(deathDeclarationDelay),
 		FinalizedBlockPollInterval: commonconfig.MustNewDuration(finalizedBlockPollInterval),
 		NewHeadsPollInterval:       commonconfig.MustNewDuration(newHeadsPollInterval),
+		QuorumReads:                defaults.NodePool.QuorumReads,
+		Multicall:                  defaults.NodePool.Multicall,
+		ResponseCache:              defaults.NodePool.ResponseCache,
 	}
 	nodePoolCfg := &evmconfig.NodePoolConfig{C: nodePool}
 	chainConfig := &evmconfig.EVMConfig{
@@ -55,6 +55,9 @@ func TestClientConfigBuilder(t *testing.T) {
 	require.Equal(t, deathDeclarationDelay, nodePool.DeathDeclarationDelay())
 	require.Equal(t, pollInterval, nodePool.FinalizedBlockPollInterval())
 	require.Equal(t, newHeadsPollInterval, nodePool.NewHeadsPollInterval())
+	require.Zero(t, nodePool.QuorumReads().Nodes())
+	require.False(t, nodePool.Multicall().Enabled())
+	require.False(t, nodePool.ResponseCache().Enabled())
 
 	// Validate node configs
 	require.Equal(t, *nodeConfigs[0].Name, *nodes[0].Name)
@@ -16,6 +16,7 @@ import (
 	commontypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 	"github.com/smartcontractkit/chainlink-framework/multinode"
 
+	evmabi "github.com/smartcontractkit/chainlink-evm/pkg/abi"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config"
 	"github.com/smartcontractkit/chainlink-evm/pkg/label"
 )
@@ -103,13 +104,14 @@ var parity = ClientErrors{
 
 // Geth
 // See: https://github.com/ethereum/go-ethereum/blob/b9df7ecdc3d3685180ceb29665bab59e9f614da5/core/tx_pool.go#L516
-var gethFatal = regexp.MustCompile(`(: |^)(exceeds block gas limit|invalid sender|negative value|oversized data|gas uint64 overflow|intrinsic gas too low)$`)
+// Blob pool errors: https://github.com/ethereum/go-ethereum/blob/v1.15.3/core/txpool/validation.go#L133
+var gethFatal = regexp.MustCompile(`(: |^)(exceeds block gas limit|invalid sender|negative value|oversized data|gas uint64 overflow|intrinsic gas too low|missing sidecar in blob transaction|blobless blob transaction|too many blobs in transaction: have [0-9]+, permitted [0-9]+)$`)
 var geth = ClientErrors{
 	NonceTooLow:                       regexp.MustCompile(`(: |^)nonce too low$`),
 	NonceTooHigh:                      regexp.MustCompile(`(: |^)nonce too high$`),
-	ReplacementTransactionUnderpriced: regexp.MustCompile(`(: |^)replacement transaction underpriced$`),
+	ReplacementTransactionUnderpriced: regexp.MustCompile(`(: |^)replacement transaction underpriced(: new tx (gas fee cap|gas tip cap|blob gas fee cap) [0-9]+ <=? [0-9]+ queued( \+ [0-9]+% replacement penalty)?)?$`),
 	TransactionAlreadyInMempool:       regexp.MustCompile(`(: |^)(?i)(known transaction|already known)`),
-	TerminallyUnderpriced:             regexp.MustCompile(`(: |^)transaction underpriced$`),
+	TerminallyUnderpriced:             regexp.MustCompile(`(: |^)transaction underpriced(: (gas tip cap|blob fee cap) [0-9]+, minimum needed [0-9]+)?$`),
 	InsufficientEth:                   regexp.MustCompile(`(: |^)(insufficient funds for transfer|insufficient funds for gas \* price \+ value|insufficient balance for transfer|transaction would cause overdraft)$`),
 	TxFeeExceedsCap:                   regexp.MustCompile(`(: |^)tx fee \([0-9\.]+ [a-zA-Z]+\) exceeds the configured cap \([0-9\.]+ [a-zA-Z]+\)$`),
 	Fatal:                             gethFatal,
@@ -433,6 +435,15 @@ func (s *SendError) IsFatal() bool {
 	return s.IsTerminallyStuckConfigError(nil)
 }
 
+// RevertReason returns the decoded revert reason of the transaction, or nil if it didn't revert or the revert data
+// couldn't be decoded.
+func (s *SendError) RevertReason() *evmabi.DecodedError {
+	if s == nil {
+		return nil
+	}
+	return RevertReason(s.err)
+}
+
 // IsTimeout indicates if the error was caused by an exceeded context deadline
 func (s *SendError) IsTimeout() bool {
 	if s == nil {
@@ -459,7 +470,7 @@ func NewFatalSendError(e error) *SendError {
 	if e == nil {
 		return nil
 	}
-	return &SendError{err: pkgerrors.WithStack(e), fatal: true}
+	return &SendError{err: pkgerrors.WithStack(DecodeRevert(e, nil)), fatal: true}
 }
 
 func NewSendErrorS(s string) *SendError {
@@ -471,7 +482,7 @@ func NewSendError(e error) *SendError {
 		return nil
 	}
 	fatal := isFatalSendError(e)
-	return &SendError{err: pkgerrors.WithStack(e), fatal: fatal}
+	return &SendError{err: pkgerrors.WithStack(DecodeRevert(e, nil)), fatal: fatal}
 }
 
 func NewTxError(e error) commontypes.ErrorClassifier {
@@ -7,12 +7,18 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-framework/multinode"
 
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/forwarder"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/reverts"
 	evmclient "github.com/smartcontractkit/chainlink-evm/pkg/client"
+	evmutils "github.com/smartcontractkit/chainlink-evm/pkg/utils"
 )
 
 func newSendErrorWrapped(s string) *evmclient.SendError {
@@ -104,6 +110,8 @@ func Test_Eth_Errors(t *testing.T) {
 	t.Run("IsReplacementUnderpriced", func(t *testing.T) {
 		tests := []errorCase{
 			{"replacement transaction underpriced", true, "geth"},
+			{"replacement transaction underpriced: new tx blob gas fee cap 10 < 12 queued + 100% replacement penalty", true, "geth"},
+			{"replacement transaction underpriced: new tx gas tip cap 1 <= 1 queued", true, "geth"},
 			{"Replacement transaction underpriced", true, "Besu"},
 			{"replacement transaction underpriced", true, "Erigon"},
 			{"replacement transaction underpriced", true, "Klaytn"},
@@ -163,7 +171,9 @@ func Test_Eth_Errors(t *testing.T) {
 
 		tests := []errorCase{
 			{"transaction underpriced", true, "geth"},
+			{"transaction underpriced: blob fee cap 0, minimum needed 1", true, "geth"},
 			{"replacement transaction underpriced", false, "geth"},
+			{"replacement transaction underpriced: new tx blob gas fee cap 10 < 12 queued + 100% replacement penalty", false, "geth"},
 			{"Gas price below configured minimum gas price", true, "Besu"},
 			{"transaction underpriced", true, "Erigon"},
 			{"There are too many transactions in the queue. Your transaction was dropped due to limit. Try increasing the fee.", false, "Parity"},
@@ -388,6 +398,9 @@ func Test_Eth_Errors_Fatal(t *testing.T) {
 		{"oversized data", true, "Geth"},
 		{"gas uint64 overflow", true, "Geth"},
 		{"intrinsic gas too low", true, "Geth"},
+		{"missing sidecar in blob transaction", true, "Geth"},
+		{"blobless blob transaction", true, "Geth"},
+		{"too many blobs in transaction: have 10, permitted 9", true, "Geth"},
 
 		{"Intrinsic gas exceeds gas limit", true, "Besu"},
 		{"Transaction gas limit exceeds block gas limit", true, "Besu"},
@@ -575,3 +588,71 @@ func Test_IsTooManyResultsError(t *testing.T) {
 		assert.True(t, evmclient.IsTooManyResults(context.DeadlineExceeded, nil))
 	})
 }
+
+func Test_DecodeRevert(t *testing.T) {
+	t.Parallel()
+
+	forwarderABI, err := forwarder.KeystoneForwarderMetaData.GetAbi()
+	require.NoError(t, err)
+	alreadyAttempted := forwarderABI.Errors["AlreadyAttempted"]
+	args, err := alreadyAttempted.Inputs.Pack(common.HexToHash("0x1234"))
+	require.NoError(t, err)
+	customErrorData := hexutil.Encode(append(alreadyAttempted.ID[:4:4], args...))
+	reason := "AlreadyAttempted(bytes32 transmissionId: 0x0000000000000000000000000000000000000000000000000000000000001234)"
+
+	errorString, err := evmutils.ABIEncode(`[{"type":"string"}]`, "not enough balance")
+	require.NoError(t, err)
+	errorStringData := hexutil.Encode(append(hexutil.MustDecode("0x08c379a0"), errorString...))
+
+	t.Run("custom error", func(t *testing.T) {
+		rpcErr := &evmclient.JsonError{Code: 3, Message: "execution reverted", Data: customErrorData}
+		err := evmclient.DecodeRevert(pkgerrors.Wrap(rpcErr, "call failed"), reverts.Registry())
+		assert.Equal(t, "call failed: execution reverted: "+reason, err.Error())
+		require.NotNil(t, evmclient.RevertReason(err))
+		assert.Equal(t, "AlreadyAttempted", evmclient.RevertReason(err).Name)
+		assert.Equal(t, err, evmclient.DecodeRevert(err, reverts.Registry()), "decoded once")
+
+		assert.Equal(t, rpcErr, evmclient.DecodeRevert(rpcErr, nil), "custom errors aren't decoded by default")
+
+		jErr, eErr := evmclient.ExtractRPCError(err)
+		require.NoError(t, eErr)
+		assert.Equal(t, customErrorData, jErr.Data)
+	})
+
+	t.Run("parity revert data", func(t *testing.T) {
+		rpcErr := &evmclient.JsonError{Code: -32015, Message: "VM execution error.", Data: "Reverted " + errorStringData}
+		err := evmclient.DecodeRevert(rpcErr, nil)
+		assert.Equal(t, `VM execution error.: Error(string reason: "not enough balance")`, err.Error())
+	})
+
+	t.Run("geth revert reason", func(t *testing.T) {
+		rpcErr := &evmclient.JsonError{Code: 3, Message: "execution reverted: not enough balance", Data: errorStringData}
+		err := evmclient.DecodeRevert(rpcErr, nil)
+		assert.Equal(t, "execution reverted: not enough balance", err.Error())
+		require.NotNil(t, evmclient.RevertReason(err))
+		assert.Equal(t, "Error", evmclient.RevertReason(err).Name)
+	})
+
+	t.Run("not decoded", func(t *testing.T) {
+		for _, rpcErr := range []error{
+			errors.New("execution reverted"),
+			&evmclient.JsonError{Code: 3, Message: "execution reverted", Data: "0xdeadbeef"},
+			&evmclient.JsonError{Code: 3, Message: "execution reverted", Data: map[string]any{"foo": "bar"}},
+		} {
+			err := evmclient.DecodeRevert(rpcErr, reverts.Registry())
+			assert.Equal(t, rpcErr, err)
+			assert.Nil(t, evmclient.RevertReason(err))
+		}
+		assert.NoError(t, evmclient.DecodeRevert(nil, nil))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		sendErr := evmclient.NewSendError(evmclient.DecodeRevert(&evmclient.JsonError{Code: 3, Message: "execution reverted", Data: customErrorData}, reverts.Registry()))
+		assert.Equal(t, "execution reverted: "+reason, sendErr.Error())
+		require.NotNil(t, sendErr.RevertReason())
+		assert.Equal(t, "AlreadyAttempted(bytes32)", sendErr.RevertReason().Signature)
+
+		assert.Nil(t, evmclient.NewSendErrorS("nonce too low").RevertReason())
+		assert.Nil(t, (*evmclient.SendError)(nil).RevertReason())
+	})
+}
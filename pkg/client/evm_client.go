@@ -10,6 +10,7 @@ import (
 	"github.com/smartcontractkit/chainlink-framework/metrics"
 	"github.com/smartcontractkit/chainlink-framework/multinode"
 
+	evmabi "github.com/smartcontractkit/chainlink-evm/pkg/abi"
 	evmconfig "github.com/smartcontractkit/chainlink-evm/pkg/config"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
@@ -17,7 +18,39 @@ import (
 
 const QueryTimeout = 10 * time.Second
 
-func NewEvmClient(cfg evmconfig.NodePool, chainCfg multinode.ChainConfig, clientErrors evmconfig.ClientErrors, lggr logger.Logger, chainID *big.Int, nodes []*toml.Node, chainType chaintype.ChainType) (Client, error) {
+// ClientOpt is an option of NewEvmClient and NewRPCClient.
+type ClientOpt func(*clientOpts)
+
+type clientOpts struct {
+	revertErrors *evmabi.ErrorRegistry
+	finality     multinode.ChainConfig
+}
+
+func newClientOpts(opts []ClientOpt) (o clientOpts) {
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return
+}
+
+// WithRevertErrors decodes the revert data of failed calls with errs, e.g. reverts.Registry() to decode the custom errors
+// of all the generated contract wrappers. By default, only Error(string) and Panic(uint256) are decoded.
+func WithRevertErrors(errs *evmabi.ErrorRegistry) ClientOpt {
+	return func(o *clientOpts) {
+		o.revertErrors = errs
+	}
+}
+
+// WithFinality makes RPCClient determine the finalized blocks whose responses are cached like the HeadTracker does
+// with chainCfg: from the finalized tag only if FinalityTagEnabled, otherwise FinalityDepth blocks below the latest
+// block. By default, the finalized tag is used. NewEvmClient always passes its chainCfg.
+func WithFinality(chainCfg multinode.ChainConfig) ClientOpt {
+	return func(o *clientOpts) {
+		o.finality = chainCfg
+	}
+}
+
+func NewEvmClient(cfg evmconfig.NodePool, chainCfg multinode.ChainConfig, clientErrors evmconfig.ClientErrors, lggr logger.Logger, chainID *big.Int, nodes []*toml.Node, chainType chaintype.ChainType, opts ...ClientOpt) (Client, error) {
 	var primaries []multinode.Node[*big.Int, *RPCClient]
 	var sendonlys []multinode.SendOnlyNode[*big.Int, *RPCClient]
 	largePayloadRPCTimeout, defaultRPCTimeout := getRPCTimeouts(chainType)
@@ -27,16 +60,17 @@ func NewEvmClient(cfg evmconfig.NodePool, chainCfg multinode.ChainConfig, client
 		return nil, fmt.Errorf("failed to initialize metrics: %w", err)
 	}
 
+	rpcOpts := append([]ClientOpt{WithFinality(chainCfg)}, opts...)
 	for i, node := range nodes {
 		if node.SendOnly != nil && *node.SendOnly {
 			rpc := NewRPCClient(cfg, lggr, nil, node.HTTPURL.URL(), *node.Name, i, chainID,
-				multinode.Secondary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
+				multinode.Secondary, largePayloadRPCTimeout, defaultRPCTimeout, chainType, rpcOpts...)
 			sendonly := multinode.NewSendOnlyNode(lggr, multiNodeMetrics, (url.URL)(*node.HTTPURL),
 				*node.Name, chainID, rpc)
 			sendonlys = append(sendonlys, sendonly)
 		} else {
 			rpc := NewRPCClient(cfg, lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i,
-				chainID, multinode.Primary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
+				chainID, multinode.Primary, largePayloadRPCTimeout, defaultRPCTimeout, chainType, rpcOpts...)
 
 			primaryNode := multinode.NewNode(cfg, chainCfg,
 				lggr, multiNodeMetrics, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i, chainID, *node.Order,
@@ -46,7 +80,7 @@ func NewEvmClient(cfg evmconfig.NodePool, chainCfg multinode.ChainConfig, client
 	}
 
 	return NewChainClient(lggr, multiNodeMetrics, cfg.SelectionMode(), cfg.LeaseDuration(),
-		primaries, sendonlys, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType), nil
+		primaries, sendonlys, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType, cfg.QuorumReads(), cfg.Multicall(), newClientOpts(opts).revertErrors), nil
 }
 
 func getRPCTimeouts(chainType chaintype.ChainType) (largePayload, defaultTimeout time.Duration) {
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
@@ -96,8 +97,43 @@ type TestNodePoolConfig struct {
 	EnforceRepeatableReadVal       bool
 	NodeDeathDeclarationDelay      time.Duration
 	NodeNewHeadsPollInterval       time.Duration
+	NodeQuorumReads                config.QuorumReads
+	NodeMulticall                  config.Multicall
+	NodeResponseCache              config.ResponseCache
 }
 
+type TestQuorumReads struct {
+	NodesVal   uint32
+	MethodsVal []string
+}
+
+func (q TestQuorumReads) Nodes() uint32     { return q.NodesVal }
+func (q TestQuorumReads) Methods() []string { return q.MethodsVal }
+
+type TestMulticall struct {
+	EnabledVal      bool
+	AddressVal      common.Address
+	BatchWindowVal  time.Duration
+	MaxBatchSizeVal uint32
+	GasCapVal       uint64
+}
+
+func (m TestMulticall) Enabled() bool              { return m.EnabledVal }
+func (m TestMulticall) Address() common.Address    { return m.AddressVal }
+func (m TestMulticall) BatchWindow() time.Duration { return m.BatchWindowVal }
+func (m TestMulticall) MaxBatchSize() uint32       { return m.MaxBatchSizeVal }
+func (m TestMulticall) GasCap() uint64             { return m.GasCapVal }
+
+type TestResponseCache struct {
+	EnabledVal         bool
+	SizeVal            uint32
+	DisabledMethodsVal []string
+}
+
+func (r TestResponseCache) Enabled() bool             { return r.EnabledVal }
+func (r TestResponseCache) Size() uint32              { return r.SizeVal }
+func (r TestResponseCache) DisabledMethods() []string { return r.DisabledMethodsVal }
+
 func (tc TestNodePoolConfig) PollFailureThreshold() uint32 { return tc.NodePollFailureThreshold }
 func (tc TestNodePoolConfig) PollInterval() time.Duration  { return tc.NodePollInterval }
 func (tc TestNodePoolConfig) SelectionMode() string        { return tc.NodeSelectionMode }
@@ -134,6 +170,18 @@ func (tc TestNodePoolConfig) DeathDeclarationDelay() time.Duration {
 	return tc.NodeDeathDeclarationDelay
 }
 
+func (tc TestNodePoolConfig) QuorumReads() config.QuorumReads {
+	return tc.NodeQuorumReads
+}
+
+func (tc TestNodePoolConfig) Multicall() config.Multicall {
+	return tc.NodeMulticall
+}
+
+func (tc TestNodePoolConfig) ResponseCache() config.ResponseCache {
+	return tc.NodeResponseCache
+}
+
 func NewChainClientWithTestNode(
 	t *testing.T,
 	nodeCfg multinode.NodeConfig,
@@ -179,11 +227,38 @@ func NewChainClientWithTestNode(
 	}
 
 	clientErrors := NewTestClientErrors()
-	c := NewChainClient(lggr, multiNodeMetrics, nodeCfg.SelectionMode(), leaseDuration, primaries, sendonlys, chainID, &clientErrors, 0, "")
+	c := NewChainClient(lggr, multiNodeMetrics, nodeCfg.SelectionMode(), leaseDuration, primaries, sendonlys, chainID, &clientErrors, 0, "", nil, nil, nil)
 	t.Cleanup(c.Close)
 	return c, nil
 }
 
+// NewChainClientWithTestNodes returns a client with a primary node for each of rpcURLs, which must be websocket URLs.
+func NewChainClientWithTestNodes(
+	t *testing.T,
+	nodeCfg TestNodePoolConfig,
+	rpcURLs []string,
+	chainID *big.Int,
+) Client {
+	multiNodeMetrics, err := metrics.NewGenericMultiNodeMetrics("EVM Test", chainID.String())
+	require.NoError(t, err)
+
+	lggr := logger.Test(t)
+	var primaries []multinode.Node[*big.Int, *RPCClient]
+	for i, rpcURL := range rpcURLs {
+		parsed, err := url.ParseRequestURI(rpcURL)
+		require.NoError(t, err)
+		rpc := NewRPCClient(nodeCfg, lggr, parsed, nil, fmt.Sprintf("eth-primary-rpc-%d", i), i, chainID, multinode.Primary, client.QueryTimeout, client.QueryTimeout, "")
+		n := multinode.NewNode[*big.Int, *evmtypes.Head, *RPCClient](
+			nodeCfg, mocks.ChainConfig{}, lggr, multiNodeMetrics, parsed, nil, fmt.Sprintf("eth-primary-node-%d", i), i, chainID, int32(i), rpc, "EVM") //nolint:gosec // G115
+		primaries = append(primaries, n)
+	}
+
+	clientErrors := NewTestClientErrors()
+	c := NewChainClient(lggr, multiNodeMetrics, nodeCfg.SelectionMode(), nodeCfg.LeaseDuration(), primaries, nil, chainID, &clientErrors, 0, "", nodeCfg.QuorumReads(), nodeCfg.Multicall(), nil)
+	t.Cleanup(c.Close)
+	return c
+}
+
 func NewChainClientWithEmptyNode(
 	t *testing.T,
 	selectionMode string,
@@ -196,7 +271,7 @@ func NewChainClientWithEmptyNode(
 	multiNodeMetrics, err := metrics.NewGenericMultiNodeMetrics("EVM Test", chainID.String())
 	require.NoError(t, err)
 
-	c := NewChainClient(lggr, multiNodeMetrics, selectionMode, leaseDuration, nil, nil, chainID, nil, 0, "")
+	c := NewChainClient(lggr, multiNodeMetrics, selectionMode, leaseDuration, nil, nil, chainID, nil, 0, "", nil, nil, nil)
 	t.Cleanup(c.Close)
 	return c
 }
@@ -223,7 +298,7 @@ func NewChainClientWithMockedRpc(
 		cfg, mocks.ChainConfig{NoNewHeadsThresholdVal: noNewHeadsThreshold}, lggr, multiNodeMetrics, parsed, nil, "eth-primary-node-0", 1, chainID, 1, rpc, "EVM")
 	primaries := []multinode.Node[*big.Int, *RPCClient]{n}
 	clientErrors := NewTestClientErrors()
-	c := NewChainClient(lggr, multiNodeMetrics, selectionMode, leaseDuration, primaries, nil, chainID, &clientErrors, 0, "")
+	c := NewChainClient(lggr, multiNodeMetrics, selectionMode, leaseDuration, primaries, nil, chainID, &clientErrors, 0, "", nil, nil, nil)
 	t.Cleanup(c.Close)
 	return c
 }
@@ -0,0 +1,246 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/multicall3"
+	evmabi "github.com/smartcontractkit/chainlink-evm/pkg/abi"
+	evmconfig "github.com/smartcontractkit/chainlink-evm/pkg/config"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+var (
+	promEVMPoolRPCMulticallBatches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_pool_rpc_multicall_batches",
+		Help: "The total number of batches of reads sent to Multicall3, by outcome: success, failed (the reads were sent individually) or unavailable (Multicall3 is not deployed)",
+	}, []string{"evmChainID", "outcome"})
+	promEVMPoolRPCMulticallReads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_pool_rpc_multicall_reads",
+		Help: "The total number of reads served by Multicall3 batches",
+	}, []string{"evmChainID"})
+
+	multicall3ABI = evmtypes.MustGetABI(multicall3.Multicall3MetaData.ABI)
+)
+
+// errNotBatched is returned by multicallBatcher.read when the read must be sent individually.
+var errNotBatched = errors.New("read not batched")
+
+// multicallBatcher coalesces concurrent reads of the same block into aggregate3 calls of Multicall3.
+type multicallBatcher struct {
+	address      common.Address
+	window       time.Duration
+	maxBatchSize int
+	gasCap       uint64
+	lggr         logger.SugaredLogger
+	chainID      string
+	revertErrors *evmabi.ErrorRegistry
+	// call sends an eth_call, without batching.
+	call func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	unavailable  atomic.Bool  // set once Multicall3 is found not to be deployed at the latest block
+	undeployedAt atomic.Int64 // highest past block at which Multicall3 was found not to be deployed yet
+
+	mu      sync.Mutex
+	batches map[string]*multicallBatch // pending batches by block
+}
+
+type multicallBatch struct {
+	ctx         context.Context // of the first read, without its cancellation
+	blockNumber *big.Int
+	reads       []multicallRead
+	timer       *time.Timer
+	sent        bool
+}
+
+type multicallRead struct {
+	call multicall3.Multicall3Call3
+	done chan multicallResult
+}
+
+type multicallResult struct {
+	data []byte
+	err  error
+}
+
+// newMulticallBatcher returns a batcher sending the batches with call, or nil if Multicall is disabled.
+func newMulticallBatcher(cfg evmconfig.Multicall, lggr logger.SugaredLogger, chainID *big.Int, revertErrors *evmabi.ErrorRegistry,
+	call func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)) *multicallBatcher {
+	if cfg == nil || !cfg.Enabled() {
+		return nil
+	}
+	return &multicallBatcher{
+		address:      cfg.Address(),
+		window:       cfg.BatchWindow(),
+		maxBatchSize: int(cfg.MaxBatchSize()),
+		gasCap:       cfg.GasCap(),
+		lggr:         logger.Sugared(logger.Named(lggr, "Multicall")),
+		chainID:      chainID.String(),
+		revertErrors: revertErrors,
+		call:         call,
+		batches:      make(map[string]*multicallBatch),
+	}
+}
+
+// batchable returns whether msg is a plain read, whose result doesn't depend on being sent by Multicall3.
+func (b *multicallBatcher) batchable(msg ethereum.CallMsg) bool {
+	return b != nil && !b.unavailable.Load() && msg.To != nil && msg.From == (common.Address{}) &&
+		(msg.Value == nil || msg.Value.Sign() == 0) && msg.Gas == 0 &&
+		msg.GasPrice == nil && msg.GasFeeCap == nil && msg.GasTipCap == nil &&
+		msg.AccessList == nil && msg.BlobGasFeeCap == nil && msg.BlobHashes == nil
+}
+
+// read returns the result of calling target with data at blockNumber, as part of a batch. It returns errNotBatched
+// if the read must be sent individually instead, e.g. because no other read joined the batch or the batch failed.
+func (b *multicallBatcher) read(ctx context.Context, target common.Address, data []byte, blockNumber *big.Int) ([]byte, error) {
+	if b.unavailable.Load() || (blockNumber != nil && blockNumber.Sign() > 0 && blockNumber.Cmp(big.NewInt(b.undeployedAt.Load())) <= 0) {
+		return nil, errNotBatched
+	}
+	key := "latest"
+	if blockNumber != nil {
+		key = blockNumber.String()
+	}
+	r := multicallRead{
+		call: multicall3.Multicall3Call3{Target: target, AllowFailure: true, CallData: data},
+		done: make(chan multicallResult, 1),
+	}
+
+	b.mu.Lock()
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &multicallBatch{ctx: context.WithoutCancel(ctx), blockNumber: blockNumber}
+		batch.timer = time.AfterFunc(b.window, func() { b.send(key, batch) })
+		b.batches[key] = batch
+	}
+	batch.reads = append(batch.reads, r)
+	full := len(batch.reads) >= b.maxBatchSize
+	if full {
+		// Later reads start a new batch.
+		delete(b.batches, key)
+	}
+	b.mu.Unlock()
+	if full {
+		batch.timer.Stop()
+		go b.send(key, batch)
+	}
+
+	select {
+	case res := <-r.done:
+		return res.data, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// send sends the batch, unless it was already sent.
+func (b *multicallBatcher) send(key string, batch *multicallBatch) {
+	b.mu.Lock()
+	if batch.sent {
+		b.mu.Unlock()
+		return
+	}
+	batch.sent = true
+	if b.batches[key] == batch {
+		delete(b.batches, key)
+	}
+	reads := batch.reads
+	b.mu.Unlock()
+
+	if len(reads) == 1 {
+		reads[0].done <- multicallResult{err: errNotBatched}
+		return
+	}
+	results, err := b.aggregate3(batch.ctx, reads, batch.blockNumber)
+	if err != nil {
+		for _, r := range reads {
+			r.done <- multicallResult{err: errNotBatched}
+		}
+		return
+	}
+	served := 0
+	for i, r := range reads {
+		switch {
+		case results[i].Success:
+			r.done <- multicallResult{data: results[i].ReturnData}
+		case len(results[i].ReturnData) == 0:
+			// The read may have run out of the gas of the batch, which doesn't revert with data, so it's retried
+			// individually to fail like the RPC would have, if it does.
+			r.done <- multicallResult{err: errNotBatched}
+			continue
+		default:
+			// Fail the read like the RPC would have if it was sent individually.
+			r.done <- multicallResult{err: DecodeRevert(&JsonError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(results[i].ReturnData)}, b.revertErrors)}
+		}
+		served++
+	}
+	promEVMPoolRPCMulticallReads.WithLabelValues(b.chainID).Add(float64(served))
+}
+
+func (b *multicallBatcher) aggregate3(ctx context.Context, reads []multicallRead, blockNumber *big.Int) ([]multicall3.Multicall3Result, error) {
+	calls := make([]multicall3.Multicall3Call3, len(reads))
+	for i, r := range reads {
+		calls[i] = r.call
+	}
+	data, err := multicall3ABI.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, err
+	}
+	out, err := b.call(ctx, ethereum.CallMsg{To: &b.address, Data: data, Gas: b.gasCap}, blockNumber)
+	if err != nil {
+		promEVMPoolRPCMulticallBatches.WithLabelValues(b.chainID, "failed").Inc()
+		b.lggr.Debugw("Multicall3 batch failed, sending the reads individually", "reads", len(reads), "blockNumber", blockNumber, "err", err)
+		return nil, err
+	}
+	if len(out) == 0 {
+		promEVMPoolRPCMulticallBatches.WithLabelValues(b.chainID, "unavailable").Inc()
+		switch {
+		case isLatestBlock(blockNumber):
+			if !b.unavailable.Swap(true) {
+				b.lggr.Warnw("Multicall3 is not deployed, reads won't be batched", "address", b.address)
+			}
+		case blockNumber.Sign() > 0:
+			// Multicall3 may have been deployed since, so only the reads of blocks up to this one aren't batched anymore.
+			for n := b.undeployedAt.Load(); n < blockNumber.Int64() && !b.undeployedAt.CompareAndSwap(n, blockNumber.Int64()); n = b.undeployedAt.Load() {
+			}
+			b.lggr.Debugw("Multicall3 is not deployed at block, reads of blocks up to it won't be batched", "address", b.address, "blockNumber", blockNumber)
+		}
+		return nil, errors.New("multicall3 not deployed")
+	}
+	var results []multicall3.Multicall3Result
+	unpacked, err := multicall3ABI.Unpack("aggregate3", out)
+	if err == nil {
+		results = *abi.ConvertType(unpacked[0], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result)
+		if len(results) != len(reads) {
+			err = errors.New("unexpected number of results")
+		}
+	}
+	if err != nil {
+		promEVMPoolRPCMulticallBatches.WithLabelValues(b.chainID, "failed").Inc()
+		b.lggr.Errorw("Failed to unpack Multicall3 results, sending the reads individually", "address", b.address, "err", err)
+		return nil, err
+	}
+	promEVMPoolRPCMulticallBatches.WithLabelValues(b.chainID, "success").Inc()
+	return results, nil
+}
+
+// getEthBalanceCallData returns the call data of Multicall3.getEthBalance, to batch BalanceAt reads.
+func getEthBalanceCallData(account common.Address) []byte {
+	data, err := multicall3ABI.Pack("getEthBalance", account)
+	if err != nil {
+		panic(err) // an address always packs
+	}
+	return data
+}
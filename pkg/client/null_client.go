@@ -219,6 +219,10 @@ func (nc *NullClient) SuggestGasTipCap(ctx context.Context) (tipCap *big.Int, er
 	return nil, nil
 }
 
+func (nc *NullClient) BlobBaseFee(ctx context.Context) (blobBaseFee *big.Int, err error) {
+	return nil, nil
+}
+
 // NodeStates implements evmclient.Client
 func (nc *NullClient) NodeStates() map[string]string { return nil }
 
@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-framework/multinode"
+
+	evmconfig "github.com/smartcontractkit/chainlink-evm/pkg/config"
+)
+
+var (
+	promEVMPoolRPCQuorumReads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_pool_rpc_quorum_reads",
+		Help: "The total number of reads sent to a quorum of RPC nodes, by method and whether a majority of the nodes agreed",
+	}, []string{"evmChainID", "method", "agreed"})
+	promEVMPoolRPCNodeQuorumDisagreements = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_pool_rpc_node_quorum_disagreements",
+		Help: "The total number of quorum reads for which the given RPC node returned a result differing from the majority",
+	}, []string{"evmChainID", "nodeName", "method"})
+)
+
+type quorumReadCtxKey struct{}
+
+// WithQuorumRead returns a context requesting the reads which support it to be sent to the given number of nodes, and
+// only succeed if a strict majority of them return the same result, or fail with the RPC error a strict majority of them
+// returned. It overrides the NodePool.QuorumReads config for the calls made with the context: nodes = 0 disables quorum
+// reads.
+func WithQuorumRead(ctx context.Context, nodes uint32) context.Context {
+	return context.WithValue(ctx, quorumReadCtxKey{}, nodes)
+}
+
+// QuorumDisagreementError is returned by quorum reads when no strict majority of the nodes returned the same result or
+// error.
+type QuorumDisagreementError struct {
+	Method      string
+	BlockNumber *big.Int         // block the read was pinned to, nil if it wasn't pinned
+	Nodes       int              // number of nodes the read was sent to
+	Agreeing    int              // number of nodes which returned the most common result or error
+	Errors      map[string]error // errors returned, by node name
+}
+
+func (e *QuorumDisagreementError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "quorum read %s failed: at most %d of %d nodes agreed", e.Method, e.Agreeing, e.Nodes)
+	if e.BlockNumber != nil {
+		fmt.Fprintf(&sb, " at block %s", e.BlockNumber)
+	}
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(&sb, "; %s: %v", name, e.Errors[name])
+	}
+	return sb.String()
+}
+
+func (e *QuorumDisagreementError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// quorumReadConfig is the configuration of the quorum reads of a chainClient.
+type quorumReadConfig struct {
+	nodes   uint32
+	methods map[string]struct{}
+}
+
+func newQuorumReadConfig(cfg evmconfig.QuorumReads) quorumReadConfig {
+	if cfg == nil {
+		return quorumReadConfig{}
+	}
+	q := quorumReadConfig{nodes: cfg.Nodes(), methods: make(map[string]struct{})}
+	for _, m := range cfg.Methods() {
+		q.methods[m] = struct{}{}
+	}
+	return q
+}
+
+// quorumReadNodes returns the number of nodes a read of method must be sent to, 0 if it isn't a quorum read.
+func (c *chainClient) quorumReadNodes(ctx context.Context, method string) uint32 {
+	if nodes, ok := ctx.Value(quorumReadCtxKey{}).(uint32); ok {
+		return nodes
+	}
+	if _, ok := c.quorumReads.methods[method]; ok {
+		return c.quorumReads.nodes
+	}
+	return 0
+}
+
+// quorumRPCs returns the RPCs of the first n alive primary nodes.
+func (c *chainClient) quorumRPCs(ctx context.Context, n uint32) ([]*RPCClient, error) {
+	var rpcs []*RPCClient
+	err := c.multiNode.DoAll(ctx, func(_ context.Context, r *RPCClient, isSendOnly bool) {
+		if !isSendOnly && len(rpcs) < int(n) {
+			rpcs = append(rpcs, r)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rpcs) < int(n) {
+		return nil, fmt.Errorf("quorum read requires %d alive nodes, got %d: %w", n, len(rpcs), multinode.ErrNodeError)
+	}
+	return rpcs, nil
+}
+
+// pinnedBlock returns the lowest latest block of rpcs, which all of them can serve.
+func pinnedBlock(ctx context.Context, rpcs []*RPCClient) (*big.Int, error) {
+	var pinned *big.Int
+	for _, r := range rpcs {
+		latest, _ := r.GetInterceptedChainInfo()
+		n := big.NewInt(latest.BlockNumber)
+		if latest.BlockNumber <= 0 {
+			var err error
+			if n, err = r.LatestBlockHeight(ctx); err != nil {
+				return nil, fmt.Errorf("failed to get latest block of %s: %w", r.name, err)
+			}
+		}
+		if pinned == nil || n.Cmp(pinned) < 0 {
+			pinned = n
+		}
+	}
+	return pinned, nil
+}
+
+// isLatestBlock returns whether the block number argument of a read refers to the latest block.
+func isLatestBlock(n *big.Int) bool {
+	return n == nil || n.Cmp(big.NewInt(int64(rpc.LatestBlockNumber))) == 0
+}
+
+// quorumRead sends read to nodes alive nodes, and returns the result returned by a strict majority of them, compared
+// by key, or the error returned by a strict majority of them, compared by quorumErrorKey. If pin is set, the read is pinned to the lowest latest block of the nodes, otherwise block is passed as is.
+func quorumRead[T any](ctx context.Context, c *chainClient, method string, nodes uint32, block *big.Int, pin bool,
+	read func(ctx context.Context, r *RPCClient, block *big.Int) (T, error), key func(T) string) (result T, err error) {
+	rpcs, err := c.quorumRPCs(ctx, nodes)
+	if err != nil {
+		return result, err
+	}
+	if pin {
+		if block, err = pinnedBlock(ctx, rpcs); err != nil {
+			return result, err
+		}
+	}
+
+	type response struct {
+		val T
+		key string // empty if the response doesn't vote
+		err error
+	}
+	responses := make([]response, len(rpcs))
+	var wg sync.WaitGroup
+	for i, r := range rpcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := read(ctx, r, block)
+			responses[i] = response{val: val, err: err}
+			if err == nil {
+				responses[i].key = "result:" + key(val)
+			} else {
+				responses[i].key = quorumErrorKey(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	var majority response
+	var agreeing int
+	for _, resp := range responses {
+		if resp.key == "" {
+			continue
+		}
+		counts[resp.key]++
+		if counts[resp.key] > agreeing {
+			majority, agreeing = resp, counts[resp.key]
+		}
+	}
+
+	chainID := c.ConfiguredChainID().String()
+	if agreeing <= len(rpcs)/2 {
+		promEVMPoolRPCQuorumReads.WithLabelValues(chainID, method, "false").Inc()
+		qErr := &QuorumDisagreementError{Method: method, BlockNumber: block, Nodes: len(rpcs), Agreeing: agreeing, Errors: make(map[string]error)}
+		for i, resp := range responses {
+			if resp.err != nil {
+				qErr.Errors[rpcs[i].name] = resp.err
+			}
+		}
+		c.logger.Errorw("Quorum read failed, nodes disagree", "method", method, "blockNumber", block, "nodes", len(rpcs), "agreeing", agreeing, "err", qErr)
+		return result, qErr
+	}
+
+	promEVMPoolRPCQuorumReads.WithLabelValues(chainID, method, "true").Inc()
+	for i, resp := range responses {
+		switch {
+		case resp.key == "":
+			c.logger.Debugw("Quorum read failed on node", "method", method, "node", rpcs[i].name, "err", resp.err)
+		case resp.key != majority.key:
+			promEVMPoolRPCNodeQuorumDisagreements.WithLabelValues(chainID, rpcs[i].name, method).Inc()
+			c.logger.Warnw("Node disagrees with the majority of the quorum read", "method", method, "node", rpcs[i].name, "blockNumber", block, "err", resp.err)
+		}
+	}
+	return majority.val, majority.err
+}
+
+// quorumErrorKey returns the key by which errors returned by nodes are compared in quorum reads: the JSON-RPC error
+// code and data, or message if it has no data, so that e.g. nodes agree on the revert of a call. Other errors, such
+// as network errors, don't vote and return "".
+func quorumErrorKey(err error) string {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return ""
+	}
+	key := "error:" + strconv.Itoa(rpcErr.ErrorCode())
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+		data, _ := json.Marshal(dataErr.ErrorData()) // decoded from JSON
+		return key + ":" + string(data)
+	}
+	return key + ":" + rpcErr.Error()
+}
+
+func bytesKey(b []byte) string { return hexutil.Encode(b) }
+
+func bigKey(n *big.Int) string { return n.String() }
+
+func uint64Key(n uint64) string { return strconv.FormatUint(n, 10) }
+
+func headerKey(h *types.Header) string {
+	if h == nil {
+		return ""
+	}
+	return h.Hash().Hex()
+}
+
+func logsKey(logs []types.Log) string {
+	b, _ := json.Marshal(logs) // logs always marshal
+	return string(b)
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	evmconfig "github.com/smartcontractkit/chainlink-evm/pkg/config"
+)
+
+var (
+	promEVMPoolRPCCacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_pool_rpc_cache_hits",
+		Help: "The total number of responses of finalized blocks served from the cache of the given RPC node, by method",
+	}, []string{"evmChainID", "nodeName", "method"})
+	promEVMPoolRPCCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "evm_pool_rpc_cache_misses",
+		Help: "The total number of cacheable requests to the given RPC node which were not in its cache, by method",
+	}, []string{"evmChainID", "nodeName", "method"})
+)
+
+// cachedMethods maps the JSON-RPC methods whose responses are cached to the client methods they are disabled by.
+var cachedMethods = map[string]string{
+	"eth_getBlockByHash":        "BlockByHash",
+	"eth_getTransactionReceipt": "TransactionReceipt",
+}
+
+// responseCache is an LRU cache of the responses of an RPC which can no longer change: those of blocks at or below
+// the latest finalized block of the RPC. The finalized block follows the finality config of the chain, see WithFinality,
+// and the blocks last returned by LatestBlock and LatestFinalizedBlock, which the head subscriptions and the finalized
+// block polling of the node call.
+type responseCache struct {
+	chainID  string
+	nodeName string
+	disabled []string
+	// finalized returns the number of the latest finalized block of the RPC.
+	finalized func() int64
+
+	responses *lru.Cache[string, cachedResponse]
+}
+
+type cachedResponse struct {
+	blockNumber int64
+	value       any
+}
+
+// newResponseCache returns a cache of the responses of the RPC, or nil if caching is disabled.
+func newResponseCache(cfg evmconfig.ResponseCache, chainID *big.Int, nodeName string, finalized func() int64) *responseCache {
+	if cfg == nil || !cfg.Enabled() || cfg.Size() == 0 {
+		return nil
+	}
+	return &responseCache{
+		chainID:   chainID.String(),
+		nodeName:  nodeName,
+		disabled:  cfg.DisabledMethods(),
+		finalized: finalized,
+		responses: lru.NewCache[string, cachedResponse](int(cfg.Size())),
+	}
+}
+
+// enabled returns whether the responses of the client method are cached.
+func (c *responseCache) enabled(method string) bool {
+	return c != nil && !slices.Contains(c.disabled, method)
+}
+
+// get returns the cached response to the request identified by key. Responses of blocks above the finalized block,
+// e.g. after a finality violation or a reset of the RPC, are evicted instead.
+func (c *responseCache) get(method string, key string) (any, bool) {
+	r, ok := c.responses.Get(key)
+	if ok && r.blockNumber > c.finalized() {
+		c.responses.Remove(key)
+		ok = false
+	}
+	if !ok {
+		promEVMPoolRPCCacheMisses.WithLabelValues(c.chainID, c.nodeName, method).Inc()
+		return nil, false
+	}
+	promEVMPoolRPCCacheHits.WithLabelValues(c.chainID, c.nodeName, method).Inc()
+	return r.value, true
+}
+
+// add caches the response to the request identified by key, if blockNumber is finalized.
+func (c *responseCache) add(key string, blockNumber int64, value any) {
+	if blockNumber < 0 || blockNumber > c.finalized() {
+		return
+	}
+	c.responses.Add(key, cachedResponse{blockNumber: blockNumber, value: value})
+}
+
+// callKey returns the cache key of a JSON-RPC call, and whether its response can be cached.
+func (c *responseCache) callKey(method string, args []any) (string, bool) {
+	m, ok := cachedMethods[method]
+	if !ok || !c.enabled(m) {
+		return "", false
+	}
+	return cacheKey(method, args...), true
+}
+
+// getCall unmarshals the cached response of a JSON-RPC call into result.
+func (c *responseCache) getCall(method string, key string, result any) bool {
+	v, ok := c.get(cachedMethods[method], key)
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(v.(json.RawMessage), result) == nil
+}
+
+// addCall caches the raw response of a JSON-RPC call, if it is a block or receipt of a finalized block.
+func (c *responseCache) addCall(key string, raw json.RawMessage) {
+	var r struct {
+		Number      *hexutil.Big `json:"number"`      // of blocks
+		BlockNumber *hexutil.Big `json:"blockNumber"` // of receipts
+	}
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return
+	}
+	n := r.Number
+	if n == nil {
+		n = r.BlockNumber
+	}
+	if n == nil || !n.ToInt().IsInt64() {
+		return
+	}
+	c.add(key, n.ToInt().Int64(), raw)
+}
+
+func cacheKey(method string, args ...any) string {
+	b, err := json.Marshal(args)
+	if err != nil {
+		panic(err) // hashes, addresses and block numbers always marshal
+	}
+	return method + string(b)
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	evmabi "github.com/smartcontractkit/chainlink-evm/pkg/abi"
+)
+
+// RevertError is the error of a call which reverted, with its revert data decoded, e.g. InvalidReport(bytes32 reportId: 0x...)
+// instead of the hex data.
+type RevertError struct {
+	err    error
+	Reason *evmabi.DecodedError
+}
+
+func (e *RevertError) Error() string {
+	msg := e.err.Error()
+	if e.Reason.Name == "Error" && len(e.Reason.Args) == 1 {
+		// Geth already includes the reason of Error(string) reverts, e.g. "execution reverted: not enough balance".
+		if reason, ok := e.Reason.Args[0].Value.(string); ok && strings.Contains(msg, reason) {
+			return msg
+		}
+	}
+	return msg + ": " + e.Reason.String()
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// Cause lets pkgerrors.Cause return the underlying RPC error, which ExtractRPCError and the send error classification
+// rely on.
+func (e *RevertError) Cause() error {
+	return e.err
+}
+
+// builtinRevertErrors decodes Error(string) and Panic(uint256) only.
+var builtinRevertErrors = evmabi.NewErrorRegistry()
+
+// DecodeRevert returns err as a RevertError if it carries revert data which errs can decode, and err as is otherwise.
+// A nil errs decodes Error(string) and Panic(uint256) only: pass reverts.Registry() from gethwrappers to also decode the
+// custom errors of the generated contract wrappers.
+func DecodeRevert(err error, errs *evmabi.ErrorRegistry) error {
+	if err == nil || RevertReason(err) != nil {
+		return err
+	}
+	data, ok := revertData(err)
+	if !ok {
+		return err
+	}
+	if errs == nil {
+		errs = builtinRevertErrors
+	}
+	reason, dErr := errs.Decode(data)
+	if dErr != nil {
+		return err
+	}
+	return &RevertError{err: err, Reason: reason}
+}
+
+// RevertReason returns the decoded revert reason of err, or nil if it doesn't have one.
+func RevertReason(err error) *evmabi.DecodedError {
+	var rErr *RevertError
+	if errors.As(err, &rErr) {
+		return rErr.Reason
+	}
+	return nil
+}
+
+// revertData returns the revert data of an RPC error. Geth returns it hex encoded in the error data, and parity based
+// clients prefixed by "Reverted ".
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	s, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, dErr := hexutil.Decode(strings.TrimPrefix(s, "Reverted "))
+	return data, dErr == nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -25,6 +26,7 @@ import (
 
 	commonassets "github.com/smartcontractkit/chainlink-common/pkg/assets"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	evmabi "github.com/smartcontractkit/chainlink-evm/pkg/abi"
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
@@ -99,6 +101,9 @@ type RPCClient struct {
 	rpcTimeout                 time.Duration
 	chainType                  chaintype.ChainType
 	clientErrors               config.ClientErrors
+	cache                      *responseCache // nil if disabled
+	revertErrors               *evmabi.ErrorRegistry
+	finality                   multinode.ChainConfig // nil to trust the finalized tag
 
 	ws   atomic.Pointer[rawclient]
 	http atomic.Pointer[rawclient]
@@ -121,12 +126,16 @@ func NewRPCClient(
 	largePayloadRPCTimeout time.Duration,
 	rpcTimeout time.Duration,
 	chainType chaintype.ChainType,
+	opts ...ClientOpt,
 ) *RPCClient {
+	o := newClientOpts(opts)
 	r := &RPCClient{
 		largePayloadRPCTimeout: largePayloadRPCTimeout,
 		rpcTimeout:             rpcTimeout,
 		chainType:              chainType,
 		clientErrors:           cfg.Errors(),
+		revertErrors:           o.revertErrors,
+		finality:               o.finality,
 	}
 	r.cfg = cfg
 	r.name = name
@@ -151,9 +160,31 @@ func NewRPCClient(
 	r.rpcLog = logger.Sugared(lggr).Named("RPC")
 
 	r.RPCClientBase = multinode.NewRPCClientBase[*evmtypes.Head](cfg, QueryTimeout, lggr, r.latestBlock, r.latestFinalizedBlock)
+	r.cache = newResponseCache(cfg.ResponseCache(), chainID, name, r.finalizedBlockNumber)
 	return r
 }
 
+// finalizedBlockNumber returns the number of the latest block of the RPC which the HeadTracker considers finalized:
+// the observed finalized block minus FinalizedBlockOffset if FinalityTagEnabled, otherwise the observed latest block
+// minus FinalityDepth and FinalizedBlockOffset. It is negative while no block is known to be finalized.
+func (r *RPCClient) finalizedBlockNumber() int64 {
+	latest, _ := r.GetInterceptedChainInfo()
+	if r.finality == nil {
+		return latest.FinalizedBlockNumber
+	}
+	offset := int64(r.finality.FinalizedBlockOffset())
+	if r.finality.FinalityTagEnabled() {
+		if latest.FinalizedBlockNumber == 0 {
+			return -1
+		}
+		return latest.FinalizedBlockNumber - offset
+	}
+	if latest.BlockNumber == 0 {
+		return -1
+	}
+	return latest.BlockNumber - int64(r.finality.FinalityDepth()) - offset
+}
+
 func (r *RPCClient) ClientVersion(ctx context.Context) (version string, err error) {
 	err = r.CallContext(ctx, &version, "web3_clientVersion")
 	if err != nil {
@@ -305,6 +336,22 @@ func (r *RPCClient) isChainType(chainType chaintype.ChainType) bool {
 
 // CallContext implementation
 func (r *RPCClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	key, cached := r.cache.callKey(method, args)
+	if !cached {
+		return r.callContext(ctx, result, method, args...)
+	}
+	if r.cache.getCall(method, key, result) {
+		return nil
+	}
+	var raw json.RawMessage
+	if err := r.callContext(ctx, &raw, method, args...); err != nil {
+		return err
+	}
+	r.cache.addCall(key, raw)
+	return json.Unmarshal(raw, result)
+}
+
+func (r *RPCClient) callContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.largePayloadRPCTimeout)
 	defer cancel()
 	lggr := r.newRqLggr().With(
@@ -353,18 +400,24 @@ func (r *RPCClient) BatchCallContext(rootCtx context.Context, b []rpc.BatchElem)
 		}
 	}
 
+	send, sent := r.cachedBatchElems(b)
+	if len(send) == 0 && len(b) > 0 {
+		// All served from the cache.
+		return nil
+	}
+
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(rootCtx, r.largePayloadRPCTimeout)
 	defer cancel()
-	lggr := r.newRqLggr().With("nBatchElems", len(b), "batchElems", b)
+	lggr := r.newRqLggr().With("nBatchElems", len(send), "batchElems", send)
 
 	lggr.Trace("RPC call: evmclient.Client#BatchCallContext")
 	start := time.Now()
 	var err error
 
 	if http != nil {
-		err = r.wrapHTTP(http.rpc.BatchCallContext(ctx, b))
+		err = r.wrapHTTP(http.rpc.BatchCallContext(ctx, send))
 	} else {
-		err = r.wrapWS(ws.rpc.BatchCallContext(ctx, b))
+		err = r.wrapWS(ws.rpc.BatchCallContext(ctx, send))
 	}
 	duration := time.Since(start)
 
@@ -372,6 +425,7 @@ func (r *RPCClient) BatchCallContext(rootCtx context.Context, b []rpc.BatchElem)
 	if err != nil {
 		return err
 	}
+	sent()
 
 	if r.chainType == chaintype.ChainAstar && requestedFinalizedBlock {
 		// populate requested finalized block with correct value
@@ -391,6 +445,42 @@ func (r *RPCClient) BatchCallContext(rootCtx context.Context, b []rpc.BatchElem)
 	return nil
 }
 
+// cachedBatchElems fills the elements of b whose responses are cached, and returns the elements left to send along
+// with a function to call once they are sent, which sets their results in b and caches them.
+func (r *RPCClient) cachedBatchElems(b []rpc.BatchElem) (send []rpc.BatchElem, sent func()) {
+	if r.cache == nil {
+		return b, func() {}
+	}
+	var (
+		indexes []int    // in b of the elements to send
+		keys    []string // of the elements to send, empty if not cached
+	)
+	for i, el := range b {
+		key, cached := r.cache.callKey(el.Method, el.Args)
+		if cached {
+			if r.cache.getCall(el.Method, key, el.Result) {
+				b[i].Error = nil
+				continue
+			}
+			el.Result = new(json.RawMessage)
+		}
+		send = append(send, el)
+		indexes = append(indexes, i)
+		keys = append(keys, key)
+	}
+	return send, func() {
+		for j, i := range indexes {
+			b[i].Error = send[j].Error
+			if keys[j] == "" || send[j].Error != nil {
+				continue
+			}
+			raw := *send[j].Result.(*json.RawMessage)
+			r.cache.addCall(keys[j], raw)
+			b[i].Error = json.Unmarshal(raw, b[i].Result)
+		}
+	}
+}
+
 func isRequestingFinalizedBlock(el rpc.BatchElem) bool {
 	isGetBlock := el.Method == "eth_getBlockByNumber" && len(el.Args) > 0
 	if !isGetBlock {
@@ -472,6 +562,21 @@ func (r *RPCClient) TransactionReceipt(ctx context.Context, txHash common.Hash)
 }
 
 func (r *RPCClient) TransactionReceiptGeth(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	const cachedMethod = "TransactionReceipt"
+	key := cacheKey("TransactionReceiptGeth", txHash)
+	if r.cache.enabled(cachedMethod) {
+		if v, ok := r.cache.get(cachedMethod, key); ok {
+			cp := *v.(*types.Receipt)
+			return &cp, nil
+		}
+		defer func() {
+			if err == nil && receipt.BlockNumber != nil && receipt.BlockNumber.IsInt64() {
+				cp := *receipt
+				r.cache.add(key, receipt.BlockNumber.Int64(), &cp)
+			}
+		}()
+	}
+
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
 	lggr := r.newRqLggr().With("txHash", txHash)
@@ -540,6 +645,19 @@ func (r *RPCClient) HeaderByNumber(ctx context.Context, number *big.Int) (header
 }
 
 func (r *RPCClient) HeaderByHash(ctx context.Context, hash common.Hash) (header *types.Header, err error) {
+	const cachedMethod = "HeaderByHash"
+	key := cacheKey(cachedMethod, hash)
+	if r.cache.enabled(cachedMethod) {
+		if v, ok := r.cache.get(cachedMethod, key); ok {
+			return types.CopyHeader(v.(*types.Header)), nil
+		}
+		defer func() {
+			if err == nil && header.Number != nil && header.Number.IsInt64() {
+				r.cache.add(key, header.Number.Int64(), types.CopyHeader(header))
+			}
+		}()
+	}
+
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
 	lggr := r.newRqLggr().With("hash", hash)
@@ -676,6 +794,20 @@ func (r *RPCClient) BlockByHash(ctx context.Context, hash common.Hash) (head *ev
 }
 
 func (r *RPCClient) BlockByHashGeth(ctx context.Context, hash common.Hash) (block *types.Block, err error) {
+	const cachedMethod = "BlockByHash"
+	key := cacheKey("BlockByHashGeth", hash)
+	if r.cache.enabled(cachedMethod) {
+		if v, ok := r.cache.get(cachedMethod, key); ok {
+			// Blocks are immutable.
+			return v.(*types.Block), nil
+		}
+		defer func() {
+			if err == nil && block.Number().IsInt64() {
+				r.cache.add(key, block.Number().Int64(), block)
+			}
+		}()
+	}
+
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
 	lggr := r.newRqLggr().With("hash", hash)
@@ -855,6 +987,20 @@ func (r *RPCClient) PendingCodeAt(ctx context.Context, account common.Address) (
 }
 
 func (r *RPCClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	const cachedMethod = "CodeAt"
+	key := cacheKey(cachedMethod, account, blockNumber)
+	// The code at a block tag, like latest, changes.
+	if r.cache.enabled(cachedMethod) && blockNumber != nil && blockNumber.Sign() >= 0 && blockNumber.IsInt64() {
+		if v, ok := r.cache.get(cachedMethod, key); ok {
+			return bytes.Clone(v.([]byte)), nil
+		}
+		defer func() {
+			if err == nil {
+				r.cache.add(key, blockNumber.Int64(), bytes.Clone(code))
+			}
+		}()
+	}
+
 	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
 	defer cancel()
 	lggr := r.newRqLggr().With("account", account, "blockNumber", blockNumber)
@@ -887,7 +1033,7 @@ func (r *RPCClient) EstimateGas(ctx context.Context, c interface{}) (gas uint64,
 	start := time.Now()
 
 	if r.isChainType(chaintype.ChainTron) {
-		err = r.wrapHTTP(http.rpc.CallContext(ctx, &gas, "eth_estimateGas", r.prepareCallArgs(call)))
+		err = DecodeRevert(r.wrapHTTP(http.rpc.CallContext(ctx, &gas, "eth_estimateGas", r.prepareCallArgs(call))), r.revertErrors)
 		return
 	}
 
@@ -898,6 +1044,7 @@ func (r *RPCClient) EstimateGas(ctx context.Context, c interface{}) (gas uint64,
 		gas, err = ws.geth.EstimateGas(ctx, call)
 		err = r.wrapWS(err)
 	}
+	err = DecodeRevert(err, r.revertErrors)
 	duration := time.Since(start)
 
 	r.logResult(lggr, err, duration, r.getRPCDomain(), "EstimateGas",
@@ -949,6 +1096,7 @@ func (r *RPCClient) CallContract(ctx context.Context, msg interface{}, blockNumb
 	if err == nil {
 		val = hex
 	}
+	err = DecodeRevert(err, r.revertErrors)
 	duration := time.Since(start)
 
 	r.logResult(lggr, err, duration, r.getRPCDomain(), "CallContract",
@@ -977,6 +1125,7 @@ func (r *RPCClient) PendingCallContract(ctx context.Context, msg interface{}) (v
 	if err == nil {
 		val = hex
 	}
+	err = DecodeRevert(err, r.revertErrors)
 	duration := time.Since(start)
 
 	r.logResult(lggr, err, duration, r.getRPCDomain(), "PendingCallContract",
@@ -1074,11 +1223,9 @@ type CallArgs struct {
 func (r *RPCClient) TokenBalance(ctx context.Context, address common.Address, contractAddress common.Address) (*big.Int, error) {
 	result := ""
 	numLinkBigInt := new(big.Int)
-	functionSelector := evmtypes.HexToFunctionSelector(BALANCE_OF_ADDRESS_FUNCTION_SELECTOR) // balanceOf(address)
-	data := utils.ConcatBytes(functionSelector.Bytes(), common.LeftPadBytes(address.Bytes(), utils.EVMWordByteLen))
 	args := CallArgs{
 		To:   contractAddress,
-		Data: data,
+		Data: balanceOfCallData(address),
 	}
 	err := r.CallContext(ctx, &result, "eth_call", args, "latest")
 	if err != nil {
@@ -1090,6 +1237,12 @@ func (r *RPCClient) TokenBalance(ctx context.Context, address common.Address, co
 	return numLinkBigInt, nil
 }
 
+// balanceOfCallData returns the call data of the ERC20 balanceOf(address).
+func balanceOfCallData(address common.Address) []byte {
+	functionSelector := evmtypes.HexToFunctionSelector(BALANCE_OF_ADDRESS_FUNCTION_SELECTOR) // balanceOf(address)
+	return utils.ConcatBytes(functionSelector.Bytes(), common.LeftPadBytes(address.Bytes(), utils.EVMWordByteLen))
+}
+
 // LINKBalance returns the balance of LINK at the given address
 func (r *RPCClient) LINKBalance(ctx context.Context, address common.Address, linkAddress common.Address) (*commonassets.Link, error) {
 	balance, err := r.TokenBalance(ctx, address, linkAddress)
@@ -1181,6 +1334,32 @@ func (r *RPCClient) SuggestGasTipCap(ctx context.Context) (tipCap *big.Int, err
 	return
 }
 
+// BlobBaseFee returns the blob base fee of the next block. It's only supported by chains that activated EIP-4844.
+func (r *RPCClient) BlobBaseFee(ctx context.Context) (blobBaseFee *big.Int, err error) {
+	ctx, cancel, ws, http := r.makeLiveQueryCtxAndSafeGetClients(ctx, r.rpcTimeout)
+	defer cancel()
+	lggr := r.newRqLggr()
+
+	lggr.Debug("RPC call: evmclient.Client#BlobBaseFee")
+	start := time.Now()
+	var result hexutil.Big
+	if http != nil {
+		err = r.wrapHTTP(http.rpc.CallContext(ctx, &result, "eth_blobBaseFee"))
+	} else {
+		err = r.wrapWS(ws.rpc.CallContext(ctx, &result, "eth_blobBaseFee"))
+	}
+	duration := time.Since(start)
+	if err == nil {
+		blobBaseFee = result.ToInt()
+	}
+
+	r.logResult(lggr, err, duration, r.getRPCDomain(), "BlobBaseFee",
+		"blobBaseFee", blobBaseFee,
+	)
+
+	return
+}
+
 // Returns the ChainID according to the geth client. This is useful for functions like verify()
 // the common node.
 func (r *RPCClient) ChainID(ctx context.Context) (chainID *big.Int, err error) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"sync/atomic"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
@@ -25,7 +27,10 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/chainlink-framework/multinode"
+	"github.com/smartcontractkit/chainlink-framework/multinode/mocks"
 
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/forwarder"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/reverts"
 	"github.com/smartcontractkit/chainlink-evm/pkg/client"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
@@ -905,3 +910,280 @@ func TestAstarCustomFinality(t *testing.T) {
 		})
 	}
 }
+
+func TestRPCClient_Cassette(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(tests.Context(t), tests.WaitTimeout(t))
+	defer cancel()
+
+	chainId := big.NewInt(123456)
+	lggr := logger.Test(t)
+	server := testutils.NewJSONRPCCassetteServer(t, "../testdata/jsonrpc/rpcClientCassette.json")
+	rpcClient := client.NewRPCClient(client.TestNodePoolConfig{}, lggr, server.WSURL(), nil, "rpc", 1, chainId, multinode.Primary, client.QueryTimeout, client.QueryTimeout, "")
+	require.NoError(t, rpcClient.Dial(ctx))
+	defer rpcClient.Close()
+
+	ch, sub, err := rpcClient.SubscribeToHeads(ctx)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	for _, n := range []int64{1, 2} {
+		select {
+		case head := <-ch:
+			assert.Equal(t, n, head.BlockNumber())
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for head")
+		}
+	}
+
+	addr := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	balance, err := rpcClient.BalanceAt(ctx, addr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), balance)
+
+	var latest, atBlock hexutil.Big
+	reqs := []rpc.BatchElem{
+		{Method: "eth_getBalance", Args: []any{addr, "latest"}, Result: &latest},
+		{Method: "eth_getBalance", Args: []any{addr, "0x2"}, Result: &atBlock},
+	}
+	require.NoError(t, rpcClient.BatchCallContext(ctx, reqs))
+	require.NoError(t, reqs[0].Error)
+	require.NoError(t, reqs[1].Error)
+	assert.Equal(t, big.NewInt(100), latest.ToInt())
+	assert.Equal(t, big.NewInt(50), atBlock.ToInt())
+
+	_, err = rpcClient.CodeAt(ctx, addr, nil)
+	require.ErrorContains(t, err, "header not found")
+}
+
+// revertingService is an eth namespace whose calls revert with data.
+type revertingService struct {
+	data string
+}
+
+type revertingServiceError struct {
+	data string
+}
+
+func (e revertingServiceError) Error() string          { return "execution reverted" }
+func (e revertingServiceError) ErrorCode() int         { return 3 }
+func (e revertingServiceError) ErrorData() interface{} { return e.data }
+
+func (s *revertingService) Call(json.RawMessage, string) (hexutil.Bytes, error) {
+	return nil, revertingServiceError{data: s.data}
+}
+
+func (s *revertingService) EstimateGas(json.RawMessage, *string) (hexutil.Uint64, error) {
+	return 0, revertingServiceError{data: s.data}
+}
+
+func TestRPCClient_DecodesRevertErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(tests.Context(t), tests.WaitTimeout(t))
+	defer cancel()
+
+	forwarderABI, err := forwarder.KeystoneForwarderMetaData.GetAbi()
+	require.NoError(t, err)
+	alreadyAttempted := forwarderABI.Errors["AlreadyAttempted"]
+	args, err := alreadyAttempted.Inputs.Pack(common.HexToHash("0x1234"))
+	require.NoError(t, err)
+
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", &revertingService{data: hexutil.Encode(append(alreadyAttempted.ID[:4:4], args...))}))
+	t.Cleanup(srv.Stop)
+	s := httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+	t.Cleanup(s.Close)
+
+	rpcClient := client.NewRPCClient(client.TestNodePoolConfig{}, logger.Test(t), testutils.WSServerURL(t, s), nil, "rpc", 1, big.NewInt(123456), multinode.Primary, client.QueryTimeout, client.QueryTimeout, "",
+		client.WithRevertErrors(reverts.Registry()))
+	require.NoError(t, rpcClient.Dial(ctx))
+	defer rpcClient.Close()
+
+	to := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	msg := ethereum.CallMsg{To: &to, Data: []byte{1}}
+	const reason = "AlreadyAttempted(bytes32 transmissionId: 0x0000000000000000000000000000000000000000000000000000000000001234)"
+
+	_, err = rpcClient.CallContract(ctx, msg, nil)
+	require.ErrorContains(t, err, "execution reverted: "+reason)
+	require.NotNil(t, client.RevertReason(err))
+	assert.Equal(t, "AlreadyAttempted", client.RevertReason(err).Name)
+
+	_, err = rpcClient.PendingCallContract(ctx, msg)
+	require.ErrorContains(t, err, reason)
+
+	_, err = rpcClient.EstimateGas(ctx, msg)
+	require.ErrorContains(t, err, reason)
+}
+
+func TestRPCClient_ResponseCache(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+	chainId := big.NewInt(123456)
+	lggr := logger.Test(t)
+
+	finalizedHash := common.HexToHash("0x10")
+	unfinalizedHash := common.HexToHash("0x20")
+	txHash := common.HexToHash("0x30")
+	heads := map[string]*evmtypes.Head{
+		finalizedHash.Hex():   {Number: 5, Hash: finalizedHash},
+		unfinalizedHash.Hex(): {Number: 20, Hash: unfinalizedHash},
+	}
+	var finalized atomic.Int64
+	finalized.Store(10)
+	var mu sync.Mutex
+	calls := map[string]int{}
+	countCalls := func(method string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[method]
+	}
+	server := testutils.NewWSServer(t, chainId, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+		mu.Lock()
+		calls[method]++
+		mu.Unlock()
+		var result any
+		switch method {
+		case "eth_getBlockByNumber":
+			result = &evmtypes.Head{Number: finalized.Load(), Hash: common.BigToHash(big.NewInt(finalized.Load()))}
+		case "eth_getBlockByHash":
+			result = heads[params.Array()[0].String()]
+		case "eth_getTransactionReceipt":
+			result = &evmtypes.Receipt{TxHash: txHash, BlockHash: finalizedHash, BlockNumber: big.NewInt(5), Status: 1}
+		case "eth_getCode":
+			result = hexutil.Bytes{0x60, 0x01}
+		}
+		b, err := json.Marshal(result)
+		require.NoError(t, err)
+		resp.Result = string(b)
+		return
+	})
+	cfg := client.TestNodePoolConfig{NodeResponseCache: client.TestResponseCache{EnabledVal: true, SizeVal: 10, DisabledMethodsVal: []string{"CodeAt"}}}
+	rpcClient := client.NewRPCClient(cfg, lggr, server.WSURL(), nil, "rpc", 1, chainId, multinode.Primary, client.QueryTimeout, client.QueryTimeout, "")
+	require.NoError(t, rpcClient.Dial(ctx))
+	defer rpcClient.Close()
+
+	t.Run("nothing is cached until the finalized block is known", func(t *testing.T) {
+		for range 2 {
+			head, err := rpcClient.BlockByHash(ctx, finalizedHash)
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), head.Number)
+		}
+		assert.Equal(t, 2, countCalls("eth_getBlockByHash"))
+	})
+
+	_, err := rpcClient.LatestFinalizedBlock(ctx)
+	require.NoError(t, err)
+
+	t.Run("caches finalized blocks", func(t *testing.T) {
+		for range 3 {
+			head, err := rpcClient.BlockByHash(ctx, finalizedHash)
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), head.Number)
+			assert.Equal(t, finalizedHash, head.Hash)
+			assert.Equal(t, chainId, head.EVMChainID.ToInt())
+		}
+		assert.Equal(t, 3, countCalls("eth_getBlockByHash"))
+
+		for range 2 {
+			head, err := rpcClient.BlockByHash(ctx, unfinalizedHash)
+			require.NoError(t, err)
+			assert.Equal(t, int64(20), head.Number)
+		}
+		assert.Equal(t, 5, countCalls("eth_getBlockByHash"))
+	})
+
+	t.Run("serves batch elements from the cache", func(t *testing.T) {
+		for range 2 {
+			var head evmtypes.Head
+			var receipt evmtypes.Receipt
+			reqs := []rpc.BatchElem{
+				{Method: "eth_getBlockByHash", Args: []any{finalizedHash.Hex(), false}, Result: &head},
+				{Method: "eth_getTransactionReceipt", Args: []any{txHash, false}, Result: &receipt},
+			}
+			require.NoError(t, rpcClient.BatchCallContext(ctx, reqs))
+			require.NoError(t, reqs[0].Error)
+			require.NoError(t, reqs[1].Error)
+			assert.Equal(t, int64(5), head.Number)
+			assert.Equal(t, txHash, receipt.TxHash)
+			assert.Equal(t, big.NewInt(5), receipt.BlockNumber)
+		}
+		assert.Equal(t, 5, countCalls("eth_getBlockByHash"))
+		assert.Equal(t, 1, countCalls("eth_getTransactionReceipt"))
+
+		receipt, err := rpcClient.TransactionReceipt(ctx, txHash)
+		require.NoError(t, err)
+		assert.Equal(t, txHash, receipt.TxHash)
+		assert.Equal(t, 1, countCalls("eth_getTransactionReceipt"))
+	})
+
+	t.Run("disabled methods are not cached", func(t *testing.T) {
+		for range 2 {
+			code, err := rpcClient.CodeAt(ctx, common.HexToAddress("0x01"), big.NewInt(5))
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0x60, 0x01}, code)
+		}
+		assert.Equal(t, 2, countCalls("eth_getCode"))
+	})
+
+	t.Run("evicts the blocks above the finalized block", func(t *testing.T) {
+		finalized.Store(4)
+		_, err := rpcClient.LatestFinalizedBlock(ctx)
+		require.NoError(t, err)
+
+		_, err = rpcClient.BlockByHash(ctx, finalizedHash)
+		require.NoError(t, err)
+		assert.Equal(t, 6, countCalls("eth_getBlockByHash"))
+	})
+}
+
+func TestRPCClient_ResponseCache_FinalityDepth(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+	chainId := big.NewInt(123456)
+	lggr := logger.Test(t)
+
+	// The finalized tag of the RPC is ahead of FinalityDepth, it mustn't be trusted.
+	heads := map[string]*evmtypes.Head{}
+	for _, n := range []int64{5, 15} {
+		heads[common.BigToHash(big.NewInt(n)).Hex()] = &evmtypes.Head{Number: n, Hash: common.BigToHash(big.NewInt(n))}
+	}
+	var calls atomic.Int32
+	server := testutils.NewWSServer(t, chainId, func(method string, params gjson.Result) (resp testutils.JSONRPCResponse) {
+		var result any
+		switch method {
+		case "eth_getBlockByNumber":
+			n := int64(20)
+			if params.Array()[0].String() == rpc.FinalizedBlockNumber.String() {
+				n = 19
+			}
+			result = &evmtypes.Head{Number: n, Hash: common.BigToHash(big.NewInt(n))}
+		case "eth_getBlockByHash":
+			calls.Add(1)
+			result = heads[params.Array()[0].String()]
+		}
+		b, err := json.Marshal(result)
+		require.NoError(t, err)
+		resp.Result = string(b)
+		return
+	})
+	cfg := client.TestNodePoolConfig{NodeResponseCache: client.TestResponseCache{EnabledVal: true, SizeVal: 10}}
+	rpcClient := client.NewRPCClient(cfg, lggr, server.WSURL(), nil, "rpc", 1, chainId, multinode.Primary, client.QueryTimeout, client.QueryTimeout, "",
+		client.WithFinality(mocks.ChainConfig{FinalityDepthVal: 10}))
+	require.NoError(t, rpcClient.Dial(ctx))
+	defer rpcClient.Close()
+
+	_, err := rpcClient.LatestFinalizedBlock(ctx)
+	require.NoError(t, err)
+	_, err = rpcClient.BlockByHash(ctx, common.BigToHash(big.NewInt(5)))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load(), "nothing is finalized until the latest block is known")
+
+	_, err = rpcClient.LatestBlock(ctx)
+	require.NoError(t, err)
+	for range 2 {
+		_, err = rpcClient.BlockByHash(ctx, common.BigToHash(big.NewInt(5)))
+		require.NoError(t, err)
+		_, err = rpcClient.BlockByHash(ctx, common.BigToHash(big.NewInt(15)))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(4), calls.Load(), "only blocks at or below latest - FinalityDepth are cached")
+}
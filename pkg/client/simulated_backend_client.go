@@ -15,8 +15,10 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 
@@ -561,6 +563,18 @@ func (c *SimulatedBackendClient) SuggestGasTipCap(ctx context.Context) (tipCap *
 	return c.client.SuggestGasTipCap(ctx)
 }
 
+// BlobBaseFee returns the blob base fee of the next block. The simulated backend uses params.AllDevChainProtocolChanges.
+func (c *SimulatedBackendClient) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.ExcessBlobGas == nil {
+		return nil, errors.New("blob base fee is not available before Cancun")
+	}
+	return eip4844.CalcBlobFee(params.AllDevChainProtocolChanges, header), nil
+}
+
 func (c *SimulatedBackendClient) Backend() evmtypes.Backend {
 	return c.b
 }
@@ -47,7 +47,7 @@ func (e *EVMConfig) BalanceMonitor() BalanceMonitor {
 }
 
 func (e *EVMConfig) Transactions() Transactions {
-	return &transactionsConfig{c: e.C.Transactions}
+	return &transactionsConfig{c: e.C.Transactions, k: e.C.KeySpecific}
 }
 
 func (e *EVMConfig) HeadTracker() HeadTracker {
@@ -86,6 +86,10 @@ func (e *EVMConfig) LogBackfillBatchSize() uint32 {
 	return *e.C.LogBackfillBatchSize
 }
 
+func (e *EVMConfig) LogBackfillConcurrency() uint32 {
+	return *e.C.LogBackfillConcurrency
+}
+
 func (e *EVMConfig) LogPollInterval() time.Duration {
 	return e.C.LogPollInterval.Duration()
 }
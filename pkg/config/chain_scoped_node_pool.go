@@ -3,6 +3,8 @@ package config
 import (
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
 )
 
@@ -55,3 +57,67 @@ func (n *NodePoolConfig) EnforceRepeatableRead() bool {
 func (n *NodePoolConfig) DeathDeclarationDelay() time.Duration {
 	return n.C.DeathDeclarationDelay.Duration()
 }
+
+func (n *NodePoolConfig) QuorumReads() QuorumReads {
+	return &quorumReadsConfig{c: n.C.QuorumReads}
+}
+
+func (n *NodePoolConfig) Multicall() Multicall {
+	return &multicallConfig{c: n.C.Multicall}
+}
+
+func (n *NodePoolConfig) ResponseCache() ResponseCache {
+	return &responseCacheConfig{c: n.C.ResponseCache}
+}
+
+type quorumReadsConfig struct {
+	c toml.QuorumReads
+}
+
+func (q *quorumReadsConfig) Nodes() uint32 {
+	return *q.c.Nodes
+}
+
+func (q *quorumReadsConfig) Methods() []string {
+	return q.c.Methods
+}
+
+type multicallConfig struct {
+	c toml.Multicall
+}
+
+func (m *multicallConfig) Enabled() bool {
+	return *m.c.Enabled
+}
+
+func (m *multicallConfig) Address() common.Address {
+	return m.c.Address.Address()
+}
+
+func (m *multicallConfig) BatchWindow() time.Duration {
+	return m.c.BatchWindow.Duration()
+}
+
+func (m *multicallConfig) MaxBatchSize() uint32 {
+	return *m.c.MaxBatchSize
+}
+
+func (m *multicallConfig) GasCap() uint64 {
+	return *m.c.GasCap
+}
+
+type responseCacheConfig struct {
+	c toml.ResponseCache
+}
+
+func (r *responseCacheConfig) Enabled() bool {
+	return *r.c.Enabled
+}
+
+func (r *responseCacheConfig) Size() uint32 {
+	return *r.c.Size
+}
+
+func (r *responseCacheConfig) DisabledMethods() []string {
+	return r.c.DisabledMethods
+}
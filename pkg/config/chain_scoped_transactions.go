@@ -4,11 +4,14 @@ import (
 	"net/url"
 	"time"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
 )
 
 type transactionsConfig struct {
 	c toml.Transactions
+	k toml.KeySpecificConfig
 }
 
 func (t *transactionsConfig) Enabled() bool {
@@ -40,11 +43,12 @@ func (t *transactionsConfig) MaxQueued() uint64 {
 }
 
 func (t *transactionsConfig) TransactionManagerV2() TransactionManagerV2 {
-	return &transactionManagerV2Config{c: t.c.TransactionManagerV2}
+	return &transactionManagerV2Config{c: t.c.TransactionManagerV2, k: t.k}
 }
 
 type transactionManagerV2Config struct {
 	c toml.TransactionManagerV2Config
+	k toml.KeySpecificConfig
 }
 
 func (t *transactionManagerV2Config) Enabled() bool {
@@ -64,6 +68,60 @@ func (t *transactionManagerV2Config) DualBroadcast() *bool {
 	return t.c.DualBroadcast
 }
 
+func (t *transactionManagerV2Config) MaxInFlight() uint32 {
+	return *t.c.MaxInFlight
+}
+
+func (t *transactionManagerV2Config) MaxInFlightSubset() uint32 {
+	return *t.c.MaxInFlightSubset
+}
+
+func (t *transactionManagerV2Config) MaxAttempts() uint16 {
+	return *t.c.MaxAttempts
+}
+
+func (t *transactionManagerV2Config) MaxQueued() uint32 {
+	return *t.c.MaxQueued
+}
+
+func (t *transactionManagerV2Config) EscalationBlockThreshold() uint32 {
+	return *t.c.EscalationBlockThreshold
+}
+
+func (t *transactionManagerV2Config) MaxBumpsPerAttempt() uint32 {
+	return *t.c.MaxBumpsPerAttempt
+}
+
+func (t *transactionManagerV2Config) MaxInFlightKey(addr gethcommon.Address) uint32 {
+	if ks := t.keySpecific(addr); ks != nil && ks.MaxInFlight != nil {
+		return *ks.MaxInFlight
+	}
+	return t.MaxInFlight()
+}
+
+func (t *transactionManagerV2Config) MaxInFlightSubsetKey(addr gethcommon.Address) uint32 {
+	if ks := t.keySpecific(addr); ks != nil && ks.MaxInFlightSubset != nil {
+		return *ks.MaxInFlightSubset
+	}
+	return t.MaxInFlightSubset()
+}
+
+func (t *transactionManagerV2Config) MaxQueuedKey(addr gethcommon.Address) uint32 {
+	if ks := t.keySpecific(addr); ks != nil && ks.MaxQueued != nil {
+		return *ks.MaxQueued
+	}
+	return t.MaxQueued()
+}
+
+func (t *transactionManagerV2Config) keySpecific(addr gethcommon.Address) *toml.KeySpecificTransactionManagerV2 {
+	for i := range t.k {
+		if t.k[i].Key.Address() == addr {
+			return &t.k[i].TransactionManagerV2
+		}
+	}
+	return nil
+}
+
 func (t *transactionsConfig) AutoPurge() AutoPurgeConfig {
 	return &autoPurgeConfig{c: t.c.AutoPurge}
 }
@@ -87,3 +145,7 @@ func (a *autoPurgeConfig) MinAttempts() *uint32 {
 func (a *autoPurgeConfig) DetectionApiUrl() *url.URL {
 	return a.c.DetectionApiUrl.URL()
 }
+
+func (a *autoPurgeConfig) MempoolDetection() bool {
+	return *a.c.MempoolDetection
+}
@@ -36,6 +36,7 @@ type EVM interface {
 	FlagsContractAddress() string
 	LinkContractAddress() string
 	LogBackfillBatchSize() uint32
+	LogBackfillConcurrency() uint32
 	LogKeepBlocksDepth() uint32
 	BackupLogPollerBlockDelay() uint64
 	LogPollInterval() time.Duration
@@ -119,6 +120,7 @@ type AutoPurgeConfig interface {
 	Threshold() *uint32
 	MinAttempts() *uint32
 	DetectionApiUrl() *url.URL
+	MempoolDetection() bool
 }
 
 type TransactionManagerV2 interface {
@@ -126,6 +128,15 @@ type TransactionManagerV2 interface {
 	BlockTime() *time.Duration
 	CustomURL() *url.URL
 	DualBroadcast() *bool
+	MaxInFlight() uint32
+	MaxInFlightSubset() uint32
+	MaxAttempts() uint16
+	MaxQueued() uint32
+	EscalationBlockThreshold() uint32
+	MaxBumpsPerAttempt() uint32
+	MaxInFlightKey(addr gethcommon.Address) uint32
+	MaxInFlightSubsetKey(addr gethcommon.Address) uint32
+	MaxQueuedKey(addr gethcommon.Address) uint32
 }
 
 type GasEstimator interface {
@@ -202,6 +213,38 @@ type NodePool interface {
 	DeathDeclarationDelay() time.Duration
 	NewHeadsPollInterval() time.Duration
 	VerifyChainID() bool
+	QuorumReads() QuorumReads
+	Multicall() Multicall
+	ResponseCache() ResponseCache
+}
+
+type QuorumReads interface {
+	// Nodes is the number of nodes each quorum read is sent to, 0 if quorum reads are disabled.
+	Nodes() uint32
+	// Methods are the names of the client methods read with a quorum.
+	Methods() []string
+}
+
+type Multicall interface {
+	// Enabled is whether concurrent reads of the same block are batched into Multicall3 calls.
+	Enabled() bool
+	// Address is the address of the Multicall3 contract.
+	Address() gethcommon.Address
+	// BatchWindow is how long the first read of a batch waits for others to join it.
+	BatchWindow() time.Duration
+	// MaxBatchSize is the maximum number of reads of a batch.
+	MaxBatchSize() uint32
+	// GasCap is the gas limit of each batch, 0 to use the gas cap of the RPC.
+	GasCap() uint64
+}
+
+type ResponseCache interface {
+	// Enabled is whether the responses of finalized blocks are cached.
+	Enabled() bool
+	// Size is the maximum number of cached responses of each node.
+	Size() uint32
+	// DisabledMethods are the names of the client methods whose responses are never cached.
+	DisabledMethods() []string
 }
 
 type ChainScopedConfig interface {
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -50,6 +51,15 @@ func TestChainScopedConfig(t *testing.T) {
 		})
 	})
 
+	t.Run("LogBackfillConcurrency", func(t *testing.T) {
+		assert.Equal(t, uint32(1), cfg.EVM().LogBackfillConcurrency())
+
+		cfg2 := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+			c.LogBackfillConcurrency = ptr[uint32](4)
+		})
+		assert.Equal(t, uint32(4), cfg2.EVM().LogBackfillConcurrency())
+	})
+
 	t.Run("PriceMaxKey", func(t *testing.T) {
 		addr := utils.NewAddress()
 		randomOtherAddr := utils.NewAddress()
@@ -358,6 +368,36 @@ func TestChainScopedConfig_HeadTracker(t *testing.T) {
 	assert.True(t, ht.PersistenceEnabled())
 }
 
+func TestChainScopedConfig_TransactionManagerV2(t *testing.T) {
+	t.Parallel()
+	addr := utils.NewAddress()
+	otherAddr := utils.NewAddress()
+	cfg := configtest.NewChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.KeySpecific = toml.KeySpecificConfig{
+			{Key: ptr(types.EIP55AddressFromAddress(addr)),
+				TransactionManagerV2: toml.KeySpecificTransactionManagerV2{
+					MaxInFlight: ptr[uint32](32),
+					MaxQueued:   ptr[uint32](1000),
+				},
+			},
+		}
+	})
+
+	txmv2 := cfg.EVM().Transactions().TransactionManagerV2()
+	assert.Equal(t, uint32(16), txmv2.MaxInFlight())
+	assert.Equal(t, uint32(5), txmv2.MaxInFlightSubset())
+	assert.Equal(t, uint16(10), txmv2.MaxAttempts())
+	assert.Equal(t, uint32(250), txmv2.MaxQueued())
+	assert.Equal(t, uint32(0), txmv2.EscalationBlockThreshold())
+	assert.Equal(t, uint32(8), txmv2.MaxBumpsPerAttempt())
+
+	assert.Equal(t, uint32(32), txmv2.MaxInFlightKey(addr))
+	assert.Equal(t, uint32(5), txmv2.MaxInFlightSubsetKey(addr))
+	assert.Equal(t, uint32(1000), txmv2.MaxQueuedKey(addr))
+	assert.Equal(t, uint32(16), txmv2.MaxInFlightKey(otherAddr))
+	assert.Equal(t, uint32(250), txmv2.MaxQueuedKey(otherAddr))
+}
+
 func TestNodePoolConfig(t *testing.T) {
 	cfg := configtest.NewChainScopedConfig(t, nil)
 
@@ -368,6 +408,16 @@ func TestNodePoolConfig(t *testing.T) {
 	require.False(t, cfg.EVM().NodePool().NodeIsSyncingEnabled())
 	require.True(t, cfg.EVM().NodePool().EnforceRepeatableRead())
 	require.Equal(t, time.Minute, cfg.EVM().NodePool().DeathDeclarationDelay())
+	require.Equal(t, uint32(0), cfg.EVM().NodePool().QuorumReads().Nodes())
+	require.Empty(t, cfg.EVM().NodePool().QuorumReads().Methods())
+	require.False(t, cfg.EVM().NodePool().Multicall().Enabled())
+	require.Equal(t, common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"), cfg.EVM().NodePool().Multicall().Address())
+	require.Equal(t, 10*time.Millisecond, cfg.EVM().NodePool().Multicall().BatchWindow())
+	require.Equal(t, uint32(100), cfg.EVM().NodePool().Multicall().MaxBatchSize())
+	require.Zero(t, cfg.EVM().NodePool().Multicall().GasCap())
+	require.False(t, cfg.EVM().NodePool().ResponseCache().Enabled())
+	require.Equal(t, uint32(1000), cfg.EVM().NodePool().ResponseCache().Size())
+	require.Empty(t, cfg.EVM().NodePool().ResponseCache().DisabledMethods())
 }
 
 func TestClientErrorsConfig(t *testing.T) {
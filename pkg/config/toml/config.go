@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
@@ -334,6 +335,7 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 		err = multierr.Append(err, commonconfig.ErrMissing{Name: "Nodes", Msg: "must have at least one node"})
 	} else {
 		var hasPrimary bool
+		var primaries uint32
 		var logBroadcasterEnabled bool
 		var newHeadsPollingInterval commonconfig.Duration
 		if c.LogBroadcasterEnabled != nil {
@@ -350,6 +352,7 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 			}
 
 			hasPrimary = true
+			primaries++
 
 			// if the node is a primary node, then the WS URL is required when
 			//	1. LogBroadcaster is enabled
@@ -367,6 +370,11 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 			err = multierr.Append(err, commonconfig.ErrMissing{Name: "Nodes",
 				Msg: "must have at least one primary node"})
 		}
+
+		if q := c.NodePool.QuorumReads.Nodes; q != nil && *q > primaries {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "NodePool.QuorumReads.Nodes", Value: *q,
+				Msg: fmt.Sprintf("must not exceed the number of primary nodes (%d)", primaries)})
+		}
 	}
 
 	err = multierr.Append(err, c.Chain.ValidateConfig())
@@ -393,6 +401,7 @@ type Chain struct {
 	FlagsContractAddress         *types.EIP55Address
 	LinkContractAddress          *types.EIP55Address
 	LogBackfillBatchSize         *uint32
+	LogBackfillConcurrency       *uint32
 	LogPollInterval              *commonconfig.Duration
 	LogKeepBlocksDepth           *uint32
 	LogPrunePageSize             *uint32
@@ -437,12 +446,39 @@ func (c *Chain) ValidateConfig() (err error) {
 		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MinIncomingConfirmations", Value: *c.MinIncomingConfirmations,
 			Msg: "must be greater than or equal to 1"})
 	}
+	if *c.LogBackfillConcurrency < 1 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "LogBackfillConcurrency", Value: *c.LogBackfillConcurrency,
+			Msg: "must be greater than or equal to 1"})
+	}
 
 	if *c.FinalizedBlockOffset > *c.HeadTracker.HistoryDepth {
 		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "HeadTracker.HistoryDepth", Value: *c.HeadTracker.HistoryDepth,
 			Msg: "must be greater than or equal to FinalizedBlockOffset"})
 	}
 
+	// Key specific TransactionManagerV2 limits fall back to the chain ones, so validate the effective pairs
+	txmv2 := c.Transactions.TransactionManagerV2
+	for i, k := range c.KeySpecific {
+		ks := k.TransactionManagerV2
+		if ks.MaxInFlight != nil && ks.MaxInFlightSubset != nil {
+			continue // validated by KeySpecificTransactionManagerV2
+		}
+		maxInFlight, maxInFlightSubset := ks.MaxInFlight, ks.MaxInFlightSubset
+		if maxInFlight == nil {
+			maxInFlight = txmv2.MaxInFlight
+		}
+		if maxInFlightSubset == nil {
+			maxInFlightSubset = txmv2.MaxInFlightSubset
+		}
+		if maxInFlight == nil || maxInFlightSubset == nil || (ks.MaxInFlight == nil && ks.MaxInFlightSubset == nil) {
+			continue
+		}
+		if *maxInFlightSubset > *maxInFlight {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: fmt.Sprintf("KeySpecific[%d].TransactionManagerV2.MaxInFlightSubset", i), Value: *maxInFlightSubset,
+				Msg: fmt.Sprintf("must be less than or equal to the effective MaxInFlight (%d)", *maxInFlight)})
+		}
+	}
+
 	// AutoPurge configs depend on ChainType so handling validation on per chain basis
 	if c.Transactions.AutoPurge.Enabled != nil && *c.Transactions.AutoPurge.Enabled {
 		chainType := c.ChainType.ChainType()
@@ -553,10 +589,11 @@ func (t *Transactions) setFrom(f *Transactions) {
 }
 
 type AutoPurgeConfig struct {
-	Enabled         *bool
-	Threshold       *uint32
-	MinAttempts     *uint32
-	DetectionApiUrl *commonconfig.URL
+	Enabled          *bool
+	Threshold        *uint32
+	MinAttempts      *uint32
+	DetectionApiUrl  *commonconfig.URL
+	MempoolDetection *bool
 }
 
 func (a *AutoPurgeConfig) setFrom(f *AutoPurgeConfig) {
@@ -572,13 +609,23 @@ func (a *AutoPurgeConfig) setFrom(f *AutoPurgeConfig) {
 	if v := f.DetectionApiUrl; v != nil {
 		a.DetectionApiUrl = v
 	}
+	if v := f.MempoolDetection; v != nil {
+		a.MempoolDetection = v
+	}
 }
 
 type TransactionManagerV2Config struct {
-	Enabled       *bool                  `toml:",omitempty"`
-	BlockTime     *commonconfig.Duration `toml:",omitempty"`
-	CustomURL     *commonconfig.URL      `toml:",omitempty"`
-	DualBroadcast *bool                  `toml:",omitempty"`
+	Enabled           *bool                  `toml:",omitempty"`
+	BlockTime         *commonconfig.Duration `toml:",omitempty"`
+	CustomURL         *commonconfig.URL      `toml:",omitempty"`
+	DualBroadcast     *bool                  `toml:",omitempty"`
+	MaxInFlight       *uint32                `toml:",omitempty"`
+	MaxInFlightSubset *uint32                `toml:",omitempty"`
+	MaxAttempts       *uint16                `toml:",omitempty"`
+	MaxQueued         *uint32                `toml:",omitempty"`
+
+	EscalationBlockThreshold *uint32 `toml:",omitempty"`
+	MaxBumpsPerAttempt       *uint32 `toml:",omitempty"`
 }
 
 func (t *TransactionManagerV2Config) setFrom(f *TransactionManagerV2Config) {
@@ -594,6 +641,24 @@ func (t *TransactionManagerV2Config) setFrom(f *TransactionManagerV2Config) {
 	if v := f.DualBroadcast; v != nil {
 		t.DualBroadcast = f.DualBroadcast
 	}
+	if v := f.MaxInFlight; v != nil {
+		t.MaxInFlight = v
+	}
+	if v := f.MaxInFlightSubset; v != nil {
+		t.MaxInFlightSubset = v
+	}
+	if v := f.MaxAttempts; v != nil {
+		t.MaxAttempts = v
+	}
+	if v := f.MaxQueued; v != nil {
+		t.MaxQueued = v
+	}
+	if v := f.EscalationBlockThreshold; v != nil {
+		t.EscalationBlockThreshold = v
+	}
+	if v := f.MaxBumpsPerAttempt; v != nil {
+		t.MaxBumpsPerAttempt = v
+	}
 }
 
 func (t *TransactionManagerV2Config) ValidateConfig() (err error) {
@@ -606,6 +671,30 @@ func (t *TransactionManagerV2Config) ValidateConfig() (err error) {
 			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "BlockTime", Msg: "must be equal to or greater than 2 seconds"})
 		}
 	}
+	err = multierr.Append(err, validateTransactionManagerV2Limits(t.MaxInFlight, t.MaxInFlightSubset, t.MaxQueued))
+	if t.MaxAttempts != nil && *t.MaxAttempts == 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxAttempts", Value: 0, Msg: "must be greater than 0"})
+	}
+	if t.MaxBumpsPerAttempt != nil && *t.MaxBumpsPerAttempt == 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxBumpsPerAttempt", Value: 0, Msg: "must be greater than 0"})
+	}
+	return
+}
+
+func validateTransactionManagerV2Limits(maxInFlight, maxInFlightSubset, maxQueued *uint32) (err error) {
+	if maxInFlight != nil && *maxInFlight == 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxInFlight", Value: 0, Msg: "must be greater than 0"})
+	}
+	if maxInFlightSubset != nil {
+		if *maxInFlightSubset == 0 {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxInFlightSubset", Value: 0, Msg: "must be greater than 0"})
+		} else if maxInFlight != nil && *maxInFlightSubset > *maxInFlight {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxInFlightSubset", Value: *maxInFlightSubset, Msg: "must be less than or equal to MaxInFlight"})
+		}
+	}
+	if maxQueued != nil && *maxQueued == 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxQueued", Value: 0, Msg: "must be greater than 0"})
+	}
 	return
 }
 
@@ -923,8 +1012,9 @@ func (ks KeySpecificConfig) ValidateConfig() (err error) {
 }
 
 type KeySpecific struct {
-	Key          *types.EIP55Address
-	GasEstimator KeySpecificGasEstimator `toml:",omitempty"`
+	Key                  *types.EIP55Address
+	GasEstimator         KeySpecificGasEstimator         `toml:",omitempty"`
+	TransactionManagerV2 KeySpecificTransactionManagerV2 `toml:",omitempty"`
 }
 
 type KeySpecificGasEstimator struct {
@@ -937,6 +1027,28 @@ func (e *KeySpecificGasEstimator) setFrom(f *KeySpecificGasEstimator) {
 	}
 }
 
+type KeySpecificTransactionManagerV2 struct {
+	MaxInFlight       *uint32 `toml:",omitempty"`
+	MaxInFlightSubset *uint32 `toml:",omitempty"`
+	MaxQueued         *uint32 `toml:",omitempty"`
+}
+
+func (t *KeySpecificTransactionManagerV2) setFrom(f *KeySpecificTransactionManagerV2) {
+	if v := f.MaxInFlight; v != nil {
+		t.MaxInFlight = v
+	}
+	if v := f.MaxInFlightSubset; v != nil {
+		t.MaxInFlightSubset = v
+	}
+	if v := f.MaxQueued; v != nil {
+		t.MaxQueued = v
+	}
+}
+
+func (t *KeySpecificTransactionManagerV2) ValidateConfig() error {
+	return validateTransactionManagerV2Limits(t.MaxInFlight, t.MaxInFlightSubset, t.MaxQueued)
+}
+
 type HeadTracker struct {
 	HistoryDepth            *uint32
 	MaxBufferSize           *uint32
@@ -1056,6 +1168,9 @@ type NodePool struct {
 	DeathDeclarationDelay      *commonconfig.Duration
 	NewHeadsPollInterval       *commonconfig.Duration
 	VerifyChainID              *bool
+	QuorumReads                QuorumReads   `toml:",omitempty"`
+	Multicall                  Multicall     `toml:",omitempty"`
+	ResponseCache              ResponseCache `toml:",omitempty"`
 }
 
 func (p *NodePool) setFrom(f *NodePool) {
@@ -1098,6 +1213,9 @@ func (p *NodePool) setFrom(f *NodePool) {
 	}
 
 	p.Errors.setFrom(&f.Errors)
+	p.QuorumReads.setFrom(&f.QuorumReads)
+	p.Multicall.setFrom(&f.Multicall)
+	p.ResponseCache.setFrom(&f.ResponseCache)
 }
 
 func (p *NodePool) ValidateConfig(finalityTagEnabled *bool) (err error) {
@@ -1114,6 +1232,115 @@ func (p *NodePool) ValidateConfig(finalityTagEnabled *bool) (err error) {
 	return
 }
 
+// QuorumReadMethods are the client methods which support quorum reads.
+var QuorumReadMethods = []string{"BalanceAt", "CallContract", "CodeAt", "FilterLogs", "HeaderByHash", "HeaderByNumber", "NonceAt"}
+
+type QuorumReads struct {
+	Nodes   *uint32
+	Methods []string `toml:",omitempty"`
+}
+
+func (q *QuorumReads) setFrom(f *QuorumReads) {
+	if v := f.Nodes; v != nil {
+		q.Nodes = v
+	}
+	if v := f.Methods; v != nil {
+		q.Methods = v
+	}
+}
+
+func (q *QuorumReads) ValidateConfig() (err error) {
+	if q.Nodes != nil && *q.Nodes == 1 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Nodes", Value: *q.Nodes, Msg: "must be 0 to disable quorum reads, or at least 2"})
+	}
+	for _, m := range q.Methods {
+		if !slices.Contains(QuorumReadMethods, m) {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Methods", Value: m,
+				Msg: fmt.Sprintf("quorum reads are only supported by %s", strings.Join(QuorumReadMethods, ", "))})
+		}
+	}
+	return
+}
+
+type Multicall struct {
+	Enabled      *bool
+	Address      *types.EIP55Address
+	BatchWindow  *commonconfig.Duration
+	MaxBatchSize *uint32
+	GasCap       *uint64
+}
+
+func (m *Multicall) setFrom(f *Multicall) {
+	if v := f.Enabled; v != nil {
+		m.Enabled = v
+	}
+	if v := f.Address; v != nil {
+		m.Address = v
+	}
+	if v := f.BatchWindow; v != nil {
+		m.BatchWindow = v
+	}
+	if v := f.MaxBatchSize; v != nil {
+		m.MaxBatchSize = v
+	}
+	if v := f.GasCap; v != nil {
+		m.GasCap = v
+	}
+}
+
+func (m *Multicall) ValidateConfig() (err error) {
+	if m.Enabled == nil || !*m.Enabled {
+		return
+	}
+	if m.Address == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "Address", Msg: "required when Multicall is enabled"})
+	}
+	if m.BatchWindow != nil && m.BatchWindow.Duration() < 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "BatchWindow", Value: m.BatchWindow, Msg: "must not be negative"})
+	}
+	if m.MaxBatchSize != nil && *m.MaxBatchSize < 2 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MaxBatchSize", Value: *m.MaxBatchSize, Msg: "must be at least 2"})
+	}
+	return
+}
+
+// ResponseCacheMethods are the client methods whose responses can be cached.
+var ResponseCacheMethods = []string{"BlockByHash", "CodeAt", "HeaderByHash", "TransactionReceipt"}
+
+type ResponseCache struct {
+	Enabled         *bool
+	Size            *uint32
+	DisabledMethods []string `toml:",omitempty"`
+}
+
+func (r *ResponseCache) setFrom(f *ResponseCache) {
+	if v := f.Enabled; v != nil {
+		r.Enabled = v
+	}
+	if v := f.Size; v != nil {
+		r.Size = v
+	}
+	if v := f.DisabledMethods; v != nil {
+		r.DisabledMethods = v
+	}
+}
+
+func (r *ResponseCache) ValidateConfig() (err error) {
+	for _, m := range r.DisabledMethods {
+		if !slices.Contains(ResponseCacheMethods, m) {
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "DisabledMethods", Value: m,
+				Msg: fmt.Sprintf("only the responses of %s are cached", strings.Join(ResponseCacheMethods, ", "))})
+		}
+	}
+	if r.Enabled == nil || !*r.Enabled {
+		return
+	}
+	if r.Size != nil && *r.Size == 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Size", Value: *r.Size, Msg: "must be greater than 0"})
+	}
+	return
+}
+
 type OCR struct {
 	ContractConfirmations              *uint16
 	ContractTransmitterTransmitTimeout *commonconfig.Duration
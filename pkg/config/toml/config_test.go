@@ -44,6 +44,154 @@ func TestEVMConfig_ValidateConfig(t *testing.T) {
 			assert.NoError(t, config.Validate(evmCfg))
 		})
 	}
+
+	t.Run("key specific TransactionManagerV2 limits", func(t *testing.T) {
+		for _, tt := range []struct {
+			name   string
+			chain  TransactionManagerV2Config
+			key    KeySpecificTransactionManagerV2
+			expErr string
+		}{
+			{"valid", TransactionManagerV2Config{MaxInFlight: ptr[uint32](16), MaxInFlightSubset: ptr[uint32](8)}, KeySpecificTransactionManagerV2{MaxInFlightSubset: ptr[uint32](16)}, ""},
+			{"key MaxInFlightSubset above chain MaxInFlight", TransactionManagerV2Config{MaxInFlight: ptr[uint32](16), MaxInFlightSubset: ptr[uint32](8)}, KeySpecificTransactionManagerV2{MaxInFlightSubset: ptr[uint32](20)},
+				"KeySpecific[0].TransactionManagerV2.MaxInFlightSubset: invalid value (20): must be less than or equal to the effective MaxInFlight (16)"},
+			{"chain MaxInFlightSubset above key MaxInFlight", TransactionManagerV2Config{MaxInFlight: ptr[uint32](16), MaxInFlightSubset: ptr[uint32](8)}, KeySpecificTransactionManagerV2{MaxInFlight: ptr[uint32](4)},
+				"KeySpecific[0].TransactionManagerV2.MaxInFlightSubset: invalid value (8): must be less than or equal to the effective MaxInFlight (4)"},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				id := DefaultIDs[0]
+				evmCfg := &EVMConfig{
+					ChainID: id,
+					Chain:   Defaults(id),
+					Nodes: EVMNodes{{
+						Name:    &name,
+						WSURL:   config.MustParseURL("wss://foo.test/ws"),
+						HTTPURL: config.MustParseURL("http://foo.test"),
+					}},
+				}
+				evmCfg.Transactions.TransactionManagerV2.setFrom(&tt.chain)
+				evmCfg.KeySpecific = KeySpecificConfig{{Key: asEIP55Address(t, "0x1234567890abcdefaC8a1b4E58707D29258707D2"), TransactionManagerV2: tt.key}}
+
+				err := config.Validate(evmCfg)
+				if tt.expErr == "" {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorContains(t, err, tt.expErr)
+			})
+		}
+	})
+}
+
+func TestTransactionManagerV2Config_ValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		cfg    TransactionManagerV2Config
+		expErr string
+	}{
+		{"valid", TransactionManagerV2Config{MaxInFlight: ptr[uint32](16), MaxInFlightSubset: ptr[uint32](16), MaxAttempts: ptr[uint16](1), MaxQueued: ptr[uint32](1)}, ""},
+		{"zero MaxInFlight", TransactionManagerV2Config{MaxInFlight: ptr[uint32](0)}, "MaxInFlight: invalid value (0): must be greater than 0"},
+		{"zero MaxInFlightSubset", TransactionManagerV2Config{MaxInFlightSubset: ptr[uint32](0)}, "MaxInFlightSubset: invalid value (0): must be greater than 0"},
+		{"MaxInFlightSubset above MaxInFlight", TransactionManagerV2Config{MaxInFlight: ptr[uint32](4), MaxInFlightSubset: ptr[uint32](5)}, "MaxInFlightSubset: invalid value (5): must be less than or equal to MaxInFlight"},
+		{"zero MaxAttempts", TransactionManagerV2Config{MaxAttempts: ptr[uint16](0)}, "MaxAttempts: invalid value (0): must be greater than 0"},
+		{"zero MaxQueued", TransactionManagerV2Config{MaxQueued: ptr[uint32](0)}, "MaxQueued: invalid value (0): must be greater than 0"},
+		{"zero MaxBumpsPerAttempt", TransactionManagerV2Config{MaxBumpsPerAttempt: ptr[uint32](0)}, "MaxBumpsPerAttempt: invalid value (0): must be greater than 0"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateConfig()
+			if tt.expErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expErr)
+		})
+	}
+
+	t.Run("key specific", func(t *testing.T) {
+		err := config.Validate(KeySpecificConfig{{Key: asEIP55Address(t, "0x1234567890abcdefaC8a1b4E58707D29258707D2"), TransactionManagerV2: KeySpecificTransactionManagerV2{MaxQueued: ptr[uint32](0)}}})
+		require.ErrorContains(t, err, "MaxQueued: invalid value (0): must be greater than 0")
+	})
+}
+
+func TestQuorumReads_ValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		cfg    QuorumReads
+		expErr string
+	}{
+		{"disabled", QuorumReads{Nodes: ptr[uint32](0)}, ""},
+		{"valid", QuorumReads{Nodes: ptr[uint32](3), Methods: []string{"CallContract", "FilterLogs"}}, ""},
+		{"single node", QuorumReads{Nodes: ptr[uint32](1)}, "Nodes: invalid value (1): must be 0 to disable quorum reads, or at least 2"},
+		{"unsupported method", QuorumReads{Methods: []string{"SendTransaction"}}, "Methods: invalid value (SendTransaction): quorum reads are only supported by"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateConfig()
+			if tt.expErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expErr)
+		})
+	}
+
+	t.Run("more nodes than primaries", func(t *testing.T) {
+		name := "fake"
+		evmCfg := &EVMConfig{
+			ChainID: big.NewI(1),
+			Chain:   Defaults(big.NewI(1)),
+			Nodes: EVMNodes{{
+				Name:    &name,
+				WSURL:   config.MustParseURL("wss://foo.test/ws"),
+				HTTPURL: config.MustParseURL("http://foo.test"),
+			}},
+		}
+		evmCfg.NodePool.QuorumReads.Nodes = ptr[uint32](2)
+		require.ErrorContains(t, config.Validate(evmCfg), "NodePool.QuorumReads.Nodes: invalid value (2): must not exceed the number of primary nodes (1)")
+	})
+}
+
+func TestResponseCache_ValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		cfg    ResponseCache
+		expErr string
+	}{
+		{"disabled", ResponseCache{Enabled: ptr(false), Size: ptr[uint32](0)}, ""},
+		{"valid", ResponseCache{Enabled: ptr(true), Size: ptr[uint32](10), DisabledMethods: []string{"CodeAt", "TransactionReceipt"}}, ""},
+		{"empty", ResponseCache{Enabled: ptr(true), Size: ptr[uint32](0)}, "Size: invalid value (0): must be greater than 0"},
+		{"unknown method", ResponseCache{DisabledMethods: []string{"CallContract"}}, "DisabledMethods: invalid value (CallContract): only the responses of BlockByHash, CodeAt, HeaderByHash, TransactionReceipt are cached"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateConfig()
+			if tt.expErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestMulticall_ValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		cfg    Multicall
+		expErr string
+	}{
+		{"disabled", Multicall{Enabled: ptr(false)}, ""},
+		{"valid", Multicall{Enabled: ptr(true), Address: ptr(types.MustEIP55Address("0xcA11bde05977b3631167028862bE2a173976CA11")), MaxBatchSize: ptr[uint32](10)}, ""},
+		{"missing address", Multicall{Enabled: ptr(true)}, "Address: missing: required when Multicall is enabled"},
+		{"batch too small", Multicall{Enabled: ptr(true), Address: ptr(types.MustEIP55Address("0xcA11bde05977b3631167028862bE2a173976CA11")), MaxBatchSize: ptr[uint32](1)}, "MaxBatchSize: invalid value (1): must be at least 2"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateConfig()
+			if tt.expErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expErr)
+		})
+	}
 }
 
 func TestDefaults_fieldsNotNil(t *testing.T) {
@@ -115,7 +263,12 @@ func TestDocs(t *testing.T) {
 		// clean up KeySpecific as a special case
 		require.Len(t, docDefaults.KeySpecific, 1)
 		ks := KeySpecific{Key: new(types.EIP55Address),
-			GasEstimator: KeySpecificGasEstimator{PriceMax: new(assets.Wei)}}
+			GasEstimator: KeySpecificGasEstimator{PriceMax: new(assets.Wei)},
+			TransactionManagerV2: KeySpecificTransactionManagerV2{
+				MaxInFlight:       new(uint32),
+				MaxInFlightSubset: new(uint32),
+				MaxQueued:         new(uint32),
+			}}
 		require.Equal(t, ks, docDefaults.KeySpecific[0])
 		docDefaults.KeySpecific = nil
 
@@ -243,11 +396,17 @@ var fullConfig = EVMConfig{
 				GasEstimator: KeySpecificGasEstimator{
 					PriceMax: assets.NewWei(new(stdbig.Int).SetBytes([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF})),
 				},
+				TransactionManagerV2: KeySpecificTransactionManagerV2{
+					MaxInFlight:       ptr[uint32](32),
+					MaxInFlightSubset: ptr[uint32](10),
+					MaxQueued:         ptr[uint32](1000),
+				},
 			},
 		},
 
 		LinkContractAddress:          ptr(types.MustEIP55Address("0x538aAaB4ea120b2bC2fe5D296852D948F07D849e")),
 		LogBackfillBatchSize:         ptr[uint32](17),
+		LogBackfillConcurrency:       ptr[uint32](3),
 		LogPollInterval:              config.MustNewDuration(time.Minute),
 		LogKeepBlocksDepth:           ptr[uint32](100000),
 		LogPrunePageSize:             ptr[uint32](0),
@@ -271,16 +430,24 @@ var fullConfig = EVMConfig{
 			ResendAfterThreshold: config.MustNewDuration(time.Hour),
 			ForwardersEnabled:    ptr(true),
 			AutoPurge: AutoPurgeConfig{
-				Enabled:         ptr(false),
-				Threshold:       ptr[uint32](42),
-				MinAttempts:     ptr[uint32](13),
-				DetectionApiUrl: config.MustParseURL("http://example.net"),
+				Enabled:          ptr(false),
+				Threshold:        ptr[uint32](42),
+				MinAttempts:      ptr[uint32](13),
+				DetectionApiUrl:  config.MustParseURL("http://example.net"),
+				MempoolDetection: ptr(true),
 			},
 			TransactionManagerV2: TransactionManagerV2Config{
-				Enabled:       ptr(false),
-				DualBroadcast: ptr(true),
-				BlockTime:     config.MustNewDuration(42 * time.Second),
-				CustomURL:     config.MustParseURL("http://txs.org"),
+				Enabled:           ptr(false),
+				DualBroadcast:     ptr(true),
+				BlockTime:         config.MustNewDuration(42 * time.Second),
+				CustomURL:         config.MustParseURL("http://txs.org"),
+				MaxInFlight:       ptr[uint32](20),
+				MaxInFlightSubset: ptr[uint32](7),
+				MaxAttempts:       ptr[uint16](12),
+				MaxQueued:         ptr[uint32](300),
+
+				EscalationBlockThreshold: ptr[uint32](6),
+				MaxBumpsPerAttempt:       ptr[uint32](4),
 			},
 		},
 
@@ -322,6 +489,22 @@ var fullConfig = EVMConfig{
 				ServiceUnavailable:                ptr[string]("(: |^)service unavailable"),
 				TooManyResults:                    ptr[string]("(: |^)too many results"),
 			},
+			QuorumReads: QuorumReads{
+				Nodes:   ptr[uint32](3),
+				Methods: []string{"CallContract", "FilterLogs"},
+			},
+			Multicall: Multicall{
+				Enabled:      ptr(true),
+				Address:      ptr(types.MustEIP55Address("0xcA11bde05977b3631167028862bE2a173976CA11")),
+				BatchWindow:  config.MustNewDuration(50 * time.Millisecond),
+				MaxBatchSize: ptr[uint32](50),
+				GasCap:       ptr[uint64](30_000_000),
+			},
+			ResponseCache: ResponseCache{
+				Enabled:         ptr(true),
+				Size:            ptr[uint32](5000),
+				DisabledMethods: []string{"CodeAt"},
+			},
 		},
 		OCR: OCR{
 			ContractConfirmations:              ptr[uint16](11),
@@ -212,15 +212,22 @@ func (f *FwdMgr) getAuthorizedSenders(ctx context.Context, addr common.Address)
 	return senders, nil
 }
 
+// initForwardersCache gets the senders of the forwarders concurrently, so the client can batch the calls.
 func (f *FwdMgr) initForwardersCache(ctx context.Context, fwdrs []Forwarder) {
+	var wg sync.WaitGroup
 	for _, fwdr := range fwdrs {
-		senders, err := f.getAuthorizedSenders(ctx, fwdr.Address)
-		if err != nil {
-			f.logger.Warnw("Failed to call getAuthorizedSenders on forwarder", "forwarder", fwdr.Address, "err", err)
-			continue
-		}
-		f.setCachedSenders(fwdr.Address, senders)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			senders, err := f.getAuthorizedSenders(ctx, fwdr.Address)
+			if err != nil {
+				f.logger.Warnw("Failed to call getAuthorizedSenders on forwarder", "forwarder", fwdr.Address, "err", err)
+				return
+			}
+			f.setCachedSenders(fwdr.Address, senders)
+		}()
 	}
+	wg.Wait()
 }
 
 func (f *FwdMgr) subscribeForwardersLogs(ctx context.Context, fwdrs []Forwarder) error {
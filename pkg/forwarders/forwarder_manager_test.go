@@ -35,6 +35,7 @@ import (
 func TestFwdMgr_MaybeForwardTransaction(t *testing.T) {
 	lggr := logger.Test(t)
 	db := testutils.NewSqlxDB(t)
+	testutils.MigrateUp(t, db, logpoller.Migrations)
 	evmcfg := configtest.NewChainScopedConfig(t, nil)
 	owner := testutils.MustNewSimTransactor(t)
 	ctx := testutils.Context(t)
@@ -103,6 +104,7 @@ func TestFwdMgr_MaybeForwardTransaction(t *testing.T) {
 func TestFwdMgr_AccountUnauthorizedToForward_SkipsForwarding(t *testing.T) {
 	lggr := logger.Test(t)
 	db := testutils.NewSqlxDB(t)
+	testutils.MigrateUp(t, db, logpoller.Migrations)
 	ctx := testutils.Context(t)
 	evmcfg := configtest.NewChainScopedConfig(t, nil)
 	owner := testutils.MustNewSimTransactor(t)
@@ -152,6 +154,7 @@ func TestFwdMgr_AccountUnauthorizedToForward_SkipsForwarding(t *testing.T) {
 func TestFwdMgr_InvalidForwarderForOCR2FeedsStates(t *testing.T) {
 	lggr := logger.Test(t)
 	db := testutils.NewSqlxDB(t)
+	testutils.MigrateUp(t, db, logpoller.Migrations)
 	ctx := testutils.Context(t)
 	evmcfg := configtest.NewChainScopedConfig(t, nil)
 	owner := testutils.MustNewSimTransactor(t)
@@ -0,0 +1,163 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
+)
+
+const (
+	// BlobTxBumpPercentage is the min bump required by the blob pool to replace a blob transaction. It applies to the
+	// tip cap, the fee cap and the blob fee cap.
+	// See: https://github.com/ethereum/go-ethereum/blob/v1.15.3/core/txpool/blobpool/config.go#L37
+	BlobTxBumpPercentage = 100
+	// BlobBaseFeeUpdateFractionPrague is the BLOB_BASE_FEE_UPDATE_FRACTION of the Prague fork. Cancun uses 3338477.
+	BlobBaseFeeUpdateFractionPrague = 5007716
+	// blobFeeCapMultiplier gives the blob fee cap enough headroom to survive several full blocks, since the blob base fee
+	// can increase by at most 12.5% per block.
+	blobFeeCapMultiplier = 2
+	// blobBaseFeeMaxIncreasePercentage is the max increase of the blob base fee from a block to the next, 12.5% rounded up.
+	blobBaseFeeMaxIncreasePercentage = 13
+)
+
+var minBlobBaseFee = big.NewInt(1)
+
+type BlobEstimatorConfig struct {
+	// UpdateFraction is the BLOB_BASE_FEE_UPDATE_FRACTION used to derive the blob base fee from the excess blob gas of
+	// each head. If it's zero, the blob base fee is fetched from the RPC with eth_blobBaseFee instead.
+	UpdateFraction uint64
+	// PriceMax caps the max fee per blob gas.
+	PriceMax *assets.Wei
+}
+
+type blobEstimatorClient interface {
+	BlobBaseFee(ctx context.Context) (*big.Int, error)
+}
+
+// BlobEstimator estimates the max fee per blob gas of EIP-4844 transactions. The execution fees of blob transactions
+// are estimated by the regular EvmFeeEstimator.
+type BlobEstimator struct {
+	client blobEstimatorClient
+	lggr   logger.SugaredLogger
+	config BlobEstimatorConfig
+
+	blobBaseFeeMu sync.RWMutex
+	blobBaseFee   *assets.Wei
+}
+
+func NewBlobEstimator(lggr logger.Logger, client blobEstimatorClient, cfg BlobEstimatorConfig) *BlobEstimator {
+	return &BlobEstimator{
+		client: client,
+		lggr:   logger.Sugared(logger.Named(lggr, "BlobEstimator")),
+		config: cfg,
+	}
+}
+
+// OnNewLongestChain derives the blob base fee of the head from its excess blob gas. As the excess blob gas of the next
+// block depends on the blob gas used by the head, the blob base fee of the next block is bounded by padding it with
+// the max increase of a block.
+func (b *BlobEstimator) OnNewLongestChain(_ context.Context, head *evmtypes.Head) {
+	if head == nil || head.ExcessBlobGas == nil || b.config.UpdateFraction == 0 {
+		return
+	}
+	blobBaseFee := assets.NewWei(CalcBlobBaseFee(*head.ExcessBlobGas, b.config.UpdateFraction)).AddPercentage(blobBaseFeeMaxIncreasePercentage)
+	b.blobBaseFeeMu.Lock()
+	b.blobBaseFee = blobBaseFee
+	b.blobBaseFeeMu.Unlock()
+	b.lggr.Debugw("Updated blob base fee", "blobBaseFee", blobBaseFee, "head", head.Number)
+}
+
+// BlobBaseFee returns the latest blob base fee. If it hasn't been derived from a head yet, it's fetched from the RPC.
+func (b *BlobEstimator) BlobBaseFee(ctx context.Context) (*assets.Wei, error) {
+	b.blobBaseFeeMu.RLock()
+	blobBaseFee := b.blobBaseFee
+	b.blobBaseFeeMu.RUnlock()
+	if blobBaseFee != nil {
+		return blobBaseFee, nil
+	}
+
+	fee, err := b.client.BlobBaseFee(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob base fee: %w", err)
+	}
+	return assets.NewWei(fee), nil
+}
+
+// GetBlobFee returns the max fee per blob gas for a new blob transaction. It's capped by PriceMax.
+func (b *BlobEstimator) GetBlobFee(ctx context.Context) (*assets.Wei, error) {
+	blobBaseFee, err := b.BlobBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blobFeeCap := blobBaseFee.Mul(big.NewInt(blobFeeCapMultiplier))
+	if b.config.PriceMax != nil && blobFeeCap.Cmp(b.config.PriceMax) > 0 {
+		if blobBaseFee.Cmp(b.config.PriceMax) > 0 {
+			return nil, fmt.Errorf("blob base fee: %s is above the blob PriceMax: %s", blobBaseFee, b.config.PriceMax)
+		}
+		return b.config.PriceMax, nil
+	}
+	return blobFeeCap, nil
+}
+
+// BumpFee bumps the tip cap, the fee cap and the blob fee cap of a blob transaction by BlobTxBumpPercentage, so it can
+// replace originalFee in the blob pool. Fees are also raised to at least the current estimates, currentFee and
+// GetBlobFee. fees.ErrBumpFeeExceedsLimit is returned if the fee cap exceeds maxFeePrice or the blob fee cap exceeds PriceMax.
+func (b *BlobEstimator) BumpFee(ctx context.Context, originalFee EvmFee, currentFee DynamicFee, maxFeePrice *assets.Wei) (bumpedFee EvmFee, err error) {
+	if !originalFee.ValidDynamic() || originalFee.BlobFeeCap == nil {
+		return bumpedFee, fmt.Errorf("%w: original fee: %s is not a valid blob fee", fees.ErrBump, originalFee)
+	}
+	blobFee, err := b.GetBlobFee(ctx)
+	if err != nil {
+		return bumpedFee, err
+	}
+
+	bumpedFee.GasTipCap = bumpBlobTxFee(originalFee.GasTipCap, currentFee.GasTipCap)
+	bumpedFee.GasFeeCap = bumpBlobTxFee(originalFee.GasFeeCap, currentFee.GasFeeCap)
+	bumpedFee.BlobFeeCap = bumpBlobTxFee(originalFee.BlobFeeCap, blobFee)
+
+	if maxFeePrice != nil && bumpedFee.GasFeeCap.Cmp(maxFeePrice) > 0 {
+		return bumpedFee, fmt.Errorf("bumped fee cap: %s is above max price: %s: %w", bumpedFee.GasFeeCap, maxFeePrice, fees.ErrBumpFeeExceedsLimit)
+	}
+	if b.config.PriceMax != nil && bumpedFee.BlobFeeCap.Cmp(b.config.PriceMax) > 0 {
+		return bumpedFee, fmt.Errorf("bumped blob fee cap: %s is above blob PriceMax: %s: %w", bumpedFee.BlobFeeCap, b.config.PriceMax, fees.ErrBumpFeeExceedsLimit)
+	}
+	return bumpedFee, nil
+}
+
+// bumpBlobTxFee bumps fee by BlobTxBumpPercentage and returns the max of the bumped fee and currentFee.
+func bumpBlobTxFee(fee *assets.Wei, currentFee *assets.Wei) *assets.Wei {
+	bumped := fee.AddPercentage(BlobTxBumpPercentage)
+	if currentFee != nil {
+		return assets.WeiMax(bumped, currentFee)
+	}
+	return bumped
+}
+
+// CalcBlobBaseFee calculates the blob base fee from the excess blob gas of a block, as specified by EIP-4844.
+func CalcBlobBaseFee(excessBlobGas uint64, updateFraction uint64) *big.Int {
+	return fakeExponential(minBlobBaseFee, new(big.Int).SetUint64(excessBlobGas), new(big.Int).SetUint64(updateFraction))
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) using Taylor expansion.
+// Copied from: https://github.com/ethereum/go-ethereum/blob/v1.15.3/consensus/misc/eip4844/eip4844.go#L166
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	var (
+		output = new(big.Int)
+		accum  = new(big.Int).Mul(factor, denominator)
+	)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+	return output.Div(output, denominator)
+}
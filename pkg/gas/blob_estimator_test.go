@@ -0,0 +1,109 @@
+package gas_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
+)
+
+type fakeBlobEstimatorClient struct {
+	blobBaseFee *big.Int
+	err         error
+}
+
+func (f *fakeBlobEstimatorClient) BlobBaseFee(context.Context) (*big.Int, error) {
+	return f.blobBaseFee, f.err
+}
+
+func TestCalcBlobBaseFee(t *testing.T) {
+	t.Parallel()
+
+	for _, excessBlobGas := range []uint64{0, 1, 2314057, 10 * 131072, 100_000_000} {
+		header := &types.Header{Number: big.NewInt(1), ExcessBlobGas: &excessBlobGas}
+		expected := eip4844.CalcBlobFee(params.MergedTestChainConfig, header)
+		assert.Equal(t, expected, gas.CalcBlobBaseFee(excessBlobGas, gas.BlobBaseFeeUpdateFractionPrague), "excessBlobGas: %d", excessBlobGas)
+	}
+}
+
+func TestBlobEstimator(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	priceMax := assets.NewWeiI(1000)
+
+	t.Run("fetches blob base fee from the RPC until a head is received", func(t *testing.T) {
+		client := &fakeBlobEstimatorClient{blobBaseFee: big.NewInt(10)}
+		b := gas.NewBlobEstimator(logger.Test(t), client, gas.BlobEstimatorConfig{UpdateFraction: gas.BlobBaseFeeUpdateFractionPrague, PriceMax: priceMax})
+
+		fee, err := b.GetBlobFee(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(20), fee)
+
+		excessBlobGas := uint64(100_000_000)
+		b.OnNewLongestChain(ctx, &evmtypes.Head{Number: 1, ExcessBlobGas: &excessBlobGas})
+		client.err = errors.New("RPC should not be called")
+		blobBaseFee, err := b.BlobBaseFee(ctx)
+		require.NoError(t, err)
+		// Padded for the next block
+		assert.Equal(t, assets.NewWei(gas.CalcBlobBaseFee(excessBlobGas, gas.BlobBaseFeeUpdateFractionPrague)).AddPercentage(13), blobBaseFee)
+	})
+
+	t.Run("caps blob fee at PriceMax", func(t *testing.T) {
+		b := gas.NewBlobEstimator(logger.Test(t), &fakeBlobEstimatorClient{blobBaseFee: big.NewInt(600)}, gas.BlobEstimatorConfig{PriceMax: priceMax})
+		fee, err := b.GetBlobFee(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, priceMax, fee)
+
+		b = gas.NewBlobEstimator(logger.Test(t), &fakeBlobEstimatorClient{blobBaseFee: big.NewInt(1001)}, gas.BlobEstimatorConfig{PriceMax: priceMax})
+		_, err = b.GetBlobFee(ctx)
+		require.ErrorContains(t, err, "is above the blob PriceMax")
+	})
+
+	t.Run("bumps all fees by 100%", func(t *testing.T) {
+		b := gas.NewBlobEstimator(logger.Test(t), &fakeBlobEstimatorClient{blobBaseFee: big.NewInt(10)}, gas.BlobEstimatorConfig{PriceMax: priceMax})
+		originalFee := gas.EvmFee{
+			DynamicFee: gas.DynamicFee{GasTipCap: assets.NewWeiI(5), GasFeeCap: assets.NewWeiI(50)},
+			BlobFeeCap: assets.NewWeiI(15),
+		}
+		bumpedFee, err := b.BumpFee(ctx, originalFee, gas.DynamicFee{GasTipCap: assets.NewWeiI(20), GasFeeCap: assets.NewWeiI(60)}, assets.NewWeiI(200))
+		require.NoError(t, err)
+		// The tip cap is raised to the current estimate
+		assert.Equal(t, assets.NewWeiI(20), bumpedFee.GasTipCap)
+		assert.Equal(t, assets.NewWeiI(100), bumpedFee.GasFeeCap)
+		assert.Equal(t, assets.NewWeiI(30), bumpedFee.BlobFeeCap)
+	})
+
+	t.Run("fails if bumped fees exceed the limits", func(t *testing.T) {
+		b := gas.NewBlobEstimator(logger.Test(t), &fakeBlobEstimatorClient{blobBaseFee: big.NewInt(10)}, gas.BlobEstimatorConfig{PriceMax: priceMax})
+		originalFee := gas.EvmFee{
+			DynamicFee: gas.DynamicFee{GasTipCap: assets.NewWeiI(5), GasFeeCap: assets.NewWeiI(50)},
+			BlobFeeCap: assets.NewWeiI(600),
+		}
+		_, err := b.BumpFee(ctx, originalFee, gas.DynamicFee{}, assets.NewWeiI(99))
+		require.ErrorIs(t, err, fees.ErrBumpFeeExceedsLimit)
+
+		_, err = b.BumpFee(ctx, originalFee, gas.DynamicFee{}, assets.NewWeiI(200))
+		require.ErrorIs(t, err, fees.ErrBumpFeeExceedsLimit)
+		require.ErrorContains(t, err, "blob PriceMax")
+	})
+
+	t.Run("fails if original fee is not a blob fee", func(t *testing.T) {
+		b := gas.NewBlobEstimator(logger.Test(t), &fakeBlobEstimatorClient{blobBaseFee: big.NewInt(10)}, gas.BlobEstimatorConfig{})
+		_, err := b.BumpFee(ctx, gas.EvmFee{GasPrice: assets.NewWeiI(10)}, gas.DynamicFee{}, nil)
+		require.ErrorIs(t, err, fees.ErrBump)
+	})
+}
@@ -0,0 +1,57 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+// setCodeAuthorizationMagic prefixes the signing payload of EIP-7702 authorizations.
+const setCodeAuthorizationMagic = 0x05
+
+// SetCodeAuthorizationHash returns the hash that the authority of an EIP-7702 authorization signs:
+// keccak256(0x05 || rlp([chain_id, address, nonce])).
+func SetCodeAuthorizationHash(auth types.SetCodeAuthorization) (common.Hash, error) {
+	payload, err := rlp.EncodeToBytes([]any{&auth.ChainID, auth.Address, auth.Nonce})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode authorization: %w", err)
+	}
+	return crypto.Keccak256Hash([]byte{setCodeAuthorizationMagic}, payload), nil
+}
+
+// SignSetCodeAuthorization signs an EIP-7702 authorization on behalf of authority. The signature values of auth are
+// ignored. The recovered authority of the returned authorization is verified to match authority.
+func SignSetCodeAuthorization(ctx context.Context, signer RawUnhashedSigner, authority common.Address, auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	h, err := SetCodeAuthorizationHash(auth)
+	if err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	sig, err := signer.SignRawUnhashedBytes(ctx, authority, h[:])
+	if err != nil {
+		return types.SetCodeAuthorization{}, fmt.Errorf("failed to sign authorization: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return types.SetCodeAuthorization{}, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	signed := types.SetCodeAuthorization{
+		ChainID: auth.ChainID,
+		Address: auth.Address,
+		Nonce:   auth.Nonce,
+		V:       sig[crypto.RecoveryIDOffset],
+		R:       *new(uint256.Int).SetBytes(sig[:32]),
+		S:       *new(uint256.Int).SetBytes(sig[32:64]),
+	}
+	recovered, err := signed.Authority()
+	if err != nil {
+		return types.SetCodeAuthorization{}, fmt.Errorf("failed to recover authority: %w", err)
+	}
+	if recovered != authority {
+		return types.SetCodeAuthorization{}, fmt.Errorf("recovered authority: %v doesn't match authority: %v", recovered, authority)
+	}
+	return signed, nil
+}
@@ -0,0 +1,56 @@
+package keys_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/keys"
+	"github.com/smartcontractkit/chainlink-evm/pkg/keys/keystest"
+)
+
+func TestSignSetCodeAuthorization(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	authority := crypto.PubkeyToAddress(privKey.PublicKey)
+	signer := keystest.MessageSigner(func(_ context.Context, address common.Address, data []byte) ([]byte, error) {
+		return crypto.Sign(data, privKey)
+	})
+	auth := types.SetCodeAuthorization{ChainID: *uint256.NewInt(1), Address: common.HexToAddress("0x1234"), Nonce: 7}
+
+	t.Run("matches geth signatures", func(t *testing.T) {
+		signed, err := keys.SignSetCodeAuthorization(ctx, signer, authority, auth)
+		require.NoError(t, err)
+		expected, err := types.SignSetCode(privKey, auth)
+		require.NoError(t, err)
+		assert.Equal(t, expected, signed)
+
+		recovered, err := signed.Authority()
+		require.NoError(t, err)
+		assert.Equal(t, authority, recovered)
+	})
+
+	t.Run("signs with keystore", func(t *testing.T) {
+		ks := keystest.NewMemoryChainStore()
+		address := ks.MustCreate(t)
+		signed, err := keys.SignSetCodeAuthorization(ctx, keys.NewStore(ks), address, auth)
+		require.NoError(t, err)
+		recovered, err := signed.Authority()
+		require.NoError(t, err)
+		assert.Equal(t, address, recovered)
+	})
+
+	t.Run("fails if the signer doesn't hold the key of the authority", func(t *testing.T) {
+		_, err := keys.SignSetCodeAuthorization(ctx, signer, common.HexToAddress("0x5678"), auth)
+		require.ErrorContains(t, err, "doesn't match authority")
+	})
+}
@@ -0,0 +1,337 @@
+package logpoller
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+var (
+	promLpAuditRanges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_audit_ranges",
+		Help: "Number of block ranges audited by re-fetching their logs from an RPC node",
+	}, []string{"evmChainID", "rpc"})
+	promLpAuditMissingLogs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_audit_missing_logs",
+		Help: "Number of logs returned by an RPC node which were missing from the db, by filter",
+	}, []string{"evmChainID", "filterName", "rpc"})
+	promLpAuditUnexpectedLogs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_audit_unexpected_logs",
+		Help: "Number of logs of the db which weren't returned by an RPC node, by filter",
+	}, []string{"evmChainID", "filterName", "rpc"})
+	promLpAuditMismatchedLogs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_audit_mismatched_logs",
+		Help: "Number of logs of the db which differ from the log at the same block hash and log index returned by an RPC node, by filter",
+	}, []string{"evmChainID", "filterName", "rpc"})
+	promLpAuditRepairedLogs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_audit_repaired_logs",
+		Help: "Number of logs missing from the db saved by the auditor, by filter",
+	}, []string{"evmChainID", "filterName", "rpc"})
+)
+
+// AuditorOpts configures an Auditor.
+type AuditorOpts struct {
+	AuditPeriod          time.Duration // period between the audits of two block ranges
+	AuditRangeSize       int64         // number of blocks of the ranges audited
+	Repair               bool          // whether to save the logs missing from the db
+	RPCName              string        // name of the RPC node of the auditor's client, used in the metrics
+	LogQueryMaxAddresses int           // maximum number of addresses of an eth_getLogs query, 0 = unlimited
+}
+
+// AuditReport is the result of the audit of a block range.
+type AuditReport struct {
+	FromBlock, ToBlock int64
+	Missing            []Log // logs returned by the RPC but missing from the db
+	Unexpected         []Log // logs of the db not returned by the RPC
+	Mismatched         []Log // logs of the db which differ from the log returned by the RPC at the same position
+	Repaired           int   // number of missing logs saved
+	Pruned             int   // number of logs returned by the RPC left out of the audit, as they may have been pruned
+}
+
+// Auditor checks the logs saved by the LogPoller against the logs returned by an RPC node, to detect RPC nodes which
+// return incomplete eth_getLogs results. It periodically picks a range of finalized blocks saved at random, fetches
+// the logs of the registered filters in it and compares them to the logs saved by their block hash and log index.
+// Logs which may have been pruned, as all the filters matching them either expired them or cap the number of logs
+// kept, are left out.
+//
+// The client may be connected to a different RPC node than the LogPoller's client, to audit the node used by the
+// LogPoller. Discrepancies are logged and counted by filter and RPC node, and the missing logs are saved if Repair is
+// set.
+type Auditor struct {
+	services.StateMachine
+	orm    ORM
+	ec     Client
+	lggr   logger.SugaredLogger
+	opts   AuditorOpts
+	stopCh services.StopChan
+	wg     sync.WaitGroup
+}
+
+func NewAuditor(orm ORM, ec Client, lggr logger.Logger, opts AuditorOpts) *Auditor {
+	return &Auditor{
+		orm:    orm,
+		ec:     ec,
+		lggr:   logger.Sugared(logger.Named(lggr, "LogPollerAuditor")),
+		opts:   opts,
+		stopCh: make(chan struct{}),
+	}
+}
+
+func (a *Auditor) Start(context.Context) error {
+	return a.StartOnce("LogPollerAuditor", func() error {
+		if a.opts.AuditPeriod <= 0 || a.opts.AuditRangeSize <= 0 {
+			return errors.New("AuditPeriod and AuditRangeSize must be positive")
+		}
+		a.wg.Add(1)
+		go a.run()
+		return nil
+	})
+}
+
+func (a *Auditor) Close() error {
+	return a.StopOnce("LogPollerAuditor", func() error {
+		close(a.stopCh)
+		a.wg.Wait()
+		return nil
+	})
+}
+
+func (a *Auditor) Name() string {
+	return a.lggr.Name()
+}
+
+func (a *Auditor) HealthReport() map[string]error {
+	return map[string]error{a.Name(): a.Healthy()}
+}
+
+func (a *Auditor) run() {
+	defer a.wg.Done()
+	ctx, cancel := a.stopCh.NewCtx()
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tickWithDefaultJitter(a.opts.AuditPeriod):
+			from, to, ok, err := a.sampleRange(ctx)
+			if err != nil {
+				a.lggr.Warnw("Unable to pick a block range to audit", "err", err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if _, err = a.AuditRange(ctx, from, to); err != nil {
+				a.lggr.Warnw("Unable to audit block range", "err", err, "fromBlock", from, "toBlock", to)
+			}
+		}
+	}
+}
+
+// sampleRange picks a range of AuditRangeSize finalized blocks at random, between the oldest block saved and the latest
+// finalized block. It's false if no block has been finalized since the oldest block saved.
+func (a *Auditor) sampleRange(ctx context.Context) (from, to int64, ok bool, err error) {
+	latest, err := a.orm.SelectLatestBlock(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, false, nil
+		}
+		return 0, 0, false, err
+	}
+	oldest, err := a.orm.SelectOldestBlock(ctx, 0)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	first := max(oldest.BlockNumber, 1)
+	last := latest.FinalizedBlockNumber - a.opts.AuditRangeSize + 1
+	if last < first {
+		if latest.FinalizedBlockNumber < first {
+			return 0, 0, false, nil
+		}
+		return first, latest.FinalizedBlockNumber, true, nil
+	}
+	from = first + rand.Int64N(last-first+1) //nolint:gosec // G404, no need for a secure random number
+	return from, from + a.opts.AuditRangeSize - 1, true, nil
+}
+
+// AuditRange compares the logs of the registered filters saved in the block range [from, to] with the logs returned
+// by the auditor's client. The blocks must be finalized, as logs of unfinalized blocks may legitimately differ.
+// Filters are left out of the ranges starting before their logs were polled, see ORM.SelectFilterStartBlocks.
+func (a *Auditor) AuditRange(ctx context.Context, from, to int64) (*AuditReport, error) {
+	filters, err := a.orm.LoadFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := a.orm.SelectFilterStartBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for name := range filters {
+		if start, ok := starts[name]; !ok || start > from {
+			delete(filters, name)
+		}
+	}
+	report := &AuditReport{FromBlock: from, ToBlock: to}
+	if len(filters) == 0 {
+		return report, nil
+	}
+	plan := newLogQueryPlan(filters, a.opts.LogQueryMaxAddresses)
+
+	gethLogs, err := plan.filterLogs(ctx, a.ec, big.NewInt(from), big.NewInt(to), nil)
+	if err != nil {
+		return nil, err
+	}
+	// The block timestamps are only needed to repair the missing logs, they're set then.
+	fetched, _ := plan.limitLogsPerBlock(plan.matchingLogs(convertLogs(gethLogs, make([]Block, len(gethLogs)), a.lggr, a.ec.ConfiguredChainID())))
+	stored, err := a.orm.SelectLogsByBlockRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	stored = plan.matchingLogs(stored)
+	fetched, stored, report.Pruned, err = a.withoutPrunedLogs(ctx, plan, fetched, stored, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type logKey struct {
+		blockHash common.Hash
+		logIndex  int64
+	}
+	storedByKey := make(map[logKey]Log, len(stored))
+	for _, l := range stored {
+		storedByKey[logKey{l.BlockHash, l.LogIndex}] = l
+	}
+	fetchedKeys := make(map[logKey]struct{}, len(fetched))
+	for _, l := range fetched {
+		key := logKey{l.BlockHash, l.LogIndex}
+		fetchedKeys[key] = struct{}{}
+		s, ok := storedByKey[key]
+		switch {
+		case !ok:
+			report.Missing = append(report.Missing, l)
+		case !sameLogContent(&s, &l):
+			report.Mismatched = append(report.Mismatched, s)
+		}
+	}
+	for _, l := range stored {
+		if _, ok := fetchedKeys[logKey{l.BlockHash, l.LogIndex}]; !ok {
+			report.Unexpected = append(report.Unexpected, l)
+		}
+	}
+
+	chainID := a.ec.ConfiguredChainID().String()
+	promLpAuditRanges.WithLabelValues(chainID, a.opts.RPCName).Inc()
+	a.countByFilter(plan, report.Missing, promLpAuditMissingLogs, chainID)
+	a.countByFilter(plan, report.Unexpected, promLpAuditUnexpectedLogs, chainID)
+	a.countByFilter(plan, report.Mismatched, promLpAuditMismatchedLogs, chainID)
+	if len(report.Missing) == 0 && len(report.Unexpected) == 0 && len(report.Mismatched) == 0 {
+		a.lggr.Debugw("Audited block range, no discrepancy", "fromBlock", from, "toBlock", to, "logs", len(stored), "rpc", a.opts.RPCName)
+		return report, nil
+	}
+	a.lggr.Errorw("Audited block range, logs saved differ from the RPC's", "fromBlock", from, "toBlock", to, "rpc", a.opts.RPCName,
+		"missing", len(report.Missing), "unexpected", len(report.Unexpected), "mismatched", len(report.Mismatched))
+
+	if a.opts.Repair && len(report.Missing) > 0 {
+		if err = a.repair(ctx, report.Missing); err != nil {
+			return report, err
+		}
+		report.Repaired = len(report.Missing)
+		a.countByFilter(plan, report.Missing, promLpAuditRepairedLogs, chainID)
+		a.lggr.Warnw("Saved logs missing from the db", "fromBlock", from, "toBlock", to, "rpc", a.opts.RPCName, "repaired", report.Repaired)
+	}
+	return report, nil
+}
+
+// withoutPrunedLogs leaves out of the logs fetched and stored those which may have been pruned, and returns the number
+// of logs fetched left out.
+func (a *Auditor) withoutPrunedLogs(ctx context.Context, plan *logQueryPlan, fetched, stored []Log, from, to int64) ([]Log, []Log, int, error) {
+	blocks, err := a.orm.GetBlocksRange(ctx, from, to)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	timestamps := make(map[int64]time.Time, len(blocks))
+	for _, b := range blocks {
+		timestamps[b.BlockNumber] = b.BlockTimestamp
+	}
+	now := time.Now()
+	pruned := func(l Log) bool { return prunable(plan, &l, timestamps[l.BlockNumber], now) }
+	n := len(fetched)
+	fetched = slices.DeleteFunc(fetched, pruned)
+	return fetched, slices.DeleteFunc(stored, pruned), n - len(fetched), nil
+}
+
+// prunable returns whether l, of a block produced at blockTime, may have been pruned: if all the filters matching it
+// either cap the number of logs kept, or have a retention l has expired by, like DeleteExpiredLogs and
+// SelectExcessLogIDs.
+func prunable(plan *logQueryPlan, l *Log, blockTime time.Time, now time.Time) bool {
+	matched := false
+	for _, filter := range plan.filters {
+		if !filter.matches(l) {
+			continue
+		}
+		matched = true
+		expired := filter.Retention > 0 && !blockTime.IsZero() && !blockTime.After(now.Add(-filter.Retention))
+		if filter.MaxLogsKept == 0 && !expired {
+			return false
+		}
+	}
+	return matched
+}
+
+// repair saves the missing logs, with the timestamps of their blocks.
+func (a *Auditor) repair(ctx context.Context, missing []Log) error {
+	timestamps := make(map[common.Hash]time.Time)
+	for i := range missing {
+		l := &missing[i]
+		ts, ok := timestamps[l.BlockHash]
+		if !ok {
+			head, err := a.ec.HeadByHash(ctx, l.BlockHash)
+			if err != nil {
+				return err
+			}
+			if head == nil {
+				return errors.New("got nil block for hash " + l.BlockHash.String())
+			}
+			ts = head.Timestamp
+			timestamps[l.BlockHash] = ts
+		}
+		l.BlockTimestamp = ts
+	}
+	return a.orm.InsertLogs(ctx, missing)
+}
+
+// countByFilter counts the logs in counter by the filters of plan matching them.
+func (a *Auditor) countByFilter(plan *logQueryPlan, logs []Log, counter *prometheus.CounterVec, chainID string) {
+	for i := range logs {
+		for _, filter := range plan.filters {
+			if filter.matches(&logs[i]) {
+				counter.WithLabelValues(chainID, filter.Name, a.opts.RPCName).Inc()
+			}
+		}
+	}
+}
+
+func sameLogContent(a, b *Log) bool {
+	if a.TxHash != b.TxHash || a.Address != b.Address || !bytes.Equal(a.Data, b.Data) || len(a.Topics) != len(b.Topics) {
+		return false
+	}
+	for i := range a.Topics {
+		if !bytes.Equal(a.Topics[i], b.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
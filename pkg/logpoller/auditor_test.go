@@ -0,0 +1,251 @@
+package logpoller
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client/clienttest"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+func TestAuditor(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+	chainID := testutils.NewRandomEVMChainID()
+	lggr := logger.Test(t)
+	orm, err := NewEmbeddedORM(chainID, memorydb.New(), lggr)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	eventSig := EmitterABI.Events["Log1"].ID
+	require.NoError(t, orm.InsertFilter(ctx, Filter{Name: "Emitter", Addresses: []common.Address{addr}, EventSigs: []common.Hash{eventSig}}))
+
+	var rpcLogs []types.Log
+	for n := int64(1); n <= 10; n++ {
+		rpcLog := types.Log{
+			Address:     addr,
+			Topics:      []common.Hash{eventSig},
+			Data:        []byte{byte(n)},
+			BlockNumber: uint64(n), //nolint:gosec // G115
+			BlockHash:   common.BigToHash(big.NewInt(n)),
+		}
+		rpcLogs = append(rpcLogs, rpcLog)
+
+		saved := convertLogs([]types.Log{rpcLog}, []Block{{BlockTimestamp: time.Now()}}, lggr, chainID)
+		switch n {
+		case 3:
+			// Missing from the db.
+			saved = nil
+		case 5:
+			saved[0].Data = []byte{0}
+		case 7:
+			saved[0].BlockHash = common.HexToHash("0x7777")
+		}
+		require.NoError(t, orm.InsertLogsWithBlock(ctx, saved, Block{EVMChainID: ubig.New(chainID), BlockHash: rpcLog.BlockHash, BlockNumber: n, BlockTimestamp: time.Now(), FinalizedBlockNumber: n}))
+	}
+
+	ec := clienttest.NewClient(t)
+	ec.On("ConfiguredChainID").Return(chainID).Maybe()
+	ec.On("FilterLogs", mock.Anything, mock.Anything).Return(func(ctx context.Context, fq ethereum.FilterQuery) ([]types.Log, error) {
+		var logs []types.Log
+		for _, l := range rpcLogs {
+			if int64(l.BlockNumber) >= fq.FromBlock.Int64() && int64(l.BlockNumber) <= fq.ToBlock.Int64() { //nolint:gosec // G115
+				logs = append(logs, l)
+			}
+		}
+		return logs, nil
+	})
+	ec.On("HeadByHash", mock.Anything, mock.Anything).Return(func(ctx context.Context, hash common.Hash) (*evmtypes.Head, error) {
+		return &evmtypes.Head{Hash: hash, Timestamp: time.Unix(1000, 0)}, nil
+	}).Maybe()
+
+	auditor := NewAuditor(orm, ec, lggr, AuditorOpts{AuditPeriod: time.Hour, AuditRangeSize: 5, RPCName: "backup"})
+
+	t.Run("sample range", func(t *testing.T) {
+		for range 10 {
+			from, to, ok, err := auditor.sampleRange(ctx)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.GreaterOrEqual(t, from, int64(1))
+			assert.LessOrEqual(t, to, int64(10))
+			assert.Equal(t, int64(4), to-from)
+		}
+	})
+
+	t.Run("reports discrepancies", func(t *testing.T) {
+		report, err := auditor.AuditRange(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, report.Missing, 2)
+		assert.Equal(t, int64(3), report.Missing[0].BlockNumber)
+		assert.Equal(t, int64(7), report.Missing[1].BlockNumber)
+		require.Len(t, report.Mismatched, 1)
+		assert.Equal(t, int64(5), report.Mismatched[0].BlockNumber)
+		require.Len(t, report.Unexpected, 1)
+		assert.Equal(t, common.HexToHash("0x7777"), report.Unexpected[0].BlockHash)
+		assert.Zero(t, report.Repaired)
+
+		assert.InDelta(t, 2, testutil.ToFloat64(promLpAuditMissingLogs.WithLabelValues(chainID.String(), "Emitter", "backup")), 0)
+		assert.InDelta(t, 1, testutil.ToFloat64(promLpAuditUnexpectedLogs.WithLabelValues(chainID.String(), "Emitter", "backup")), 0)
+		assert.InDelta(t, 1, testutil.ToFloat64(promLpAuditMismatchedLogs.WithLabelValues(chainID.String(), "Emitter", "backup")), 0)
+	})
+
+	t.Run("repairs missing logs", func(t *testing.T) {
+		auditor.opts.Repair = true
+		report, err := auditor.AuditRange(ctx, 1, 4)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Repaired)
+
+		logs, err := orm.SelectLogsByBlockRange(ctx, 3, 3)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Equal(t, time.Unix(1000, 0).UTC(), logs[0].BlockTimestamp.UTC())
+
+		report, err = auditor.AuditRange(ctx, 1, 4)
+		require.NoError(t, err)
+		assert.Empty(t, report.Missing)
+		assert.Zero(t, report.Repaired)
+	})
+}
+
+func TestAuditor_PrunedLogs(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+	chainID := testutils.NewRandomEVMChainID()
+	lggr := logger.Test(t)
+	orm, err := NewEmbeddedORM(chainID, memorydb.New(), lggr)
+	require.NoError(t, err)
+
+	addr1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	addr2 := common.HexToAddress("0x1234")
+	eventSig := EmitterABI.Events["Log1"].ID
+	require.NoError(t, orm.InsertFilter(ctx, Filter{Name: "Retention", Addresses: []common.Address{addr1}, EventSigs: []common.Hash{eventSig}, Retention: time.Hour}))
+	require.NoError(t, orm.InsertFilter(ctx, Filter{Name: "MaxLogsKept", Addresses: []common.Address{addr2}, EventSigs: []common.Hash{eventSig}, MaxLogsKept: 1}))
+
+	// Blocks 1 and 2 are older than the retention, their logs are pruned. The logs of addr2 are pruned too, as
+	// MaxLogsKept is exceeded. The log of block 4 is missing.
+	var rpcLogs []types.Log
+	for n := int64(1); n <= 4; n++ {
+		blockTime := time.Now()
+		if n <= 2 {
+			blockTime = blockTime.Add(-2 * time.Hour)
+		}
+		var saved []Log
+		for i, addr := range []common.Address{addr1, addr2} {
+			rpcLog := types.Log{
+				Address:     addr,
+				Topics:      []common.Hash{eventSig},
+				Data:        []byte{byte(n)},
+				BlockNumber: uint64(n), //nolint:gosec // G115
+				BlockHash:   common.BigToHash(big.NewInt(n)),
+				Index:       uint(i), //nolint:gosec // G115
+			}
+			rpcLogs = append(rpcLogs, rpcLog)
+			if n == 3 && addr == addr1 {
+				saved = convertLogs([]types.Log{rpcLog}, []Block{{BlockTimestamp: blockTime}}, lggr, chainID)
+			}
+		}
+		require.NoError(t, orm.InsertLogsWithBlock(ctx, saved, Block{EVMChainID: ubig.New(chainID), BlockHash: common.BigToHash(big.NewInt(n)), BlockNumber: n, BlockTimestamp: blockTime, FinalizedBlockNumber: n}))
+	}
+
+	ec := clienttest.NewClient(t)
+	ec.On("ConfiguredChainID").Return(chainID).Maybe()
+	ec.On("FilterLogs", mock.Anything, mock.Anything).Return(rpcLogs, nil)
+	ec.On("HeadByHash", mock.Anything, mock.Anything).Return(func(ctx context.Context, hash common.Hash) (*evmtypes.Head, error) {
+		return &evmtypes.Head{Hash: hash, Timestamp: time.Now()}, nil
+	}).Maybe()
+
+	auditor := NewAuditor(orm, ec, lggr, AuditorOpts{AuditPeriod: time.Hour, AuditRangeSize: 4, Repair: true})
+	report, err := auditor.AuditRange(ctx, 1, 4)
+	require.NoError(t, err)
+	require.Len(t, report.Missing, 1)
+	assert.Equal(t, int64(4), report.Missing[0].BlockNumber)
+	assert.Equal(t, addr1, report.Missing[0].Address)
+	assert.Empty(t, report.Unexpected)
+	assert.Equal(t, 1, report.Repaired)
+	assert.Equal(t, 6, report.Pruned)
+
+	logs, err := orm.SelectLogsByBlockRange(ctx, 1, 4)
+	require.NoError(t, err)
+	assert.Len(t, logs, 2, "pruned logs aren't saved again")
+}
+
+func TestAuditor_FilterRegisteredLater(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+	chainID := testutils.NewRandomEVMChainID()
+	lggr := logger.Test(t)
+	orm, err := NewEmbeddedORM(chainID, memorydb.New(), lggr)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	eventSig := EmitterABI.Events["Log1"].ID
+
+	// The filter is registered once blocks 1 to 3 are saved, its logs before block 4 were never polled.
+	var rpcLogs []types.Log
+	for n := int64(1); n <= 6; n++ {
+		if n == 4 {
+			require.NoError(t, orm.InsertFilter(ctx, Filter{Name: "Emitter", Addresses: []common.Address{addr}, EventSigs: []common.Hash{eventSig}}))
+		}
+		rpcLog := types.Log{
+			Address:     addr,
+			Topics:      []common.Hash{eventSig},
+			Data:        []byte{byte(n)},
+			BlockNumber: uint64(n), //nolint:gosec // G115
+			BlockHash:   common.BigToHash(big.NewInt(n)),
+		}
+		rpcLogs = append(rpcLogs, rpcLog)
+		var saved []Log
+		if n >= 4 {
+			saved = convertLogs([]types.Log{rpcLog}, []Block{{BlockTimestamp: time.Now()}}, lggr, chainID)
+		}
+		require.NoError(t, orm.InsertLogsWithBlock(ctx, saved, Block{EVMChainID: ubig.New(chainID), BlockHash: rpcLog.BlockHash, BlockNumber: n, BlockTimestamp: time.Now(), FinalizedBlockNumber: n}))
+	}
+
+	starts, err := orm.SelectFilterStartBlocks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"Emitter": 4}, starts)
+
+	ec := clienttest.NewClient(t)
+	ec.On("ConfiguredChainID").Return(chainID).Maybe()
+	ec.On("FilterLogs", mock.Anything, mock.Anything).Return(func(ctx context.Context, fq ethereum.FilterQuery) ([]types.Log, error) {
+		var logs []types.Log
+		for _, l := range rpcLogs {
+			if int64(l.BlockNumber) >= fq.FromBlock.Int64() && int64(l.BlockNumber) <= fq.ToBlock.Int64() { //nolint:gosec // G115
+				logs = append(logs, l)
+			}
+		}
+		return logs, nil
+	}).Maybe()
+
+	auditor := NewAuditor(orm, ec, lggr, AuditorOpts{AuditPeriod: time.Hour, AuditRangeSize: 3, Repair: true})
+	report, err := auditor.AuditRange(ctx, 1, 6)
+	require.NoError(t, err)
+	assert.Empty(t, report.Missing)
+	assert.Zero(t, report.Repaired)
+
+	report, err = auditor.AuditRange(ctx, 4, 6)
+	require.NoError(t, err)
+	assert.Empty(t, report.Missing)
+	assert.Empty(t, report.Mismatched)
+	assert.Empty(t, report.Unexpected)
+
+	logs, err := orm.SelectLogsByBlockRange(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.Empty(t, logs, "history before the filter was registered isn't backfilled")
+}
@@ -0,0 +1,201 @@
+package logpoller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client"
+)
+
+// backfillGrowthSuccesses is the number of ranges in a row fetched successfully after which backfill doubles the size
+// of the ranges again, following a reduction.
+const backfillGrowthSuccesses = 10
+
+// backfillRange is a block range [from, to] fetched by a backfill worker.
+type backfillRange struct {
+	from, to int64
+
+	dispatched bool
+	done       chan struct{}
+
+	// results, set before done is closed
+	logs     []Log
+	endBlock *Block // nil if there are no logs in the range
+	queryErr error  // the eth_getLogs error, if any
+	err      error
+}
+
+func newBackfillRange(from, to int64) *backfillRange {
+	return &backfillRange{from: from, to: to, done: make(chan struct{})}
+}
+
+// backfill fetches the logs in the block range [start, end] and saves them to the db.
+//
+// Up to backfillConcurrency ranges of blocks are fetched in parallel, but they are saved in order, so an interrupted
+// backfill never leaves a gap before the last saved block. A range rejected by the RPC for returning too many results
+// is fetched again in ranges of half the size, which becomes the size of the following ranges as well, down to a single
+// block. After backfillGrowthSuccesses ranges in a row are fetched successfully, the size doubles again, up to
+// backfillBatchSize.
+func (lp *logPoller) backfill(ctx context.Context, start, end int64) error {
+	return lp.backfillWithProgress(ctx, start, end, nil, nil)
+}
+
+// backfillWithProgress is like backfill, and calls onSaved with the last block of each range once it has been saved.
+// If plan is not nil, only the logs of its filters are backfilled, instead of the logs of the registered filters.
+func (lp *logPoller) backfillWithProgress(ctx context.Context, start, end int64, plan *logQueryPlan, onSaved func(to int64)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	batchSize := lp.backfillBatchSize
+	successes := 0
+	next := start
+	var ranges []*backfillRange // ranges to save, in order
+	inFlight := 0
+
+	for len(ranges) > 0 || next <= end {
+		for next <= end && len(ranges) < lp.backfillConcurrency {
+			r := newBackfillRange(next, min(next+batchSize-1, end))
+			ranges = append(ranges, r)
+			next = r.to + 1
+		}
+		for i := 0; i < len(ranges) && inFlight < lp.backfillConcurrency; i++ {
+			r := ranges[i]
+			if r.dispatched {
+				continue
+			}
+			if r.to-r.from+1 > batchSize {
+				// The batch size was reduced since the range was queued, the rest is fetched separately.
+				ranges = slices.Insert(ranges, i+1, newBackfillRange(r.from+batchSize, r.to))
+				r.to = r.from + batchSize - 1
+			}
+			r.dispatched = true
+			inFlight++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(r.done)
+				lp.fetchBackfillRange(ctx, plan, r)
+			}()
+		}
+
+		r := ranges[0]
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		ranges = ranges[1:]
+		inFlight--
+
+		if r.queryErr != nil {
+			if !client.IsTooManyResults(r.queryErr, lp.clientErrors) {
+				lp.lggr.Errorw("Unable to query for logs", "err", r.queryErr, "from", r.from, "to", r.to)
+				return r.queryErr
+			}
+			if r.from == r.to {
+				lp.lggr.Criticalw("Too many log results in a single block, failed to retrieve logs! Node may be running in a degraded state.", "err", r.queryErr, "from", r.from, "to", r.to, "LogBackfillBatchSize", lp.backfillBatchSize)
+				return r.queryErr
+			}
+			batchSize = max(min(batchSize, r.to-r.from+1)/2, 1)
+			successes = 0
+			lp.lggr.Warnw("Too many log results, halving block range batch size.  Consider increasing LogBackfillBatchSize if this happens frequently", "err", r.queryErr, "from", r.from, "to", r.to, "newBatchSize", batchSize, "LogBackfillBatchSize", lp.backfillBatchSize)
+			// Queue the rejected range again, ahead of the ranges following it. It's split in ranges of the reduced
+			// size as they are dispatched.
+			ranges = slices.Insert(ranges, 0, newBackfillRange(r.from, r.to))
+			continue
+		}
+		if r.err != nil {
+			return r.err
+		}
+
+		if r.endBlock != nil {
+			if err := lp.orm.InsertLogsWithBlock(ctx, r.logs, *r.endBlock); err != nil {
+				lp.lggr.Warnw("Unable to insert logs, retrying", "err", err, "from", r.from, "to", r.to)
+				return err
+			}
+			lp.publishLogs(r.logs)
+		}
+		if onSaved != nil {
+			onSaved(r.to)
+		}
+
+		successes++
+		if successes >= backfillGrowthSuccesses && batchSize < lp.backfillBatchSize {
+			batchSize = min(batchSize*2, lp.backfillBatchSize)
+			successes = 0
+			lp.lggr.Debugw("Backfill succeeding, doubling block range batch size", "newBatchSize", batchSize, "LogBackfillBatchSize", lp.backfillBatchSize)
+		}
+	}
+	return nil
+}
+
+// fetchBackfillRange fetches the logs of r, and the blocks needed to save them.
+func (lp *logPoller) fetchBackfillRange(ctx context.Context, plan *logQueryPlan, r *backfillRange) {
+	queryPlan := plan
+	if queryPlan == nil {
+		queryPlan = lp.queryPlan()
+	}
+	gethLogs, err := lp.filterLogsWithPlan(ctx, queryPlan, big.NewInt(r.from), big.NewInt(r.to), nil)
+	if err != nil {
+		r.queryErr = err
+		return
+	}
+	if len(gethLogs) == 0 {
+		return
+	}
+	blocks, err := lp.blocksFromFinalizedLogs(ctx, gethLogs, uint64(r.to)) //nolint:gosec // G115
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	endBlock := blocks[len(blocks)-1]
+	if gethLogs[len(gethLogs)-1].BlockNumber != uint64(r.to) { //nolint:gosec // G115
+		// Pop endblock if there were no logs for it, so that length of blocks & gethLogs are the same to pass to convertLogs
+		blocks = blocks[:len(blocks)-1]
+	}
+
+	lp.lggr.Debugw("Backfill found logs", "from", r.from, "to", r.to, "logs", len(gethLogs), "blocks", blocks)
+	logs := convertLogs(gethLogs, blocks, lp.lggr, lp.ec.ConfiguredChainID())
+	if plan == nil {
+		r.logs = lp.dropUnmatchedLogs(queryPlan, logs)
+	} else {
+		// The metrics only cover the query plan of the registered filters.
+		r.logs = lp.limitLogsPerBlock(plan, plan.matchingLogs(logs))
+	}
+	r.endBlock = &endBlock
+}
+
+// replayBackfill backfills the block range [fromBlock, end] for Replay. If a previous Replay with the same filters was
+// interrupted after backfilling fromBlock, including by a restart, it resumes where that one stopped. The progress is
+// saved by the ORM after each range saved.
+func (lp *logPoller) replayBackfill(ctx context.Context, fromBlock, end int64) error {
+	checkpoint := ReplayCheckpoint{FromBlock: fromBlock, NextBlock: fromBlock, FiltersHash: lp.queryPlan().hash()}
+	last, err := lp.orm.SelectReplayCheckpoint(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if last != nil && last.FiltersHash == checkpoint.FiltersHash && last.FromBlock <= fromBlock && fromBlock < last.NextBlock {
+		lp.lggr.Infow("Resuming interrupted replay", "fromBlock", fromBlock, "resumeFromBlock", last.NextBlock)
+		checkpoint = *last
+	}
+
+	err = lp.backfillWithProgress(ctx, checkpoint.NextBlock, end, nil, func(to int64) {
+		checkpoint.NextBlock = to + 1
+		if err := lp.orm.UpsertReplayCheckpoint(ctx, checkpoint); err != nil {
+			lp.lggr.Warnw("Unable to save replay checkpoint", "err", err, "nextBlock", checkpoint.NextBlock)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return lp.orm.DeleteReplayCheckpoint(ctx)
+}
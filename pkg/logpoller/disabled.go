@@ -38,6 +38,14 @@ func (disabled) Replay(ctx context.Context, fromBlock int64) error { return ErrD
 
 func (disabled) ReplayAsync(fromBlock int64) {}
 
+func (disabled) ReplayFilter(ctx context.Context, name string, fromBlock int64) error {
+	return ErrDisabled
+}
+
+func (disabled) GetFilterReplayStatus(name string) (FilterReplayStatus, bool) {
+	return FilterReplayStatus{}, false
+}
+
 func (disabled) RegisterFilter(ctx context.Context, filter Filter) error { return ErrDisabled }
 
 func (disabled) UnregisterFilter(ctx context.Context, name string) error { return ErrDisabled }
@@ -129,3 +137,11 @@ func (d disabled) FindLCA(ctx context.Context) (*Block, error) {
 func (d disabled) DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error {
 	return ErrDisabled
 }
+
+func (d disabled) Subscribe(ctx context.Context, filterName string, fromBlock int64) (*Subscription, error) {
+	return nil, ErrDisabled
+}
+
+func (d disabled) SubscribeFromCursor(ctx context.Context, filterName string, cursor string) (*Subscription, error) {
+	return nil, ErrDisabled
+}
@@ -19,4 +19,28 @@
 //   - After calling Replay(fromBlock), all blocks including that one to the latest chain tip will be polled
 //     with the current filter. This can be used on first time job add to specify a start block from which you wish to capture
 //     existing logs.
+//   - After calling ReplayFilter(name, fromBlock), all blocks including that one to the latest block polled will have been
+//     polled with the filter name only. This can be used to capture the existing logs of a new filter without re-fetching
+//     the logs of the other filters.
+//
+// A filter can carry the ABI of its events, saved with it, in which case logs of these events which don't decode with it
+// are dropped, FilteredLogs returns the logs decoded, and NewEventByFieldFilter queries them by field name. The fields
+// of the events selected by Filter.IndexedFields are indexed by DSORM as the logs are saved; the others, if they aren't
+// indexed in the event, are read from the logs matched by the other expressions of the query.
+//
+// The Auditor re-fetches the logs of ranges of finalized blocks saved, possibly from another RPC node, to report and
+// repair the logs missing from incomplete eth_getLogs results.
+//
+// With Opts.StreamLogs, the logs of the registered filters are also received by a subscription, which triggers polling
+// new blocks as soon as they have logs and saves the logs streamed instead of fetching them with eth_getLogs. Blocks
+// produced while the subscription is down or before it matched the current filters are still fetched with eth_getLogs.
+//
+// ExportSnapshot and ImportSnapshot copy the finalized logs of some filters from the db of a node to the db of another,
+// which then only polls the blocks after the snapshot instead of replaying them from the RPC.
+//
+// Logs, blocks and filters are persisted by the ORM passed to NewLogPoller. NewORM stores them in Postgres, while
+// NewEmbeddedORM stores them in an embedded key-value store (e.g. leveldb or pebble), for deployments without Postgres.
+// The tables of NewORM which aren't part of the node's schema are optional and created by Migrations, which must be run
+// separately: without them, the event ABIs of the filters aren't saved, their fields aren't indexed and replays don't
+// resume from a checkpoint.
 package logpoller
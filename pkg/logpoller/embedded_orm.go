@@ -0,0 +1,1320 @@
+package logpoller
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/lib/pq"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// Key layout of the embedded store. Every key starts with the prefix of the chain, followed by one of the record types:
+//   - block:  'b' | block_number
+//   - log:    'l' | block_number | log_index | block_hash
+//   - filter: 'f' | len(name) | name | address | event | topic2 | topic3 | topic4
+//   - filter event ABIs: 'e' | name
+//   - replay checkpoint: 'r'
+//
+// Numbers are big-endian encoded, so iterating over the store yields blocks and logs in order.
+const (
+	embeddedBlockRecord       = 'b'
+	embeddedLogRecord         = 'l'
+	embeddedFilterRecord      = 'f'
+	embeddedFilterEventRecord = 'e'
+	embeddedReplayRecord      = 'r'
+)
+
+// EmbeddedORM is an ORM for deployments running LogPoller without Postgres. Blocks, logs and filters are persisted to an
+// embedded key-value store, e.g. geth's pebble or leveldb databases, and indexed in memory so that queries never hit the
+// store. The writes of each call are committed to the store in a single batch before they are applied to the indexes.
+// Several chains can share the same store. Queries follow the semantics of DSORM, including the sql.ErrNoRows errors.
+type EmbeddedORM struct {
+	chainID    *big.Int
+	evmChainID *ubig.Big
+	db         ethdb.KeyValueStore
+	prefix     []byte
+	lggr       logger.Logger
+
+	mu        sync.RWMutex
+	blocks    []*Block       // sorted by block number
+	logs      []*embeddedLog // sorted by block number, log index and block hash
+	logsByID  map[uint64]*embeddedLog
+	filters   map[string]map[embeddedFilterRowKey]embeddedFilterRow
+	events    map[string][]abi.Event    // event ABIs of the filters, by name
+	indexed   map[string][]IndexedField // Filter.IndexedFields, saved with the events but not indexed
+	replay    *ReplayCheckpoint
+	nextLogID uint64
+}
+
+var _ ORM = &EmbeddedORM{}
+
+type embeddedLog struct {
+	id uint64
+	Log
+}
+
+// embeddedFilterRowKey identifies a row of a filter, like the rows of evm.log_poller_filters.
+type embeddedFilterRowKey struct {
+	address   common.Address
+	eventSig  common.Hash
+	topics    [3]common.Hash
+	hasTopics [3]bool
+}
+
+type embeddedFilterRow struct {
+	Retention    time.Duration
+	MaxLogsKept  uint64
+	LogsPerBlock uint64
+	CreatedAt    time.Time
+}
+
+// NewEmbeddedORM creates an EmbeddedORM scoped to chainID and loads the blocks, logs and filters of the chain from db.
+// db is not closed by the ORM.
+func NewEmbeddedORM(chainID *big.Int, db ethdb.KeyValueStore, lggr logger.Logger) (*EmbeddedORM, error) {
+	o := &EmbeddedORM{
+		chainID:    chainID,
+		evmChainID: ubig.New(chainID),
+		db:         db,
+		prefix:     []byte(fmt.Sprintf("logpoller:%s:", chainID)),
+		lggr:       lggr,
+		logsByID:   make(map[uint64]*embeddedLog),
+		filters:    make(map[string]map[embeddedFilterRowKey]embeddedFilterRow),
+		events:     make(map[string][]abi.Event),
+		indexed:    make(map[string][]IndexedField),
+	}
+	if err := o.load(); err != nil {
+		return nil, fmt.Errorf("failed to load log poller data of chain %s: %w", chainID, err)
+	}
+	return o, nil
+}
+
+func (o *EmbeddedORM) load() error {
+	it := o.db.NewIterator(o.prefix, nil)
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()[len(o.prefix):]
+		if len(key) == 0 {
+			continue
+		}
+		switch key[0] {
+		case embeddedBlockRecord:
+			var b Block
+			if err := json.Unmarshal(it.Value(), &b); err != nil {
+				return fmt.Errorf("failed to decode block: %w", err)
+			}
+			o.blocks = append(o.blocks, &b)
+		case embeddedLogRecord:
+			l := &embeddedLog{}
+			if err := json.Unmarshal(it.Value(), &l.Log); err != nil {
+				return fmt.Errorf("failed to decode log: %w", err)
+			}
+			o.logs = append(o.logs, o.withID(l))
+		case embeddedFilterRecord:
+			name, rowKey, err := decodeFilterRowKey(key[1:])
+			if err != nil {
+				return err
+			}
+			var row embeddedFilterRow
+			if err = json.Unmarshal(it.Value(), &row); err != nil {
+				return fmt.Errorf("failed to decode filter %q: %w", name, err)
+			}
+			o.filterRows(name)[rowKey] = row
+		case embeddedFilterEventRecord:
+			name := string(key[1:])
+			events, indexedFields, err := unmarshalEventABIs(it.Value())
+			if err != nil {
+				return fmt.Errorf("failed to decode the event ABIs of filter %q: %w", name, err)
+			}
+			o.events[name] = events
+			o.indexed[name] = indexedFields
+		case embeddedReplayRecord:
+			var c ReplayCheckpoint
+			if err := json.Unmarshal(it.Value(), &c); err != nil {
+				return fmt.Errorf("failed to decode replay checkpoint: %w", err)
+			}
+			o.replay = &c
+		default:
+			return fmt.Errorf("unknown record type: %q", key[0])
+		}
+	}
+	return it.Error()
+}
+
+func (o *EmbeddedORM) blockKey(blockNumber int64) []byte {
+	key := append(slices.Clip(o.prefix), embeddedBlockRecord)
+	return binary.BigEndian.AppendUint64(key, uint64(blockNumber))
+}
+
+func (o *EmbeddedORM) logKey(l *Log) []byte {
+	key := append(slices.Clip(o.prefix), embeddedLogRecord)
+	key = binary.BigEndian.AppendUint64(key, uint64(l.BlockNumber))
+	key = binary.BigEndian.AppendUint64(key, uint64(l.LogIndex))
+	return append(key, l.BlockHash.Bytes()...)
+}
+
+func (o *EmbeddedORM) filterRowKey(name string, rowKey embeddedFilterRowKey) []byte {
+	key := append(slices.Clip(o.prefix), embeddedFilterRecord)
+	key = binary.BigEndian.AppendUint32(key, uint32(len(name)))
+	key = append(key, name...)
+	key = append(key, rowKey.address.Bytes()...)
+	key = append(key, rowKey.eventSig.Bytes()...)
+	for i, topic := range rowKey.topics {
+		if rowKey.hasTopics[i] {
+			key = append(key, 1)
+			key = append(key, topic.Bytes()...)
+		} else {
+			key = append(key, 0)
+		}
+	}
+	return key
+}
+
+func decodeFilterRowKey(key []byte) (string, embeddedFilterRowKey, error) {
+	var rowKey embeddedFilterRowKey
+	if len(key) < 4 {
+		return "", rowKey, fmt.Errorf("invalid filter key: %x", key)
+	}
+	nameLen := int(binary.BigEndian.Uint32(key))
+	key = key[4:]
+	if len(key) < nameLen+common.AddressLength+common.HashLength {
+		return "", rowKey, fmt.Errorf("invalid filter key: %x", key)
+	}
+	name := string(key[:nameLen])
+	key = key[nameLen:]
+	rowKey.address = common.BytesToAddress(key[:common.AddressLength])
+	key = key[common.AddressLength:]
+	rowKey.eventSig = common.BytesToHash(key[:common.HashLength])
+	key = key[common.HashLength:]
+	for i := range rowKey.topics {
+		if len(key) == 0 {
+			return "", rowKey, fmt.Errorf("invalid topics in key of filter %q", name)
+		}
+		rowKey.hasTopics[i] = key[0] == 1
+		key = key[1:]
+		if !rowKey.hasTopics[i] {
+			continue
+		}
+		if len(key) < common.HashLength {
+			return "", rowKey, fmt.Errorf("invalid topics in key of filter %q", name)
+		}
+		rowKey.topics[i] = common.BytesToHash(key[:common.HashLength])
+		key = key[common.HashLength:]
+	}
+	return name, rowKey, nil
+}
+
+func (o *EmbeddedORM) withID(l *embeddedLog) *embeddedLog {
+	o.nextLogID++
+	l.id = o.nextLogID
+	o.logsByID[l.id] = l
+	return l
+}
+
+func (o *EmbeddedORM) filterRows(name string) map[embeddedFilterRowKey]embeddedFilterRow {
+	rows, ok := o.filters[name]
+	if !ok {
+		rows = make(map[embeddedFilterRowKey]embeddedFilterRow)
+		o.filters[name] = rows
+	}
+	return rows
+}
+
+// InsertBlock is idempotent to support replays.
+func (o *EmbeddedORM) InsertBlock(ctx context.Context, blockHash common.Hash, blockNumber int64, blockTimestamp time.Time, finalizedBlock int64) error {
+	return o.InsertLogsWithBlock(ctx, nil, Block{
+		BlockHash:            blockHash,
+		BlockNumber:          blockNumber,
+		BlockTimestamp:       blockTimestamp,
+		FinalizedBlockNumber: finalizedBlock,
+	})
+}
+
+// InsertLogs is idempotent to support replays.
+func (o *EmbeddedORM) InsertLogs(_ context.Context, logs []Log) error {
+	if err := o.validateLogs(logs); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	batch := o.db.NewBatch()
+	newLogs, err := o.putLogs(batch, logs)
+	if err != nil {
+		return err
+	}
+	if err = batch.Write(); err != nil {
+		return err
+	}
+	o.addLogs(newLogs)
+	return nil
+}
+
+// InsertLogsWithBlock persists the block and the logs atomically.
+func (o *EmbeddedORM) InsertLogsWithBlock(_ context.Context, logs []Log, block Block) error {
+	if block.BlockNumber < 0 || block.FinalizedBlockNumber < 0 {
+		return fmt.Errorf("invalid block: number %d, finalized block number %d", block.BlockNumber, block.FinalizedBlockNumber)
+	}
+	if err := o.validateLogs(logs); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	batch := o.db.NewBatch()
+	var newBlock *Block
+	// Like the primary key and unique index of evm.log_poller_blocks, a block is only inserted if neither its number
+	// nor its hash is already stored.
+	_, exists := o.searchBlock(block.BlockNumber)
+	if !exists && !slices.ContainsFunc(o.blocks, func(b *Block) bool { return b.BlockHash == block.BlockHash }) {
+		newBlock = &Block{
+			EVMChainID:           o.evmChainID,
+			BlockHash:            block.BlockHash,
+			BlockNumber:          block.BlockNumber,
+			BlockTimestamp:       block.BlockTimestamp,
+			FinalizedBlockNumber: block.FinalizedBlockNumber,
+			CreatedAt:            time.Now(),
+		}
+		value, err := json.Marshal(newBlock)
+		if err != nil {
+			return err
+		}
+		if err = batch.Put(o.blockKey(newBlock.BlockNumber), value); err != nil {
+			return err
+		}
+	}
+	newLogs, err := o.putLogs(batch, logs)
+	if err != nil {
+		return err
+	}
+	if err = batch.Write(); err != nil {
+		return err
+	}
+
+	if newBlock != nil {
+		i, _ := o.searchBlock(newBlock.BlockNumber)
+		o.blocks = slices.Insert(o.blocks, i, newBlock)
+	}
+	o.addLogs(newLogs)
+	return nil
+}
+
+func (o *EmbeddedORM) validateLogs(logs []Log) error {
+	for _, log := range logs {
+		if o.chainID.Cmp(log.EVMChainID.ToInt()) != 0 {
+			return fmt.Errorf("invalid chainID in log got %v want %v", log.EVMChainID.ToInt(), o.chainID)
+		}
+		if log.BlockNumber < 0 || log.LogIndex < 0 {
+			return fmt.Errorf("invalid log: block number %d, log index %d", log.BlockNumber, log.LogIndex)
+		}
+	}
+	return nil
+}
+
+// putLogs writes the logs which aren't stored yet to batch and returns them.
+func (o *EmbeddedORM) putLogs(batch ethdb.Batch, logs []Log) ([]*embeddedLog, error) {
+	var newLogs []*embeddedLog
+	seen := make(map[string]struct{}, len(logs))
+	for _, log := range logs {
+		if _, ok := o.searchLog(&log); ok {
+			continue
+		}
+		key := o.logKey(&log)
+		if _, ok := seen[string(key)]; ok {
+			continue
+		}
+		seen[string(key)] = struct{}{}
+
+		l := &embeddedLog{Log: copyLog(log)}
+		l.EVMChainID = o.evmChainID
+		l.CreatedAt = time.Now()
+		value, err := json.Marshal(&l.Log)
+		if err != nil {
+			return nil, err
+		}
+		if err = batch.Put(key, value); err != nil {
+			return nil, err
+		}
+		newLogs = append(newLogs, l)
+	}
+	return newLogs, nil
+}
+
+func (o *EmbeddedORM) addLogs(newLogs []*embeddedLog) {
+	if len(newLogs) == 0 {
+		return
+	}
+	sorted := len(o.logs) == 0 || compareLogs(&o.logs[len(o.logs)-1].Log, &newLogs[0].Log) < 0
+	for i, l := range newLogs {
+		o.logs = append(o.logs, o.withID(l))
+		if i > 0 && compareLogs(&newLogs[i-1].Log, &l.Log) > 0 {
+			sorted = false
+		}
+	}
+	if !sorted {
+		slices.SortFunc(o.logs, func(a, b *embeddedLog) int { return compareLogs(&a.Log, &b.Log) })
+	}
+}
+
+// compareLogs orders logs by block number, log index and block hash.
+func compareLogs(a, b *Log) int {
+	if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.LogIndex, b.LogIndex); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.BlockHash[:], b.BlockHash[:])
+}
+
+func copyLog(log Log) Log {
+	topics := make(pq.ByteaArray, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = bytes.Clone(topic)
+	}
+	log.Topics = topics
+	log.Data = bytes.Clone(log.Data)
+	return log
+}
+
+func (o *EmbeddedORM) searchLog(log *Log) (int, bool) {
+	return slices.BinarySearchFunc(o.logs, log, func(l *embeddedLog, target *Log) int {
+		return compareLogs(&l.Log, target)
+	})
+}
+
+func (o *EmbeddedORM) searchBlock(blockNumber int64) (int, bool) {
+	return slices.BinarySearchFunc(o.blocks, blockNumber, func(b *Block, target int64) int {
+		return cmp.Compare(b.BlockNumber, target)
+	})
+}
+
+// logsInRange returns the logs with start <= block_number <= end. The returned slice must not be modified.
+func (o *EmbeddedORM) logsInRange(start, end int64) []*embeddedLog {
+	lower := sort.Search(len(o.logs), func(i int) bool { return o.logs[i].BlockNumber >= start })
+	upper := sort.Search(len(o.logs), func(i int) bool { return o.logs[i].BlockNumber > end })
+	if lower >= upper {
+		return nil
+	}
+	return o.logs[lower:upper]
+}
+
+// blocksInRange returns the blocks with start <= block_number <= end. The returned slice must not be modified.
+func (o *EmbeddedORM) blocksInRange(start, end int64) []*Block {
+	lower := sort.Search(len(o.blocks), func(i int) bool { return o.blocks[i].BlockNumber >= start })
+	upper := sort.Search(len(o.blocks), func(i int) bool { return o.blocks[i].BlockNumber > end })
+	if lower >= upper {
+		return nil
+	}
+	return o.blocks[lower:upper]
+}
+
+func (o *EmbeddedORM) latestBlock() *Block {
+	if len(o.blocks) == 0 {
+		return nil
+	}
+	return o.blocks[len(o.blocks)-1]
+}
+
+// lastConfirmedBlock returns the highest block number with confs confirmations, like withConfs. ok is false if
+// there are no blocks, in which case no log is confirmed.
+func (o *EmbeddedORM) lastConfirmedBlock(confs evmtypes.Confirmations) (blockNumber int64, ok bool) {
+	latest := o.latestBlock()
+	if latest == nil {
+		return 0, false
+	}
+	if confs == evmtypes.Finalized {
+		return latest.FinalizedBlockNumber, true
+	}
+	return latest.BlockNumber - int64(confs), true
+}
+
+// selectLogs returns copies of the logs with start <= block_number <= end matching pred, ordered by block number and
+// log index.
+func (o *EmbeddedORM) selectLogs(start, end int64, pred func(l *Log) bool) []Log {
+	var logs []Log
+	for _, l := range o.logsInRange(start, end) {
+		if pred(&l.Log) {
+			logs = append(logs, copyLog(l.Log))
+		}
+	}
+	return logs
+}
+
+// selectConfirmedLogs is like selectLogs, with the end of the range capped by the last block with confs confirmations.
+func (o *EmbeddedORM) selectConfirmedLogs(start, end int64, confs evmtypes.Confirmations, pred func(l *Log) bool) []Log {
+	lastConfirmed, ok := o.lastConfirmedBlock(confs)
+	if !ok {
+		return nil
+	}
+	return o.selectLogs(start, min(end, lastConfirmed), pred)
+}
+
+func (o *EmbeddedORM) filterEventsKey(name string) []byte {
+	key := append(slices.Clip(o.prefix), embeddedFilterEventRecord)
+	return append(key, name...)
+}
+
+func (o *EmbeddedORM) InsertFilter(_ context.Context, filter Filter) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events, err := marshalEventABIs(filter.Events, filter.IndexedFields)
+	if err != nil {
+		return err
+	}
+	rowKeys := filterRowKeys(filter)
+	if len(rowKeys) == 0 {
+		return nil
+	}
+	// Like DSORM, the rows already saved keep their creation time.
+	now := time.Now()
+	newRows := make([]embeddedFilterRow, len(rowKeys))
+	batch := o.db.NewBatch()
+	for i, rowKey := range rowKeys {
+		newRows[i] = embeddedFilterRow{Retention: filter.Retention, MaxLogsKept: filter.MaxLogsKept, LogsPerBlock: filter.LogsPerBlock, CreatedAt: now}
+		if existing, ok := o.filters[filter.Name][rowKey]; ok {
+			newRows[i].CreatedAt = existing.CreatedAt
+		}
+		value, err := json.Marshal(newRows[i])
+		if err != nil {
+			return err
+		}
+		if err = batch.Put(o.filterRowKey(filter.Name, rowKey), value); err != nil {
+			return err
+		}
+	}
+	// Like DSORM, the event ABIs are replaced by those of the latest registration of the filter.
+	if events == nil {
+		err = batch.Delete(o.filterEventsKey(filter.Name))
+	} else {
+		err = batch.Put(o.filterEventsKey(filter.Name), events)
+	}
+	if err != nil {
+		return err
+	}
+	if err = batch.Write(); err != nil {
+		return err
+	}
+	rows := o.filterRows(filter.Name)
+	for i, rowKey := range rowKeys {
+		rows[rowKey] = newRows[i]
+	}
+	if events == nil {
+		delete(o.events, filter.Name)
+		delete(o.indexed, filter.Name)
+	} else {
+		o.events[filter.Name] = slices.Clone(filter.Events)
+		o.indexed[filter.Name] = slices.Clone(filter.IndexedFields)
+	}
+	return nil
+}
+
+// filterRowKeys expands filter into one row per combination of address, event and topic values, like DSORM.InsertFilter.
+func filterRowKeys(filter Filter) []embeddedFilterRowKey {
+	var rowKeys []embeddedFilterRowKey
+	for _, address := range filter.Addresses {
+		for _, eventSig := range filter.EventSigs {
+			rowKeys = append(rowKeys, embeddedFilterRowKey{address: address, eventSig: eventSig})
+		}
+	}
+	for i, topicValues := range []evmtypes.HashArray{filter.Topic2, filter.Topic3, filter.Topic4} {
+		if len(topicValues) == 0 {
+			continue
+		}
+		expanded := make([]embeddedFilterRowKey, 0, len(rowKeys)*len(topicValues))
+		for _, rowKey := range rowKeys {
+			for _, topic := range topicValues {
+				rowKey.topics[i], rowKey.hasTopics[i] = topic, true
+				expanded = append(expanded, rowKey)
+			}
+		}
+		rowKeys = expanded
+	}
+	return rowKeys
+}
+
+// DeleteFilter removes all events,address pairs associated with the Filter
+func (o *EmbeddedORM) DeleteFilter(_ context.Context, name string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	batch := o.db.NewBatch()
+	for rowKey := range o.filters[name] {
+		if err := batch.Delete(o.filterRowKey(name, rowKey)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Delete(o.filterEventsKey(name)); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	delete(o.filters, name)
+	delete(o.events, name)
+	delete(o.indexed, name)
+	return nil
+}
+
+// LoadFilters returns all filters for this chain
+func (o *EmbeddedORM) LoadFilters(_ context.Context) (map[string]Filter, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	filters := make(map[string]Filter, len(o.filters))
+	for name, rows := range o.filters {
+		filter := Filter{Name: name}
+		var topics [3]evmtypes.HashArray
+		for rowKey, row := range rows {
+			filter.Addresses = append(filter.Addresses, rowKey.address)
+			filter.EventSigs = append(filter.EventSigs, rowKey.eventSig)
+			for i, topic := range rowKey.topics {
+				if rowKey.hasTopics[i] {
+					topics[i] = append(topics[i], topic)
+				}
+			}
+			filter.Retention = max(filter.Retention, row.Retention)
+			filter.MaxLogsKept = max(filter.MaxLogsKept, row.MaxLogsKept)
+			filter.LogsPerBlock = max(filter.LogsPerBlock, row.LogsPerBlock)
+		}
+		filter.Addresses = sortedUnique(filter.Addresses, func(a, b common.Address) int { return a.Cmp(b) })
+		filter.EventSigs = sortedUnique(filter.EventSigs, func(a, b common.Hash) int { return a.Cmp(b) })
+		filter.Topic2 = sortedUnique(topics[0], func(a, b common.Hash) int { return a.Cmp(b) })
+		filter.Topic3 = sortedUnique(topics[1], func(a, b common.Hash) int { return a.Cmp(b) })
+		filter.Topic4 = sortedUnique(topics[2], func(a, b common.Hash) int { return a.Cmp(b) })
+		filter.Events = slices.Clone(o.events[name])
+		filter.IndexedFields = slices.Clone(o.indexed[name])
+		filters[name] = filter
+	}
+	return filters, nil
+}
+
+func sortedUnique[S ~[]E, E comparable](s S, cmp func(a, b E) int) S {
+	slices.SortFunc(s, cmp)
+	return slices.Compact(s)
+}
+
+func (o *EmbeddedORM) SelectBlockByHash(_ context.Context, hash common.Hash) (*Block, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	for _, b := range o.blocks {
+		if b.BlockHash == hash {
+			block := *b
+			return &block, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (o *EmbeddedORM) SelectBlockByNumber(_ context.Context, n int64) (*Block, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	i, ok := o.searchBlock(n)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	block := *o.blocks[i]
+	return &block, nil
+}
+
+func (o *EmbeddedORM) SelectLatestBlock(_ context.Context) (*Block, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	latest := o.latestBlock()
+	if latest == nil {
+		return nil, sql.ErrNoRows
+	}
+	block := *latest
+	return &block, nil
+}
+
+// SelectFilterStartBlocks returns the first block saved after each filter was last extended, like DSORM.
+func (o *EmbeddedORM) SelectFilterStartBlocks(_ context.Context) (map[string]int64, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	starts := make(map[string]int64, len(o.filters))
+	for name, rows := range o.filters {
+		var createdAt time.Time
+		for _, row := range rows {
+			if row.CreatedAt.After(createdAt) {
+				createdAt = row.CreatedAt
+			}
+		}
+		start := int64(math.MaxInt64)
+		for _, b := range o.blocks {
+			if b.CreatedAt.After(createdAt) {
+				start = min(start, b.BlockNumber)
+			}
+		}
+		if start != math.MaxInt64 {
+			starts[name] = start
+		}
+	}
+	return starts, nil
+}
+
+func (o *EmbeddedORM) SelectReplayCheckpoint(_ context.Context) (*ReplayCheckpoint, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.replay == nil {
+		return nil, sql.ErrNoRows
+	}
+	c := *o.replay
+	return &c, nil
+}
+
+func (o *EmbeddedORM) UpsertReplayCheckpoint(_ context.Context, checkpoint ReplayCheckpoint) error {
+	value, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err = o.db.Put(o.replayKey(), value); err != nil {
+		return err
+	}
+	o.replay = &checkpoint
+	return nil
+}
+
+func (o *EmbeddedORM) DeleteReplayCheckpoint(_ context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.db.Delete(o.replayKey()); err != nil {
+		return err
+	}
+	o.replay = nil
+	return nil
+}
+
+func (o *EmbeddedORM) replayKey() []byte {
+	return append(slices.Clip(o.prefix), embeddedReplayRecord)
+}
+
+func (o *EmbeddedORM) SelectLatestFinalizedBlock(_ context.Context) (*Block, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	finalized, ok := o.lastConfirmedBlock(evmtypes.Finalized)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	blocks := o.blocksInRange(0, finalized)
+	if len(blocks) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	block := *blocks[len(blocks)-1]
+	return &block, nil
+}
+
+func (o *EmbeddedORM) SelectOldestBlock(_ context.Context, minAllowedBlockNumber int64) (*Block, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	blocks := o.blocksInRange(minAllowedBlockNumber, math.MaxInt64)
+	if len(blocks) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	block := *blocks[0]
+	return &block, nil
+}
+
+func (o *EmbeddedORM) GetBlocksRange(_ context.Context, start int64, end int64) ([]Block, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var blocks []Block
+	for _, b := range o.blocksInRange(start, end) {
+		blocks = append(blocks, *b)
+	}
+	return blocks, nil
+}
+
+// execPaged mirrors RangeQueryer.ExecPagedQuery: query is executed on ranges of limit blocks, starting from the oldest
+// block, until limit rows are affected or end is reached. If limit is 0, query is executed once on all blocks up to end.
+func (o *EmbeddedORM) execPaged(limit, end int64, query func(lower, upper int64) int64) int64 {
+	if limit == 0 {
+		return query(0, end)
+	}
+	if len(o.blocks) == 0 {
+		return 0
+	}
+
+	var rowsAffected, upper int64
+	for lower := o.blocks[0].BlockNumber; rowsAffected < limit; lower = upper + 1 {
+		upper = min(lower+limit-1, end)
+		rowsAffected += query(lower, upper)
+		if upper >= end {
+			break
+		}
+	}
+	return rowsAffected
+}
+
+// DeleteBlocksBefore delete blocks before and including end. When limit is set, it will delete at most limit blocks.
+// Otherwise, it will delete all blocks at once.
+func (o *EmbeddedORM) DeleteBlocksBefore(_ context.Context, end int64, limit int64) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var toDelete []*Block
+	o.execPaged(limit, end, func(lower, upper int64) int64 {
+		blocks := o.blocksInRange(lower, upper)
+		toDelete = append(toDelete, blocks...)
+		return int64(len(blocks))
+	})
+	if len(toDelete) == 0 {
+		return 0, nil
+	}
+
+	batch := o.db.NewBatch()
+	deleted := make(map[int64]struct{}, len(toDelete))
+	for _, b := range toDelete {
+		if err := batch.Delete(o.blockKey(b.BlockNumber)); err != nil {
+			return 0, err
+		}
+		deleted[b.BlockNumber] = struct{}{}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	o.blocks = slices.DeleteFunc(o.blocks, func(b *Block) bool {
+		_, ok := deleted[b.BlockNumber]
+		return ok
+	})
+	return int64(len(toDelete)), nil
+}
+
+func (o *EmbeddedORM) DeleteLogsAndBlocksAfter(_ context.Context, start int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	blocks := o.blocksInRange(start, math.MaxInt64)
+	logs := o.logsInRange(start, math.MaxInt64)
+	batch := o.db.NewBatch()
+	for _, b := range blocks {
+		if err := batch.Delete(o.blockKey(b.BlockNumber)); err != nil {
+			return err
+		}
+	}
+	for _, l := range logs {
+		if err := batch.Delete(o.logKey(&l.Log)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		o.lggr.Warnw("Unable to clear reorged blocks and logs, retrying", "err", err)
+		return err
+	}
+
+	for _, l := range logs {
+		delete(o.logsByID, l.id)
+	}
+	o.blocks = o.blocks[:len(o.blocks)-len(blocks)]
+	o.logs = o.logs[:len(o.logs)-len(logs)]
+	return nil
+}
+
+// filterEventKey groups the rows of the filters by address and event, like the pruning queries of DSORM.
+type filterEventKey struct {
+	address  common.Address
+	eventSig common.Hash
+}
+
+func (o *EmbeddedORM) SelectUnmatchedLogIDs(_ context.Context, limit int64) ([]uint64, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	latest := o.latestBlock()
+	if latest == nil {
+		return nil, sql.ErrNoRows
+	}
+	matched := make(map[filterEventKey]struct{})
+	for _, rows := range o.filters {
+		for rowKey := range rows {
+			matched[filterEventKey{rowKey.address, rowKey.eventSig}] = struct{}{}
+		}
+	}
+
+	var ids []uint64
+	o.execPaged(limit, latest.FinalizedBlockNumber, func(lower, upper int64) int64 {
+		var found int64
+		for _, l := range o.logsInRange(lower, upper) {
+			if _, ok := matched[filterEventKey{l.Address, l.EventSig}]; !ok {
+				ids = append(ids, l.id)
+				found++
+			}
+		}
+		return found
+	})
+	return ids, nil
+}
+
+// SelectExcessLogIDs finds any logs old enough that MaxLogsKept has been exceeded for every filter they match, or
+// beyond the LogsPerBlock first logs of their block for every filter they match.
+func (o *EmbeddedORM) SelectExcessLogIDs(_ context.Context, limit int64) ([]uint64, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	latest := o.latestBlock()
+	if latest == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	type excessFilter struct {
+		events       map[filterEventKey]struct{}
+		maxLogsKept  uint64
+		logsPerBlock uint64
+	}
+	filters := make([]excessFilter, 0, len(o.filters))
+	for _, rows := range o.filters {
+		f := excessFilter{events: make(map[filterEventKey]struct{})}
+		for rowKey, row := range rows {
+			f.maxLogsKept = max(f.maxLogsKept, row.MaxLogsKept)
+			f.logsPerBlock = max(f.logsPerBlock, row.LogsPerBlock)
+			f.events[filterEventKey{rowKey.address, rowKey.eventSig}] = struct{}{}
+		}
+		filters = append(filters, f)
+	}
+
+	var ids []uint64
+	o.execPaged(limit, latest.FinalizedBlockNumber, func(lower, upper int64) int64 {
+		// Like DSORM, logs are counted per filter within the page, ordered by block number and descending log index.
+		logs := slices.Clone(o.logsInRange(lower, upper))
+		slices.SortStableFunc(logs, func(a, b *embeddedLog) int {
+			if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.LogIndex, a.LogIndex)
+		})
+
+		// A log is excess only if it's old for all the filters it matches.
+		excess := make(map[uint64]bool)
+		for _, f := range filters {
+			// The logs of a block are in descending log index order, so the rank of a log in its block by log index is
+			// the number of logs of the block matched from it on.
+			perBlock := make(map[int64]uint64)
+			for _, l := range logs {
+				if _, ok := f.events[filterEventKey{l.Address, l.EventSig}]; ok {
+					perBlock[l.BlockNumber]++
+				}
+			}
+
+			var matched uint64
+			for _, l := range logs {
+				if _, ok := f.events[filterEventKey{l.Address, l.EventSig}]; !ok {
+					continue
+				}
+				matched++
+				rank := perBlock[l.BlockNumber]
+				perBlock[l.BlockNumber]--
+				old := (f.maxLogsKept != 0 && matched > f.maxLogsKept) || (f.logsPerBlock != 0 && rank > f.logsPerBlock)
+				if wasOld, ok := excess[l.id]; ok {
+					old = old && wasOld
+				}
+				excess[l.id] = old
+			}
+		}
+
+		var found int64
+		for _, l := range logs {
+			if excess[l.id] {
+				ids = append(ids, l.id)
+				found++
+			}
+		}
+		return found
+	})
+	return ids, nil
+}
+
+// DeleteExpiredLogs removes any logs which have a timestamp older than any matching filter's retention, UNLESS there
+// is at least one matching filter with retention=0
+func (o *EmbeddedORM) DeleteExpiredLogs(_ context.Context, limit int64) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	type retention struct{ min, max time.Duration }
+	retentions := make(map[filterEventKey]retention)
+	for _, rows := range o.filters {
+		for rowKey, row := range rows {
+			key := filterEventKey{rowKey.address, rowKey.eventSig}
+			r, ok := retentions[key]
+			if !ok {
+				r = retention{min: row.Retention, max: row.Retention}
+			}
+			retentions[key] = retention{min: min(r.min, row.Retention), max: max(r.max, row.Retention)}
+		}
+	}
+
+	now := time.Now()
+	var toDelete []*embeddedLog
+	for _, l := range o.logs {
+		if limit > 0 && int64(len(toDelete)) >= limit {
+			break
+		}
+		r, ok := retentions[filterEventKey{l.Address, l.EventSig}]
+		if ok && r.min > 0 && !l.BlockTimestamp.After(now.Add(-r.max)) {
+			toDelete = append(toDelete, l)
+		}
+	}
+	return o.deleteLogs(toDelete)
+}
+
+// DeleteLogsByRowID accepts a list of log row id's to delete
+func (o *EmbeddedORM) DeleteLogsByRowID(_ context.Context, rowIDs []uint64) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var toDelete []*embeddedLog
+	for _, id := range rowIDs {
+		if l, ok := o.logsByID[id]; ok {
+			toDelete = append(toDelete, l)
+		}
+	}
+	return o.deleteLogs(toDelete)
+}
+
+func (o *EmbeddedORM) deleteLogs(toDelete []*embeddedLog) (int64, error) {
+	if len(toDelete) == 0 {
+		return 0, nil
+	}
+	batch := o.db.NewBatch()
+	deleted := make(map[uint64]struct{}, len(toDelete))
+	for _, l := range toDelete {
+		if _, ok := deleted[l.id]; ok {
+			continue
+		}
+		if err := batch.Delete(o.logKey(&l.Log)); err != nil {
+			return 0, err
+		}
+		deleted[l.id] = struct{}{}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	o.logs = slices.DeleteFunc(o.logs, func(l *embeddedLog) bool {
+		_, ok := deleted[l.id]
+		return ok
+	})
+	for id := range deleted {
+		delete(o.logsByID, id)
+	}
+	return int64(len(deleted)), nil
+}
+
+func (o *EmbeddedORM) SelectLogsByBlockRange(_ context.Context, start, end int64) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.selectLogs(start, end, func(*Log) bool { return true }), nil
+}
+
+// SelectLogs finds the logs in a given block range.
+func (o *EmbeddedORM) SelectLogs(_ context.Context, start, end int64, address common.Address, eventSig common.Hash) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.selectLogs(start, end, matchEvent(address, eventSig)), nil
+}
+
+// SelectLogsWithSigs finds the logs in the given block range with the given event signatures
+// emitted from the given address.
+func (o *EmbeddedORM) SelectLogsWithSigs(_ context.Context, start, end int64, address common.Address, eventSigs []common.Hash) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.selectLogs(start, end, func(l *Log) bool {
+		return l.Address == address && slices.Contains(eventSigs, l.EventSig)
+	}), nil
+}
+
+// SelectLogsCreatedAfter finds logs created after some timestamp.
+func (o *EmbeddedORM) SelectLogsCreatedAfter(_ context.Context, address common.Address, eventSig common.Hash, after time.Time, confs evmtypes.Confirmations) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchEvent(address, eventSig)
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, func(l *Log) bool {
+		return match(l) && l.BlockTimestamp.After(after)
+	}), nil
+}
+
+func (o *EmbeddedORM) SelectLatestLogByEventSigWithConfs(_ context.Context, eventSig common.Hash, address common.Address, confs evmtypes.Confirmations) (*Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	lastConfirmed, ok := o.lastConfirmedBlock(confs)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	logs := o.logsInRange(0, lastConfirmed)
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i].Address == address && logs[i].EventSig == eventSig {
+			log := copyLog(logs[i].Log)
+			return &log, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// SelectLatestLogEventSigsAddrsWithConfs finds the latest log by (address, event) combination that matches a list of Addresses and list of events
+func (o *EmbeddedORM) SelectLatestLogEventSigsAddrsWithConfs(_ context.Context, fromBlock int64, addresses []common.Address, eventSigs []common.Hash, confs evmtypes.Confirmations) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	latest := make(map[filterEventKey]Log)
+	for _, l := range o.selectConfirmedLogs(fromBlock, math.MaxInt64, confs, matchEvents(addresses, eventSigs)) {
+		latest[filterEventKey{l.Address, l.EventSig}] = l
+	}
+	var logs []Log
+	for _, l := range latest {
+		logs = append(logs, l)
+	}
+	slices.SortFunc(logs, func(a, b Log) int { return compareLogs(&a, &b) })
+	return logs, nil
+}
+
+// SelectLatestBlockByEventSigsAddrsWithConfs finds the latest block number that matches a list of Addresses and list of events. It returns 0 if there is no matching block
+func (o *EmbeddedORM) SelectLatestBlockByEventSigsAddrsWithConfs(_ context.Context, fromBlock int64, eventSigs []common.Hash, addresses []common.Address, confs evmtypes.Confirmations) (int64, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	lastConfirmed, ok := o.lastConfirmedBlock(confs)
+	if !ok {
+		return 0, nil
+	}
+	match := matchEvents(addresses, eventSigs)
+	logs := o.logsInRange(fromBlock, lastConfirmed)
+	for i := len(logs) - 1; i >= 0; i-- {
+		if match(&logs[i].Log) {
+			return logs[i].BlockNumber, nil
+		}
+	}
+	return 0, nil
+}
+
+func (o *EmbeddedORM) SelectLogsDataWordRange(_ context.Context, address common.Address, eventSig common.Hash, wordIndex int, wordValueMin, wordValueMax common.Hash, confs evmtypes.Confirmations) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchEvent(address, eventSig)
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, func(l *Log) bool {
+		word := dataWord(l, wordIndex)
+		return match(l) && bytes.Compare(word, wordValueMin[:]) >= 0 && bytes.Compare(word, wordValueMax[:]) <= 0
+	}), nil
+}
+
+func (o *EmbeddedORM) SelectLogsDataWordGreaterThan(_ context.Context, address common.Address, eventSig common.Hash, wordIndex int, wordValueMin common.Hash, confs evmtypes.Confirmations) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchEvent(address, eventSig)
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, func(l *Log) bool {
+		return match(l) && bytes.Compare(dataWord(l, wordIndex), wordValueMin[:]) >= 0
+	}), nil
+}
+
+func (o *EmbeddedORM) SelectLogsDataWordBetween(_ context.Context, address common.Address, eventSig common.Hash, wordIndexMin int, wordIndexMax int, wordValue common.Hash, confs evmtypes.Confirmations) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchEvent(address, eventSig)
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, func(l *Log) bool {
+		return match(l) &&
+			bytes.Compare(dataWord(l, wordIndexMin), wordValue[:]) <= 0 &&
+			bytes.Compare(dataWord(l, wordIndexMax), wordValue[:]) >= 0
+	}), nil
+}
+
+func (o *EmbeddedORM) SelectIndexedLogsTopicGreaterThan(_ context.Context, address common.Address, eventSig common.Hash, topicIndex int, topicValueMin common.Hash, confs evmtypes.Confirmations) ([]Log, error) {
+	if err := validateTopicIndex(topicIndex); err != nil {
+		return nil, err
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchEvent(address, eventSig)
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, func(l *Log) bool {
+		topic, ok := logTopic(l, topicIndex)
+		return ok && match(l) && bytes.Compare(topic, topicValueMin[:]) >= 0
+	}), nil
+}
+
+func (o *EmbeddedORM) SelectIndexedLogsTopicRange(_ context.Context, address common.Address, eventSig common.Hash, topicIndex int, topicValueMin, topicValueMax common.Hash, confs evmtypes.Confirmations) ([]Log, error) {
+	if err := validateTopicIndex(topicIndex); err != nil {
+		return nil, err
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchEvent(address, eventSig)
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, func(l *Log) bool {
+		topic, ok := logTopic(l, topicIndex)
+		return ok && match(l) && bytes.Compare(topic, topicValueMin[:]) >= 0 && bytes.Compare(topic, topicValueMax[:]) <= 0
+	}), nil
+}
+
+func (o *EmbeddedORM) SelectIndexedLogs(_ context.Context, address common.Address, eventSig common.Hash, topicIndex int, topicValues []common.Hash, confs evmtypes.Confirmations) ([]Log, error) {
+	if err := validateTopicIndex(topicIndex); err != nil {
+		return nil, err
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, matchIndexedEvent(address, eventSig, topicIndex, topicValues)), nil
+}
+
+// SelectIndexedLogsByBlockRange finds the indexed logs in a given block range.
+func (o *EmbeddedORM) SelectIndexedLogsByBlockRange(_ context.Context, start, end int64, address common.Address, eventSig common.Hash, topicIndex int, topicValues []common.Hash) ([]Log, error) {
+	if err := validateTopicIndex(topicIndex); err != nil {
+		return nil, err
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.selectLogs(start, end, matchIndexedEvent(address, eventSig, topicIndex, topicValues)), nil
+}
+
+func (o *EmbeddedORM) SelectIndexedLogsCreatedAfter(_ context.Context, address common.Address, eventSig common.Hash, topicIndex int, topicValues []common.Hash, after time.Time, confs evmtypes.Confirmations) ([]Log, error) {
+	if err := validateTopicIndex(topicIndex); err != nil {
+		return nil, err
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchIndexedEvent(address, eventSig, topicIndex, topicValues)
+	return o.selectConfirmedLogs(0, math.MaxInt64, confs, func(l *Log) bool {
+		return match(l) && l.BlockTimestamp.After(after)
+	}), nil
+}
+
+func (o *EmbeddedORM) SelectIndexedLogsByTxHash(_ context.Context, address common.Address, eventSig common.Hash, txHash common.Hash) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	match := matchEvent(address, eventSig)
+	return o.selectLogs(0, math.MaxInt64, func(l *Log) bool {
+		return match(l) && l.TxHash == txHash
+	}), nil
+}
+
+// SelectIndexedLogsWithSigsExcluding query's for logs that have signature A and exclude logs that have a corresponding signature B, matching is done based on the topic index both logs should be inside the block range and have the minimum number of evmtypes.Confirmations
+func (o *EmbeddedORM) SelectIndexedLogsWithSigsExcluding(_ context.Context, sigA, sigB common.Hash, topicIndex int, address common.Address, startBlock, endBlock int64, confs evmtypes.Confirmations) ([]Log, error) {
+	if err := validateTopicIndex(topicIndex); err != nil {
+		return nil, err
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	excluded := make(map[string]struct{})
+	for _, l := range o.selectConfirmedLogs(startBlock, endBlock, confs, matchEvent(address, sigB)) {
+		if topic, ok := logTopic(&l, topicIndex); ok {
+			excluded[string(topic)] = struct{}{}
+		}
+	}
+	matchA := matchEvent(address, sigA)
+	return o.selectConfirmedLogs(startBlock, endBlock, confs, func(l *Log) bool {
+		if !matchA(l) {
+			return false
+		}
+		topic, ok := logTopic(l, topicIndex)
+		if !ok {
+			return true
+		}
+		_, isExcluded := excluded[string(topic)]
+		return !isExcluded
+	}), nil
+}
+
+func (o *EmbeddedORM) FilteredLogs(_ context.Context, filter []query.Expression, limitAndSort query.LimitAndSort, _ string) ([]Log, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	parser := &embeddedDSLParser{latest: o.latestBlock()}
+	match, order, err := parser.buildQuery(filter, limitAndSort)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := o.selectLogs(0, math.MaxInt64, match)
+	slices.SortStableFunc(logs, func(a, b Log) int { return order(&a, &b) })
+	if limitAndSort.HasCursorLimit() || limitAndSort.Limit.Count > 0 {
+		logs = logs[:min(uint64(len(logs)), limitAndSort.Limit.Count)]
+	}
+	return logs, nil
+}
+
+func matchEvent(address common.Address, eventSig common.Hash) func(l *Log) bool {
+	return func(l *Log) bool {
+		return l.Address == address && l.EventSig == eventSig
+	}
+}
+
+func matchEvents(addresses []common.Address, eventSigs []common.Hash) func(l *Log) bool {
+	return func(l *Log) bool {
+		return slices.Contains(addresses, l.Address) && slices.Contains(eventSigs, l.EventSig)
+	}
+}
+
+func matchIndexedEvent(address common.Address, eventSig common.Hash, topicIndex int, topicValues []common.Hash) func(l *Log) bool {
+	return func(l *Log) bool {
+		topic, ok := logTopic(l, topicIndex)
+		return ok && l.Address == address && l.EventSig == eventSig &&
+			slices.ContainsFunc(topicValues, func(v common.Hash) bool { return bytes.Equal(topic, v[:]) })
+	}
+}
+
+// validateTopicIndex mirrors queryArgs.withTopicIndex.
+func validateTopicIndex(index int) error {
+	// Only topicIndex 1 through 3 is valid. 0 is the event sig and only 4 total topics are allowed
+	if !(index == 1 || index == 2 || index == 3) {
+		return fmt.Errorf("invalid index for topic: %d", index)
+	}
+	return nil
+}
+
+// logTopic returns the topic at index, which is false if the log doesn't have it, like NULL elements of Postgres arrays.
+func logTopic(l *Log, index int) ([]byte, bool) {
+	if index < 0 || index >= len(l.Topics) {
+		return nil, false
+	}
+	return l.Topics[index], true
+}
+
+// dataWord returns the 32 byte word of the log data at index. Like substring in Postgres, it's truncated or empty
+// if the data is too short.
+func dataWord(l *Log, index int) []byte {
+	start := 32 * index
+	if index < 0 || start >= len(l.Data) {
+		return nil
+	}
+	return l.Data[start:min(start+32, len(l.Data))]
+}
@@ -0,0 +1,118 @@
+package logpoller_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+func TestEmbeddedORM_Persistence(t *testing.T) {
+	ctx := testutils.Context(t)
+	lggr := logger.Test(t)
+	chainID := testutils.NewRandomEVMChainID()
+	db := memorydb.New()
+	o, err := logpoller.NewEmbeddedORM(chainID, db, lggr)
+	require.NoError(t, err)
+
+	event := common.HexToHash("0x1234")
+	address := common.HexToAddress("0x1234")
+	filter := logpoller.Filter{
+		Name:      "persisted",
+		EventSigs: evmtypes.HashArray{event},
+		Addresses: evmtypes.AddressArray{address},
+		Topic2:    evmtypes.HashArray{common.HexToHash("0x5678")},
+		Retention: time.Hour,
+	}
+	require.NoError(t, o.InsertFilter(ctx, filter))
+	require.NoError(t, o.InsertLogsWithBlock(ctx, []logpoller.Log{
+		GenLog(chainID, 1, 10, "0x10", event[:], address),
+		GenLog(chainID, 2, 10, "0x10", event[:], address),
+	}, logpoller.Block{BlockHash: common.HexToHash("0x10"), BlockNumber: 10, BlockTimestamp: time.Now(), FinalizedBlockNumber: 5}))
+
+	t.Run("reloads blocks, logs and filters from the store", func(t *testing.T) {
+		reopened, err := logpoller.NewEmbeddedORM(chainID, db, lggr)
+		require.NoError(t, err)
+
+		block, err := reopened.SelectLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), block.BlockNumber)
+		assert.Equal(t, int64(5), block.FinalizedBlockNumber)
+
+		logs, err := reopened.SelectLogs(ctx, 0, 10, address, event)
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+		assert.Equal(t, int64(1), logs[0].LogIndex)
+		assert.Equal(t, int64(2), logs[1].LogIndex)
+
+		filters, err := reopened.LoadFilters(ctx)
+		require.NoError(t, err)
+		require.Contains(t, filters, filter.Name)
+		assert.Equal(t, filter.Addresses, filters[filter.Name].Addresses)
+		assert.Equal(t, filter.EventSigs, filters[filter.Name].EventSigs)
+		assert.Equal(t, filter.Topic2, filters[filter.Name].Topic2)
+		assert.Equal(t, filter.Retention, filters[filter.Name].Retention)
+	})
+
+	t.Run("chains sharing a store are isolated", func(t *testing.T) {
+		other, err := logpoller.NewEmbeddedORM(testutils.NewRandomEVMChainID(), db, lggr)
+		require.NoError(t, err)
+
+		_, err = other.SelectLatestBlock(ctx)
+		require.Error(t, err)
+		filters, err := other.LoadFilters(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, filters)
+	})
+
+	t.Run("rejected inserts are not persisted", func(t *testing.T) {
+		invalid := GenLog(chainID, -1, 11, "0x11", event[:], address)
+		require.Error(t, o.InsertLogsWithBlock(ctx, []logpoller.Log{invalid}, logpoller.Block{BlockHash: common.HexToHash("0x11"), BlockNumber: 11, BlockTimestamp: time.Now(), FinalizedBlockNumber: 5}))
+
+		reopened, err := logpoller.NewEmbeddedORM(chainID, db, lggr)
+		require.NoError(t, err)
+		block, err := reopened.SelectLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), block.BlockNumber)
+	})
+
+	t.Run("reloads the event ABIs of filters", func(t *testing.T) {
+		parsed, err := abi.JSON(strings.NewReader(`[{"type":"event","name":"Log","inputs":[{"name":"value","type":"uint256","indexed":false}]}]`))
+		require.NoError(t, err)
+		withEvents := logpoller.Filter{
+			Name:      "with events",
+			EventSigs: evmtypes.HashArray{parsed.Events["Log"].ID},
+			Addresses: evmtypes.AddressArray{address},
+			Events:    []abi.Event{parsed.Events["Log"]},
+		}
+		require.NoError(t, o.InsertFilter(ctx, withEvents))
+
+		reopened, err := logpoller.NewEmbeddedORM(chainID, db, lggr)
+		require.NoError(t, err)
+		filters, err := reopened.LoadFilters(ctx)
+		require.NoError(t, err)
+		require.Len(t, filters[withEvents.Name].Events, 1)
+		assert.Equal(t, "Log", filters[withEvents.Name].Events[0].Name)
+		assert.Equal(t, parsed.Events["Log"].ID, filters[withEvents.Name].Events[0].ID)
+		assert.Empty(t, filters[filter.Name].Events)
+
+		withEvents.Events = nil
+		require.NoError(t, reopened.InsertFilter(ctx, withEvents))
+		reopened, err = logpoller.NewEmbeddedORM(chainID, db, lggr)
+		require.NoError(t, err)
+		filters, err = reopened.LoadFilters(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, filters[withEvents.Name].Events)
+	})
+}
@@ -0,0 +1,445 @@
+package logpoller
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+type logPredicate func(l *Log) bool
+
+type logOrder func(a, b *Log) int
+
+// The embeddedDSLParser evaluates the filtering DSL for the EmbeddedORM. Like pgDSLParser, it builds a predicate piece by
+// piece for each Accept function call and resets the error and predicate values after every call.
+type embeddedDSLParser struct {
+	// latest is the latest block of the chain, used to evaluate confirmations. It's nil if there are no blocks.
+	latest *Block
+
+	// transient properties expected to be set and reset with every expression
+	expression logPredicate
+	err        error
+}
+
+var _ primitives.Visitor = (*embeddedDSLParser)(nil)
+
+func (v *embeddedDSLParser) Comparator(_ primitives.Comparator) {
+	v.err = errors.New("comparator filters are not supported by the log poller")
+}
+
+func (v *embeddedDSLParser) Block(p primitives.Block) {
+	block, err := strconv.ParseInt(p.Block, 10, 64)
+	if err != nil {
+		v.err = fmt.Errorf("invalid block number: %s", p.Block)
+
+		return
+	}
+
+	v.expression, v.err = comparePredicate(p.Operator, func(l *Log) int {
+		return cmp.Compare(l.BlockNumber, block)
+	})
+}
+
+func (v *embeddedDSLParser) Confidence(p primitives.Confidence) {
+	switch p.ConfidenceLevel {
+	case primitives.Finalized:
+		// the highest level of confidence maps to finalized
+		v.expression = v.nestedConfPredicate(true, 0)
+	case primitives.Unconfirmed:
+		v.expression = v.nestedConfPredicate(false, 0)
+	default:
+		v.err = errors.New("unrecognized confidence level; use confidence to confirmations mappings instead")
+
+		return
+	}
+}
+
+func (v *embeddedDSLParser) Timestamp(p primitives.Timestamp) {
+	timestamp := time.Unix(int64(p.Timestamp), 0)
+
+	v.expression, v.err = comparePredicate(p.Operator, func(l *Log) int {
+		return l.BlockTimestamp.Compare(timestamp)
+	})
+}
+
+func (v *embeddedDSLParser) TxHash(p primitives.TxHash) {
+	bts, err := hexutil.Decode(p.TxHash)
+	if errors.Is(err, hexutil.ErrMissingPrefix) {
+		bts, err = hexutil.Decode("0x" + p.TxHash)
+	}
+
+	if err != nil {
+		v.err = err
+
+		return
+	}
+
+	txHash := common.BytesToHash(bts)
+
+	v.expression = func(l *Log) bool { return l.TxHash == txHash }
+}
+
+func (v *embeddedDSLParser) VisitAddressFilter(p *addressFilter) {
+	v.expression = func(l *Log) bool { return l.Address == p.address }
+}
+
+func (v *embeddedDSLParser) VisitEventSigFilter(p *eventSigFilter) {
+	v.expression = func(l *Log) bool { return l.EventSig == p.eventSig }
+}
+
+// nestedConfPredicate mirrors pgDSLParser.nestedConfQuery.
+func (v *embeddedDSLParser) nestedConfPredicate(finalized bool, confs uint64) logPredicate {
+	if v.latest == nil {
+		return func(*Log) bool { return false }
+	}
+
+	lastConfirmed := v.latest.FinalizedBlockNumber
+	if !finalized {
+		lastConfirmed = max(v.latest.BlockNumber-int64(confs), 0)
+	}
+
+	return func(l *Log) bool { return l.BlockNumber <= lastConfirmed }
+}
+
+func (v *embeddedDSLParser) VisitEventByWordFilter(p *eventByWordFilter) {
+	if len(p.HashedValueComparers) > 0 {
+		comps := make([]logPredicate, len(p.HashedValueComparers))
+		for idx, comp := range p.HashedValueComparers {
+			comps[idx], v.err = hashedValueCmpToPredicate(comp, func(l *Log) ([]byte, bool) {
+				return dataWord(l, p.WordIndex), true
+			})
+			if v.err != nil {
+				return
+			}
+		}
+
+		v.expression = allOf(comps)
+	}
+}
+
+func (v *embeddedDSLParser) VisitEventTopicsByValueFilter(p *eventByTopicFilter) {
+	if len(p.ValueComparers) == 0 {
+		return
+	}
+
+	if !(p.Topic == 1 || p.Topic == 2 || p.Topic == 3) {
+		v.err = fmt.Errorf("invalid index for topic: %d", p.Topic)
+
+		return
+	}
+
+	comps := make([]logPredicate, len(p.ValueComparers))
+	for idx, comp := range p.ValueComparers {
+		comps[idx], v.err = hashedValueCmpToPredicate(comp, func(l *Log) ([]byte, bool) {
+			return logTopic(l, int(p.Topic))
+		})
+		if v.err != nil {
+			return
+		}
+	}
+
+	v.expression = allOf(comps)
+}
+
+func (v *embeddedDSLParser) VisitEventByFieldFilter(p *eventByFieldFilter) {
+	if p.field == nil {
+		v.err = errUnresolvedEventField
+
+		return
+	}
+
+	if _, err := cmpOpToString(p.Operator); err != nil {
+		v.err = err
+
+		return
+	}
+
+	v.expression = func(l *Log) bool {
+		c, ok := p.field.compare(l, p.encoded)
+		return ok && compareHolds(p.Operator, c)
+	}
+}
+
+func (v *embeddedDSLParser) VisitConfirmationsFilter(p *confirmationsFilter) {
+	switch p.Confirmations {
+	case evmtypes.Finalized:
+		// the highest level of confidence maps to finalized
+		v.expression = v.nestedConfPredicate(true, 0)
+	default:
+		v.expression = v.nestedConfPredicate(false, uint64(p.Confirmations))
+	}
+}
+
+// hashedValueCmpToPredicate matches logs for which the comparison holds for any of the values, like ANY in Postgres.
+func hashedValueCmpToPredicate(comp HashedValueComparator, value func(l *Log) ([]byte, bool)) (logPredicate, error) {
+	if _, err := cmpOpToString(comp.Operator); err != nil {
+		return nil, err
+	}
+
+	return func(l *Log) bool {
+		v, ok := value(l)
+		if !ok {
+			return false
+		}
+
+		return slices.ContainsFunc(comp.Values, func(h common.Hash) bool {
+			return compareHolds(comp.Operator, bytes.Compare(v, h[:]))
+		})
+	}, nil
+}
+
+// comparePredicate returns a predicate which applies op to the result of compare.
+func comparePredicate(op primitives.ComparisonOperator, compare func(l *Log) int) (logPredicate, error) {
+	if _, err := cmpOpToString(op); err != nil {
+		return nil, err
+	}
+
+	return func(l *Log) bool { return compareHolds(op, compare(l)) }, nil
+}
+
+func compareHolds(op primitives.ComparisonOperator, c int) bool {
+	switch op {
+	case primitives.Eq:
+		return c == 0
+	case primitives.Neq:
+		return c != 0
+	case primitives.Gt:
+		return c > 0
+	case primitives.Gte:
+		return c >= 0
+	case primitives.Lt:
+		return c < 0
+	case primitives.Lte:
+		return c <= 0
+	default:
+		return false
+	}
+}
+
+// allOf and anyOf combine predicates. A nil predicate, left by an empty expression, matches all logs.
+func allOf(predicates []logPredicate) logPredicate {
+	return func(l *Log) bool {
+		for _, p := range predicates {
+			if p != nil && !p(l) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func anyOf(predicates []logPredicate) logPredicate {
+	return func(l *Log) bool {
+		for _, p := range predicates {
+			if p == nil || p(l) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func (v *embeddedDSLParser) buildQuery(expressions []query.Expression, limiter query.LimitAndSort) (logPredicate, logOrder, error) {
+	// reset transient properties
+	v.expression = nil
+	v.err = nil
+
+	where, err := v.whereClause(expressions, limiter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order, err := v.orderClause(limiter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return where, order, nil
+}
+
+func (v *embeddedDSLParser) whereClause(expressions []query.Expression, limiter query.LimitAndSort) (logPredicate, error) {
+	segments := make([]logPredicate, 0, 2)
+
+	if len(expressions) > 0 {
+		exp, hasFinalized, err := v.combineExpressions(expressions, query.AND)
+		if err != nil {
+			return nil, err
+		}
+
+		if limiter.HasCursorLimit() && !hasFinalized {
+			return nil, errors.New("cursor-base queries limited to only finalized blocks")
+		}
+
+		segments = append(segments, exp)
+	}
+
+	if limiter.HasCursorLimit() {
+		var compare func(a, b int64) bool
+		switch limiter.Limit.CursorDirection {
+		case query.CursorFollowing:
+			compare = func(a, b int64) bool { return a > b }
+		case query.CursorPrevious:
+			compare = func(a, b int64) bool { return a < b }
+		default:
+			return nil, errors.New("invalid cursor direction")
+		}
+
+		block, logIdx, _, err := valuesFromCursor(limiter.Limit.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		segments = append(segments, func(l *Log) bool {
+			return compare(l.BlockNumber, block) || (l.BlockNumber == block && compare(l.LogIndex, int64(logIdx)))
+		})
+	}
+
+	return allOf(segments), nil
+}
+
+func (v *embeddedDSLParser) orderClause(limiter query.LimitAndSort) (logOrder, error) {
+	sorting := limiter.SortBy
+
+	if limiter.HasCursorLimit() && !limiter.HasSequenceSort() {
+		var dir query.SortDirection
+
+		switch limiter.Limit.CursorDirection {
+		case query.CursorFollowing:
+			dir = query.Asc
+		case query.CursorPrevious:
+			dir = query.Desc
+		default:
+			return nil, errors.New("unexpected cursor direction")
+		}
+
+		sorting = append(sorting, query.NewSortBySequence(dir))
+	}
+
+	if len(sorting) == 0 {
+		// same as defaultSort
+		return func(a, b *Log) int {
+			if c := cmp.Compare(b.BlockNumber, a.BlockNumber); c != 0 {
+				return c
+			}
+
+			return cmp.Compare(b.LogIndex, a.LogIndex)
+		}, nil
+	}
+
+	orders := make([]logOrder, len(sorting))
+
+	for idx, sorted := range sorting {
+		if _, err := orderToString(sorted.GetDirection()); err != nil {
+			return nil, err
+		}
+
+		var order logOrder
+
+		switch sorted.(type) {
+		case query.SortByBlock:
+			order = func(a, b *Log) int { return cmp.Compare(a.BlockNumber, b.BlockNumber) }
+		case query.SortBySequence:
+			order = func(a, b *Log) int {
+				if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+					return c
+				}
+
+				if c := cmp.Compare(a.LogIndex, b.LogIndex); c != 0 {
+					return c
+				}
+
+				return bytes.Compare(a.TxHash[:], b.TxHash[:])
+			}
+		case query.SortByTimestamp:
+			order = func(a, b *Log) int { return a.BlockTimestamp.Compare(b.BlockTimestamp) }
+		default:
+			return nil, errors.New("unexpected sort by")
+		}
+
+		if sorted.GetDirection() == query.Desc {
+			asc := order
+			order = func(a, b *Log) int { return asc(b, a) }
+		}
+
+		orders[idx] = order
+	}
+
+	return func(a, b *Log) int {
+		for _, order := range orders {
+			if c := order(a, b); c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	}, nil
+}
+
+func (v *embeddedDSLParser) getLastExpression() (logPredicate, error) {
+	exp := v.expression
+	err := v.err
+
+	v.expression = nil
+	v.err = nil
+
+	return exp, err
+}
+
+func (v *embeddedDSLParser) combineExpressions(expressions []query.Expression, op query.BoolOperator) (logPredicate, bool, error) {
+	clauses := make([]logPredicate, len(expressions))
+
+	var isFinalized bool
+
+	for idx, exp := range expressions {
+		if exp.IsPrimitive() {
+			exp.Primitive.Accept(v)
+
+			switch prim := exp.Primitive.(type) {
+			case *primitives.Confidence:
+				isFinalized = prim.ConfidenceLevel == primitives.Finalized
+			case *confirmationsFilter:
+				isFinalized = prim.Confirmations == evmtypes.Finalized
+			}
+
+			clause, err := v.getLastExpression()
+			if err != nil {
+				return nil, isFinalized, err
+			}
+
+			clauses[idx] = clause
+		} else {
+			clause, fin, err := v.combineExpressions(exp.BoolExpression.Expressions, exp.BoolExpression.BoolOperator)
+			if err != nil {
+				return nil, isFinalized, err
+			}
+
+			if fin {
+				isFinalized = fin
+			}
+
+			clauses[idx] = clause
+		}
+	}
+
+	switch op {
+	case query.AND:
+		return allOf(clauses), isFinalized, nil
+	case query.OR:
+		return anyOf(clauses), isFinalized, nil
+	default:
+		return nil, isFinalized, fmt.Errorf("invalid boolean operator: %s", op)
+	}
+}
@@ -0,0 +1,466 @@
+package logpoller
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
+)
+
+// eventField is the location of a field of an event in its logs, following the ABI encoding.
+type eventField struct {
+	event *abi.Event
+	arg   abi.Argument
+
+	// topic is the index of the topic of an indexed field, in Log.Topics.
+	topic int
+	// word is the index of the first word of the head of a non-indexed field in Log.Data. The head of a dynamic field is
+	// a single word, holding the offset of its value in Log.Data.
+	word int
+}
+
+// locateEventField returns the location of the field name in the logs of event.
+func locateEventField(event *abi.Event, name string) (*eventField, error) {
+	topic, word := 1, 0
+	for _, arg := range event.Inputs {
+		if arg.Name == name {
+			field := &eventField{event: event, arg: arg, word: word}
+			if arg.Indexed {
+				field.topic = topic
+			}
+			return field, nil
+		}
+		if arg.Indexed {
+			topic++
+		} else {
+			word += headWords(arg.Type)
+		}
+	}
+	return nil, fmt.Errorf("event %s has no field %s", event.Name, name)
+}
+
+// isDynamicType returns whether values of type t are encoded in the tail of the ABI encoding, after an offset in the head.
+func isDynamicType(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy:
+		return true
+	case abi.TupleTy:
+		for _, elem := range t.TupleElems {
+			if isDynamicType(*elem) {
+				return true
+			}
+		}
+	case abi.ArrayTy:
+		return isDynamicType(*t.Elem)
+	}
+	return false
+}
+
+// headWords returns the number of 32 byte words of the head of the ABI encoding of type t.
+func headWords(t abi.Type) int {
+	if isDynamicType(t) {
+		return 1
+	}
+	switch t.T {
+	case abi.TupleTy:
+		words := 0
+		for _, elem := range t.TupleElems {
+			words += headWords(*elem)
+		}
+		return words
+	case abi.ArrayTy:
+		return t.Size * headWords(*t.Elem)
+	}
+	return 1
+}
+
+// isOrdered returns whether the ABI encoding of the values of type t is ordered like the values themselves.
+func isOrdered(t abi.Type) bool {
+	switch t.T {
+	case abi.UintTy, abi.AddressTy, abi.FixedBytesTy, abi.BoolTy:
+		return true
+	}
+	return false
+}
+
+// isHashedTopic returns whether the topic of an indexed field of type t is the hash of its value.
+func isHashedTopic(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	}
+	return false
+}
+
+// encode returns the bytes compared to the field of the logs to apply op to value: the topic of an indexed field, the
+// head of a static field, or the tail of a dynamic field, including its length.
+func (f *eventField) encode(op primitives.ComparisonOperator, value any) ([]byte, error) {
+	if _, err := cmpOpToString(op); err != nil {
+		return nil, err
+	}
+	ordered := isOrdered(f.arg.Type) && (f.arg.Indexed || !isDynamicType(f.arg.Type))
+	if op != primitives.Eq && op != primitives.Neq && !ordered {
+		return nil, fmt.Errorf("field %s of type %s can only be compared for equality", f.arg.Name, f.arg.Type)
+	}
+
+	if f.arg.Indexed && (f.arg.Type.T == abi.StringTy || f.arg.Type.T == abi.BytesTy) {
+		switch v := value.(type) {
+		case string:
+			return crypto.Keccak256([]byte(v)), nil
+		case []byte:
+			return crypto.Keccak256(v), nil
+		default:
+			return nil, fmt.Errorf("value of field %s must be a string or []byte, got %T", f.arg.Name, value)
+		}
+	}
+
+	encoded, err := abi.Arguments{{Type: f.arg.Type}}.Pack(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for field %s of type %s: %w", f.arg.Name, f.arg.Type, err)
+	}
+	switch {
+	case f.arg.Indexed && isHashedTopic(f.arg.Type):
+		if isDynamicType(f.arg.Type) {
+			return nil, fmt.Errorf("indexed field %s of type %s can't be queried", f.arg.Name, f.arg.Type)
+		}
+		// Static arrays and tuples are hashed in place, like their ABI encoding.
+		return crypto.Keccak256(encoded), nil
+	case isDynamicType(f.arg.Type):
+		// Skip the offset of the value.
+		return encoded[32:], nil
+	}
+	return encoded, nil
+}
+
+// logBytes returns the bytes of l compared by the field queries, like pgDSLParser.VisitEventByFieldFilter selects
+// them. Like substring in Postgres, they're truncated or empty if the data is too short.
+func (f *eventField) logBytes(l *Log, size int) ([]byte, bool) {
+	if f.arg.Indexed {
+		return logTopic(l, f.topic)
+	}
+	start := 32 * f.word
+	if isDynamicType(f.arg.Type) {
+		offset := dataSlice(l.Data, start+28, 4)
+		if len(offset) < 4 {
+			return nil, true
+		}
+		start = int(int32(binary.BigEndian.Uint32(offset))) //nolint:gosec // G115, read as an int like in Postgres
+	}
+	return dataSlice(l.Data, start, size), true
+}
+
+func dataSlice(data []byte, start, size int) []byte {
+	end := min(start+size, len(data))
+	start = max(start, 0)
+	if start >= end {
+		return nil
+	}
+	return data[start:end]
+}
+
+// decodeEventLog decodes the fields of the log of event by name. Indexed fields of dynamic types are the hash of
+// their value.
+func decodeEventLog(event *abi.Event, l *Log) (map[string]any, error) {
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	topics := l.GetTopics()
+	if len(topics) != len(indexed)+1 {
+		return nil, fmt.Errorf("log of event %s has %d topics, expected %d", event.Name, len(topics), len(indexed)+1)
+	}
+
+	decoded := make(map[string]any, len(event.Inputs))
+	if err := event.Inputs.UnpackIntoMap(decoded, l.Data); err != nil {
+		return nil, err
+	}
+	if err := abi.ParseTopicsIntoMap(decoded, indexed, topics[1:]); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// validateFilterEvents checks that the event ABI of the filter match its event signatures, and that its indexed fields
+// are fields of these events.
+func validateFilterEvents(filter *Filter) error {
+	for _, event := range filter.Events {
+		if event.Anonymous {
+			return fmt.Errorf("anonymous event %s is not supported", event.Name)
+		}
+		if !containsHash(filter.EventSigs, event.ID) {
+			return fmt.Errorf("event %s is not one of the filter's event sigs", event.Sig)
+		}
+	}
+	_, err := filter.indexedEventFields()
+	return err
+}
+
+// indexedEventFields locates the IndexedFields of the filter in its Events.
+func (filter *Filter) indexedEventFields() ([]*eventField, error) {
+	fields := make([]*eventField, len(filter.IndexedFields))
+	for i, indexed := range filter.IndexedFields {
+		j := slices.IndexFunc(filter.Events, func(e abi.Event) bool { return e.Name == indexed.Event })
+		if j < 0 {
+			return nil, fmt.Errorf("indexed field %s of event %s: the filter has no ABI for the event", indexed.Field, indexed.Event)
+		}
+		field, err := locateEventField(&filter.Events[j], indexed.Field)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(fields[:i], func(f *eventField) bool { return f.event == field.event && f.arg.Name == field.arg.Name }) {
+			return nil, fmt.Errorf("field %s of event %s is indexed twice", indexed.Field, indexed.Event)
+		}
+		fields[i] = field
+	}
+	return fields, nil
+}
+
+// indexesField returns whether the filter indexes the field of the event with signature eventSig.
+func (filter *Filter) indexesField(eventSig common.Hash, field string) bool {
+	event := filter.eventABI(eventSig)
+	return event != nil && slices.Contains(filter.IndexedFields, IndexedField{Event: event.Name, Field: field})
+}
+
+// containsAll returns whether s contains all the elements of elems.
+func containsAll[E comparable](s, elems []E) bool {
+	for _, e := range elems {
+		if !slices.Contains(s, e) {
+			return false
+		}
+	}
+	return true
+}
+
+// savedEventABI is the JSON encoding of an event ABI saved with its filter. Name is the name of the event used by
+// NewEventByFieldFilter, which differs from RawName for overloaded events.
+type savedEventABI struct {
+	Name          string
+	RawName       string
+	Anonymous     bool
+	Inputs        []abi.ArgumentMarshaling
+	IndexedFields []string `json:",omitempty"`
+}
+
+// marshalEventABIs encodes events and the indexed fields of them to be saved with their filter, or returns nil if there
+// are no events.
+func marshalEventABIs(events []abi.Event, indexedFields []IndexedField) ([]byte, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+	saved := make([]savedEventABI, len(events))
+	for i, event := range events {
+		saved[i] = savedEventABI{Name: event.Name, RawName: event.RawName, Anonymous: event.Anonymous}
+		for _, arg := range event.Inputs {
+			saved[i].Inputs = append(saved[i].Inputs, argumentMarshaling(arg.Name, &arg.Type, arg.Indexed))
+		}
+		for _, indexed := range indexedFields {
+			if indexed.Event == event.Name {
+				saved[i].IndexedFields = append(saved[i].IndexedFields, indexed.Field)
+			}
+		}
+	}
+	return json.Marshal(saved)
+}
+
+// argumentMarshaling returns the JSON ABI of an argument of type t, from which abi.NewType creates t again.
+func argumentMarshaling(name string, t *abi.Type, indexed bool) abi.ArgumentMarshaling {
+	m := abi.ArgumentMarshaling{Name: name, Type: t.String(), Indexed: indexed}
+	// The type of tuples, and of arrays of them, is "tuple" followed by the array dimensions, from inner to outer.
+	elem, dims := t, ""
+	for elem.T == abi.SliceTy || elem.T == abi.ArrayTy {
+		if elem.T == abi.SliceTy {
+			dims = "[]" + dims
+		} else {
+			dims = fmt.Sprintf("[%d]", elem.Size) + dims
+		}
+		elem = elem.Elem
+	}
+	if elem.T == abi.TupleTy {
+		m.Type = "tuple" + dims
+		for i, e := range elem.TupleElems {
+			m.Components = append(m.Components, argumentMarshaling(elem.TupleRawNames[i], e, false))
+		}
+	}
+	return m
+}
+
+// unmarshalEventABIs decodes the events and indexed fields encoded by marshalEventABIs.
+func unmarshalEventABIs(b []byte) ([]abi.Event, []IndexedField, error) {
+	if len(b) == 0 {
+		return nil, nil, nil
+	}
+	var saved []savedEventABI
+	if err := json.Unmarshal(b, &saved); err != nil {
+		return nil, nil, err
+	}
+	events := make([]abi.Event, len(saved))
+	var indexedFields []IndexedField
+	for i, e := range saved {
+		inputs := make(abi.Arguments, len(e.Inputs))
+		for j, input := range e.Inputs {
+			t, err := abi.NewType(input.Type, input.InternalType, input.Components)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid type of field %s of event %s: %w", input.Name, e.Name, err)
+			}
+			inputs[j] = abi.Argument{Name: input.Name, Type: t, Indexed: input.Indexed}
+		}
+		events[i] = abi.NewEvent(e.Name, e.RawName, e.Anonymous, inputs)
+		for _, field := range e.IndexedFields {
+			indexedFields = append(indexedFields, IndexedField{Event: e.Name, Field: field})
+		}
+	}
+	return events, indexedFields, nil
+}
+
+// eventABI returns the ABI of the event with signature eventSig, if the filter has it.
+func (filter *Filter) eventABI(eventSig common.Hash) *abi.Event {
+	for i := range filter.Events {
+		if filter.Events[i].ID == eventSig {
+			return &filter.Events[i]
+		}
+	}
+	return nil
+}
+
+// sameEventABI returns whether the logs of a and b are encoded the same way.
+func sameEventABI(a, b *abi.Event) bool {
+	if a.ID != b.ID || len(a.Inputs) != len(b.Inputs) {
+		return false
+	}
+	for i := range a.Inputs {
+		if a.Inputs[i].Name != b.Inputs[i].Name || a.Inputs[i].Indexed != b.Inputs[i].Indexed {
+			return false
+		}
+	}
+	return true
+}
+
+// eventByName returns the ABI of the event name, from the registered filters.
+func (lp *logPoller) eventByName(name string) (*abi.Event, error) {
+	lp.filterMu.RLock()
+	defer lp.filterMu.RUnlock()
+
+	var event *abi.Event
+	for _, filter := range lp.filters {
+		for i := range filter.Events {
+			e := &filter.Events[i]
+			if e.Name != name {
+				continue
+			}
+			if event != nil && !sameEventABI(event, e) {
+				return nil, fmt.Errorf("event %s is ambiguous, registered filters have different ABIs for it", name)
+			}
+			event = e
+		}
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event %s is unknown, no registered filter has its ABI", name)
+	}
+	return event, nil
+}
+
+// resolveEventFields returns the expressions with the event field filters located in the ABI of the registered
+// filters.
+func (lp *logPoller) resolveEventFields(expressions []query.Expression) ([]query.Expression, error) {
+	resolved := make([]query.Expression, len(expressions))
+	for i, exp := range expressions {
+		if !exp.IsPrimitive() {
+			sub, err := lp.resolveEventFields(exp.BoolExpression.Expressions)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = query.Expression{BoolExpression: query.BoolExpression{Expressions: sub, BoolOperator: exp.BoolExpression.BoolOperator}}
+			continue
+		}
+		p, ok := exp.Primitive.(*eventByFieldFilter)
+		if !ok {
+			resolved[i] = exp
+			continue
+		}
+		event, err := lp.eventByName(p.EventName)
+		if err != nil {
+			return nil, err
+		}
+		field, err := locateEventField(event, p.Field)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := field.encode(p.Operator, p.Value)
+		if err != nil {
+			return nil, err
+		}
+		withField := *p
+		withField.field = field
+		withField.encoded = encoded
+		withField.indexed = lp.isFieldIndexed(event.ID, field.arg.Name)
+		resolved[i] = query.Expression{Primitive: &withField}
+	}
+	return resolved, nil
+}
+
+// isFieldIndexed returns whether the field of the event with signature eventSig is indexed in all the logs of the
+// event, that is whether every registered filter with the event indexes it.
+func (lp *logPoller) isFieldIndexed(eventSig common.Hash, field string) bool {
+	lp.filterMu.RLock()
+	defer lp.filterMu.RUnlock()
+
+	indexed := false
+	for _, filter := range lp.filters {
+		if !containsHash(filter.EventSigs, eventSig) {
+			continue
+		}
+		if !filter.indexesField(eventSig, field) {
+			return false
+		}
+		indexed = true
+	}
+	return indexed
+}
+
+// decodeLogs sets the decoded fields of the logs of the events for which a registered filter has the ABI.
+func (lp *logPoller) decodeLogs(logs []Log) {
+	lp.filterMu.RLock()
+	defer lp.filterMu.RUnlock()
+
+	for i := range logs {
+		l := &logs[i]
+		for _, filter := range lp.filters {
+			event := filter.eventABI(l.EventSig)
+			if event == nil || !slices.Contains(filter.Addresses, l.Address) {
+				continue
+			}
+			decoded, err := decodeEventLog(event, l)
+			if err != nil {
+				lp.lggr.Debugw("Unable to decode log", "err", err, "event", event.Name, "blockNumber", l.BlockNumber, "logIndex", l.LogIndex)
+				continue
+			}
+			l.Decoded = decoded
+			break
+		}
+	}
+}
+
+var errUnresolvedEventField = errors.New("event field filters are only supported by LogPoller.FilteredLogs")
+
+// compare compares the bytes of l selected by the field to encoded, and is false if l doesn't have the field.
+func (f *eventField) compare(l *Log, encoded []byte) (int, bool) {
+	if l.EventSig != f.event.ID {
+		return 0, false
+	}
+	v, ok := f.logBytes(l, len(encoded))
+	if !ok {
+		return 0, false
+	}
+	return bytes.Compare(v, encoded), true
+}
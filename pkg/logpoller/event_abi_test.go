@@ -0,0 +1,313 @@
+package logpoller
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client/clienttest"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+const transferABI = `[{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+	{"name":"from","type":"address","indexed":true},
+	{"name":"to","type":"address","indexed":true},
+	{"name":"value","type":"uint256","indexed":false},
+	{"name":"memo","type":"string","indexed":false},
+	{"name":"tag","type":"string","indexed":true},
+	{"name":"amounts","type":"uint64[2]","indexed":false}
+]}]`
+
+func transferLog(t *testing.T, chainID *big.Int, event abi.Event, blockNumber int64, from, to common.Address, value int64, memo, tag string) Log {
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(value), memo, [2]uint64{uint64(value), 1}) //nolint:gosec // G115
+	require.NoError(t, err)
+	return Log{
+		EVMChainID:  ubig.New(chainID),
+		LogIndex:    0,
+		BlockHash:   common.BigToHash(big.NewInt(blockNumber)),
+		BlockNumber: blockNumber,
+		EventSig:    event.ID,
+		Address:     common.HexToAddress("0x1234"),
+		Topics: pq.ByteaArray{
+			event.ID.Bytes(),
+			common.BytesToHash(from.Bytes()).Bytes(),
+			common.BytesToHash(to.Bytes()).Bytes(),
+			crypto.Keccak256([]byte(tag)),
+		},
+		Data: data,
+	}
+}
+
+func TestEventABIs_Marshal(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(transferABI[:len(transferABI)-1] + `,
+		{"type":"event","name":"Order","inputs":[{"name":"id","type":"uint256","indexed":true}]},
+		{"type":"event","name":"Order","inputs":[
+			{"name":"id","type":"uint256","indexed":true},
+			{"name":"legs","type":"tuple[2][]","indexed":false,"components":[
+				{"name":"token","type":"address"},
+				{"name":"amounts","type":"uint256[]"}
+			]}
+		]}]`))
+	require.NoError(t, err)
+	require.Contains(t, parsed.Events, "Order0")
+	events := []abi.Event{parsed.Events["Transfer"], parsed.Events["Order"], parsed.Events["Order0"]}
+
+	indexedFields := []IndexedField{{Event: "Transfer", Field: "value"}, {Event: "Transfer", Field: "memo"}, {Event: "Order0", Field: "id"}}
+	b, err := marshalEventABIs(events, indexedFields)
+	require.NoError(t, err)
+	decoded, decodedFields, err := unmarshalEventABIs(b)
+	require.NoError(t, err)
+	assert.Equal(t, indexedFields, decodedFields)
+	require.Len(t, decoded, len(events))
+	for i := range events {
+		assert.Equal(t, events[i].Name, decoded[i].Name)
+		assert.Equal(t, events[i].RawName, decoded[i].RawName)
+		assert.Equal(t, events[i].ID, decoded[i].ID)
+		assert.Equal(t, events[i].Inputs, decoded[i].Inputs)
+	}
+
+	b, err = marshalEventABIs(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, b)
+	decoded, decodedFields, err = unmarshalEventABIs(nil)
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+	assert.Nil(t, decodedFields)
+}
+
+func TestLogPoller_EventFields(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+	chainID := testutils.NewRandomEVMChainID()
+	lggr := logger.Test(t)
+	orm, err := NewEmbeddedORM(chainID, memorydb.New(), lggr)
+	require.NoError(t, err)
+	lp := NewLogPoller(orm, clienttest.NewClient(t), lggr, nil, Opts{FinalityDepth: 1, BackfillBatchSize: 10, RPCBatchSize: 10})
+
+	parsed, err := abi.JSON(strings.NewReader(transferABI))
+	require.NoError(t, err)
+	event := parsed.Events["Transfer"]
+	alice, bob := common.HexToAddress("0xa11ce"), common.HexToAddress("0xb0b")
+
+	logs := []Log{
+		transferLog(t, chainID, event, 1, alice, bob, 10, "rent", "a"),
+		transferLog(t, chainID, event, 2, bob, alice, 20, "a longer memo which doesn't fit in a single word", "b"),
+		transferLog(t, chainID, event, 3, alice, alice, 30, "rent", "a"),
+	}
+	for _, l := range logs {
+		require.NoError(t, orm.InsertLogsWithBlock(ctx, []Log{l}, Block{BlockHash: l.BlockHash, BlockNumber: l.BlockNumber, BlockTimestamp: time.Now()}))
+	}
+
+	t.Run("requires the ABI of the event", func(t *testing.T) {
+		_, err := lp.FilteredLogs(ctx, []query.Expression{NewEventByFieldFilter("Transfer", "to", primitives.Eq, bob)}, query.LimitAndSort{}, "")
+		require.ErrorContains(t, err, "event Transfer is unknown")
+		_, err = orm.FilteredLogs(ctx, []query.Expression{NewEventByFieldFilter("Transfer", "to", primitives.Eq, bob)}, query.LimitAndSort{}, "")
+		require.ErrorIs(t, err, errUnresolvedEventField)
+	})
+
+	require.ErrorContains(t, lp.RegisterFilter(ctx, Filter{Name: "other event", Addresses: []common.Address{logs[0].Address},
+		EventSigs: []common.Hash{EmitterABI.Events["Log1"].ID}, Events: []abi.Event{event}}), "is not one of the filter's event sigs")
+	for _, indexedFields := range [][]IndexedField{
+		{{Event: "Approval", Field: "value"}},
+		{{Event: "Transfer", Field: "nope"}},
+		{{Event: "Transfer", Field: "value"}, {Event: "Transfer", Field: "value"}},
+	} {
+		require.Error(t, lp.RegisterFilter(ctx, Filter{Name: "invalid indexed fields", Addresses: []common.Address{logs[0].Address},
+			EventSigs: []common.Hash{event.ID}, Events: []abi.Event{event}, IndexedFields: indexedFields}))
+	}
+	require.NoError(t, lp.RegisterFilter(ctx, Filter{Name: "transfers", Addresses: []common.Address{logs[0].Address},
+		EventSigs: []common.Hash{event.ID}, Events: []abi.Event{event}}))
+
+	blockNumbers := func(t *testing.T, field string, op primitives.ComparisonOperator, value any) []int64 {
+		found, err := lp.FilteredLogs(ctx, []query.Expression{NewEventByFieldFilter("Transfer", field, op, value)}, query.NewLimitAndSort(query.Limit{}, query.NewSortBySequence(query.Asc)), "")
+		require.NoError(t, err)
+		var numbers []int64
+		for _, l := range found {
+			require.NotNil(t, l.Decoded)
+			numbers = append(numbers, l.BlockNumber)
+		}
+		return numbers
+	}
+
+	for _, tc := range []struct {
+		name     string
+		field    string
+		op       primitives.ComparisonOperator
+		value    any
+		expected []int64
+	}{
+		{"indexed address", "to", primitives.Eq, bob, []int64{1}},
+		{"indexed address not equal", "from", primitives.Neq, alice, []int64{2}},
+		{"indexed string", "tag", primitives.Eq, "a", []int64{1, 3}},
+		{"word", "value", primitives.Gte, big.NewInt(20), []int64{2, 3}},
+		{"dynamic string", "memo", primitives.Eq, "rent", []int64{1, 3}},
+		{"long dynamic string", "memo", primitives.Eq, "a longer memo which doesn't fit in a single word", []int64{2}},
+		{"prefix of a dynamic string", "memo", primitives.Eq, "ren", nil},
+		{"static array", "amounts", primitives.Eq, [2]uint64{30, 1}, []int64{3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, blockNumbers(t, tc.field, tc.op, tc.value))
+		})
+	}
+
+	t.Run("decodes logs", func(t *testing.T) {
+		found, err := lp.FilteredLogs(ctx, []query.Expression{NewEventByFieldFilter("Transfer", "to", primitives.Eq, bob)}, query.LimitAndSort{}, "")
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, map[string]any{
+			"from":    alice,
+			"to":      bob,
+			"value":   big.NewInt(10),
+			"memo":    "rent",
+			"tag":     common.BytesToHash(crypto.Keccak256([]byte("a"))),
+			"amounts": [2]uint64{10, 1},
+		}, found[0].Decoded)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		for _, exp := range []query.Expression{
+			NewEventByFieldFilter("Transfer", "nope", primitives.Eq, bob),
+			NewEventByFieldFilter("Transfer", "memo", primitives.Gt, "rent"),
+			NewEventByFieldFilter("Transfer", "value", primitives.Eq, "rent"),
+		} {
+			_, err := lp.FilteredLogs(ctx, []query.Expression{exp}, query.LimitAndSort{}, "")
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("indexed fields", func(t *testing.T) {
+		assert.False(t, lp.isFieldIndexed(event.ID, "value"))
+		filter := Filter{Name: "indexed transfers", Addresses: []common.Address{logs[0].Address},
+			EventSigs: []common.Hash{event.ID}, Events: []abi.Event{event}, IndexedFields: []IndexedField{{Event: "Transfer", Field: "value"}}}
+		require.NoError(t, lp.RegisterFilter(ctx, filter))
+		// The logs of the event are also saved for "transfers", which doesn't index the field.
+		assert.False(t, lp.isFieldIndexed(event.ID, "value"))
+		require.NoError(t, lp.UnregisterFilter(ctx, "transfers"))
+		assert.True(t, lp.isFieldIndexed(event.ID, "value"))
+		assert.False(t, lp.isFieldIndexed(event.ID, "memo"))
+
+		filters, err := orm.LoadFilters(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, filter.IndexedFields, filters[filter.Name].IndexedFields)
+		assert.Equal(t, []int64{2, 3}, blockNumbers(t, "value", primitives.Gte, big.NewInt(20)))
+	})
+
+	t.Run("drops logs not matching the ABI", func(t *testing.T) {
+		lp.filterMu.RLock()
+		filter := lp.filters["indexed transfers"]
+		lp.filterMu.RUnlock()
+		assert.True(t, filter.matches(&logs[0]))
+
+		missingTopic := logs[0]
+		missingTopic.Topics = missingTopic.Topics[:3]
+		assert.False(t, filter.matches(&missingTopic))
+		truncated := logs[0]
+		truncated.Data = truncated.Data[:64]
+		assert.False(t, filter.matches(&truncated))
+	})
+}
+
+func TestDSORM_FilterEventsWithoutTable(t *testing.T) {
+	ctx := tests.Context(t)
+	// Without Migrations, evm.log_poller_filter_events doesn't exist.
+	orm := NewORM(testutils.NewRandomEVMChainID(), testutils.NewSqlxDB(t), logger.Test(t))
+	exists, err := orm.hasTable(ctx, "evm.log_poller_filter_events")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	parsed, err := abi.JSON(strings.NewReader(transferABI))
+	require.NoError(t, err)
+	event := parsed.Events["Transfer"]
+	require.NoError(t, orm.InsertFilter(ctx, Filter{Name: "transfers", Addresses: []common.Address{common.HexToAddress("0x1234")},
+		EventSigs: []common.Hash{event.ID}, Events: []abi.Event{event}}))
+
+	filters, err := orm.LoadFilters(ctx)
+	require.NoError(t, err)
+	require.Contains(t, filters, "transfers")
+	assert.Empty(t, filters["transfers"].Events)
+
+	require.NoError(t, orm.DeleteFilter(ctx, "transfers"))
+	filters, err = orm.LoadFilters(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, filters)
+}
+
+func TestDSORM_EventFieldIndex(t *testing.T) {
+	ctx := tests.Context(t)
+	chainID := testutils.NewRandomEVMChainID()
+	lggr := logger.Test(t)
+	db := NewTestSqlxDB(t)
+	orm := NewORM(chainID, db, lggr)
+	lp := NewLogPoller(orm, clienttest.NewClient(t), lggr, nil, Opts{FinalityDepth: 1, BackfillBatchSize: 10, RPCBatchSize: 10})
+
+	parsed, err := abi.JSON(strings.NewReader(transferABI))
+	require.NoError(t, err)
+	event := parsed.Events["Transfer"]
+	alice, bob := common.HexToAddress("0xa11ce"), common.HexToAddress("0xb0b")
+	logs := []Log{
+		transferLog(t, chainID, event, 1, alice, bob, 10, "rent", "a"),
+		transferLog(t, chainID, event, 2, bob, alice, 20, "a longer memo which doesn't fit in a single word", "b"),
+		transferLog(t, chainID, event, 3, alice, alice, 30, "rent", "a"),
+	}
+	insert := func(l Log) {
+		require.NoError(t, orm.InsertLogsWithBlock(ctx, []Log{l}, Block{BlockHash: l.BlockHash, BlockNumber: l.BlockNumber, BlockTimestamp: time.Now()}))
+	}
+	// The logs saved before the filter are indexed when it's registered.
+	insert(logs[0])
+	indexedFields := []IndexedField{{Event: "Transfer", Field: "to"}, {Event: "Transfer", Field: "value"},
+		{Event: "Transfer", Field: "memo"}, {Event: "Transfer", Field: "amounts"}}
+	require.NoError(t, lp.RegisterFilter(ctx, Filter{Name: "transfers", Addresses: []common.Address{logs[0].Address},
+		EventSigs: []common.Hash{event.ID}, Events: []abi.Event{event}, IndexedFields: indexedFields}))
+	insert(logs[1])
+	insert(logs[2])
+
+	var count int
+	require.NoError(t, db.GetContext(ctx, &count, `SELECT count(*) FROM evm.log_poller_event_fields WHERE evm_chain_id = $1`, ubig.New(chainID)))
+	assert.Equal(t, len(logs)*len(indexedFields), count)
+
+	for _, tc := range []struct {
+		field    string
+		op       primitives.ComparisonOperator
+		value    any
+		expected []int64
+	}{
+		{"to", primitives.Eq, bob, []int64{1}},
+		{"value", primitives.Gte, big.NewInt(20), []int64{2, 3}},
+		{"value", primitives.Neq, big.NewInt(20), []int64{1, 3}},
+		{"memo", primitives.Eq, "rent", []int64{1, 3}},
+		{"memo", primitives.Eq, "a longer memo which doesn't fit in a single word", []int64{2}},
+		{"amounts", primitives.Eq, [2]uint64{30, 1}, []int64{3}},
+	} {
+		expressions, err := lp.resolveEventFields([]query.Expression{NewEventByFieldFilter("Transfer", tc.field, tc.op, tc.value)})
+		require.NoError(t, err)
+		require.True(t, expressions[0].Primitive.(*eventByFieldFilter).indexed)
+
+		found, err := lp.FilteredLogs(ctx, expressions, query.NewLimitAndSort(query.Limit{}, query.NewSortBySequence(query.Asc)), "")
+		require.NoError(t, err)
+		var numbers []int64
+		for _, l := range found {
+			numbers = append(numbers, l.BlockNumber)
+		}
+		assert.Equal(t, tc.expected, numbers, "%s %s %v", tc.field, tc.op, tc.value)
+	}
+
+	// The index of the logs removed by a reorg is removed with them.
+	require.NoError(t, orm.DeleteLogsAndBlocksAfter(ctx, 2))
+	require.NoError(t, db.GetContext(ctx, &count, `SELECT count(*) FROM evm.log_poller_event_fields WHERE evm_chain_id = $1`, ubig.New(chainID)))
+	assert.Equal(t, len(indexedFields), count)
+}
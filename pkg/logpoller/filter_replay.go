@@ -0,0 +1,185 @@
+package logpoller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+var ErrFilterReplayInProgress = pkgerrors.New("filter replay already in progress")
+
+// FilterReplayStatus is the progress of the last ReplayFilter call for a filter.
+type FilterReplayStatus struct {
+	FromBlock int64 // first block replayed
+	ToBlock   int64 // last block replayed, the latest block saved when the replay started
+	NextBlock int64 // all the blocks before NextBlock have been replayed
+	Done      bool  // whether the replay has ended, successfully if Err is nil
+	Err       error
+}
+
+// filterReplayRequest asks the main loop to replay the filter name in the unfinalized blocks [from, to].
+type filterReplayRequest struct {
+	name     string
+	status   *FilterReplayStatus
+	plan     *logQueryPlan
+	from, to int64
+	done     chan error
+}
+
+// ReplayFilter fetches the logs of the registered filter name, and only of this filter, from fromBlock up to the
+// latest block saved. It's meant to fetch the history of a filter which has just been registered, without re-fetching
+// the logs of all the other filters like Replay does.
+//
+// The finalized blocks are backfilled outside the LogPoller's main loop, which keeps polling new blocks meanwhile.
+// Only the unfinalized blocks already saved are replayed by the main loop, to avoid concurrent writes during a reorg.
+// ReplayFilter blocks until the replay is complete, and its progress is reported by GetFilterReplayStatus. If ctx is
+// cancelled while the main loop replays the unfinalized blocks, the replay continues and ErrReplayInProgress is
+// returned. Only one replay of a filter can run at a time, ErrFilterReplayInProgress is returned otherwise.
+func (lp *logPoller) ReplayFilter(ctx context.Context, name string, fromBlock int64) (err error) {
+	lp.filterMu.RLock()
+	filter, ok := lp.filters[name]
+	lp.filterMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("filter %s is not registered", name)
+	}
+
+	latest, err := lp.orm.SelectLatestBlock(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Nothing polled yet, the main loop fetches the logs of the filter from its first poll.
+			return nil
+		}
+		return err
+	}
+	if fromBlock < 1 || fromBlock > latest.BlockNumber {
+		return pkgerrors.Errorf("Invalid replay block number %v, acceptable range [1, %v]", fromBlock, latest.BlockNumber)
+	}
+
+	status := &FilterReplayStatus{FromBlock: fromBlock, ToBlock: latest.BlockNumber, NextBlock: fromBlock}
+	lp.filterReplaysMu.Lock()
+	if last, ok := lp.filterReplays[name]; ok && !last.Done {
+		lp.filterReplaysMu.Unlock()
+		return ErrFilterReplayInProgress
+	}
+	lp.filterReplays[name] = status
+	lp.filterReplaysMu.Unlock()
+
+	lggr := lp.lggr.With("filter", name, "fromBlock", fromBlock, "toBlock", latest.BlockNumber)
+	lggr.Infow("Replaying filter")
+	plan := newLogQueryPlan(map[string]Filter{name: filter}, lp.logQueryMaxAddresses)
+
+	if fromBlock <= latest.FinalizedBlockNumber {
+		err = lp.backfillWithProgress(ctx, fromBlock, latest.FinalizedBlockNumber, plan, func(to int64) {
+			lp.updateFilterReplay(status, to+1, false, nil)
+			lggr.Debugw("Filter replay progress", "nextBlock", to+1)
+		})
+		if err != nil {
+			err = lp.replayError(fromBlock, err)
+			lp.updateFilterReplay(status, status.NextBlock, true, err)
+			lggr.Errorw("Filter replay failed", "err", err)
+			return err
+		}
+	}
+
+	req := filterReplayRequest{
+		name:   name,
+		status: status,
+		plan:   plan,
+		from:   max(fromBlock, latest.FinalizedBlockNumber+1),
+		to:     latest.BlockNumber,
+		done:   make(chan error, 1),
+	}
+	if req.from > req.to {
+		lp.updateFilterReplay(status, req.from, true, nil)
+		lggr.Infow("Filter replay complete")
+		return nil
+	}
+	// Block until the main loop accepts the request or cancelled.
+	select {
+	case lp.filterReplayStart <- req:
+	case <-ctx.Done():
+		err = pkgerrors.Wrap(ErrReplayRequestAborted, ctx.Err().Error())
+		lp.updateFilterReplay(status, status.NextBlock, true, err)
+		return err
+	case <-lp.stopCh:
+		lp.updateFilterReplay(status, status.NextBlock, true, ErrLogPollerShutdown)
+		return ErrLogPollerShutdown
+	}
+	// Block until the replay is complete or cancelled.
+	select {
+	case err = <-req.done:
+		return err
+	case <-ctx.Done():
+		// The main loop completes the replay, and reports it in the filter replay status.
+		return ErrReplayInProgress
+	}
+}
+
+// GetFilterReplayStatus returns the progress of the last ReplayFilter call for the registered filter name, if any.
+func (lp *logPoller) GetFilterReplayStatus(name string) (FilterReplayStatus, bool) {
+	lp.filterReplaysMu.RLock()
+	defer lp.filterReplaysMu.RUnlock()
+
+	status, ok := lp.filterReplays[name]
+	if !ok {
+		return FilterReplayStatus{}, false
+	}
+	return *status, true
+}
+
+func (lp *logPoller) updateFilterReplay(status *FilterReplayStatus, next int64, done bool, err error) {
+	lp.filterReplaysMu.Lock()
+	defer lp.filterReplaysMu.Unlock()
+
+	status.NextBlock = next
+	status.Done = done
+	status.Err = err
+}
+
+// forgetFilterReplay drops the replay status of the filter name, once it's unregistered.
+func (lp *logPoller) forgetFilterReplay(name string) {
+	lp.filterReplaysMu.Lock()
+	defer lp.filterReplaysMu.Unlock()
+
+	delete(lp.filterReplays, name)
+}
+
+// handleFilterReplayRequest replays the filter of req in its unfinalized blocks, from the main loop.
+func (lp *logPoller) handleFilterReplayRequest(ctx context.Context, req filterReplayRequest) {
+	err := lp.replayFilterUnfinalized(ctx, req)
+	if err != nil {
+		lp.lggr.Errorw("Filter replay failed", "filter", req.name, "err", err, "fromBlock", req.status.FromBlock, "toBlock", req.to)
+	} else {
+		lp.lggr.Infow("Filter replay complete", "filter", req.name, "fromBlock", req.status.FromBlock, "toBlock", req.to)
+	}
+	lp.updateFilterReplay(req.status, req.status.NextBlock, true, err)
+	req.done <- err
+}
+
+func (lp *logPoller) replayFilterUnfinalized(ctx context.Context, req filterReplayRequest) error {
+	for n := req.from; n <= req.to; n++ {
+		block, err := lp.orm.SelectBlockByNumber(ctx, n)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// The block has been removed by a reorg since the replay started. The blocks replacing it are polled
+				// with the filter registered.
+				break
+			}
+			return err
+		}
+		gethLogs, err := lp.filterLogsWithPlan(ctx, req.plan, nil, nil, &block.BlockHash)
+		if err != nil {
+			return err
+		}
+		logs := lp.limitLogsPerBlock(req.plan, req.plan.matchingLogs(convertLogs(gethLogs, []Block{*block}, lp.lggr, lp.ec.ConfiguredChainID())))
+		if err = lp.orm.InsertLogs(ctx, logs); err != nil {
+			return err
+		}
+		lp.publishLogs(logs)
+		lp.updateFilterReplay(req.status, n+1, false, nil)
+	}
+	return nil
+}
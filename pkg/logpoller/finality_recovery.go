@@ -0,0 +1,189 @@
+package logpoller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// errNoDivergence is returned by the recovery when all the blocks saved are still on the RPC's chain, e.g. when the
+// finality violation was declared by a replay or the backup poller for blocks which aren't saved.
+var errNoDivergence = errors.New("all the blocks saved are on the RPC's chain")
+
+// FinalityViolationIncident describes a finality violation the LogPoller recovered from.
+type FinalityViolationIncident struct {
+	LastValidBlock int64    // last block saved which is still on the RPC's chain
+	LatestBlock    int64    // latest block saved when the recovery started
+	LogsRemoved    int      // logs saved after LastValidBlock which are no longer on the RPC's chain
+	LogsAdded      int      // logs of the RPC's chain saved after LastValidBlock by the recovery
+	ChangedFilters []string // names of the filters matching the logs removed or added, sorted
+}
+
+// recoverFinalityViolation rewinds the blocks and logs saved to the RPC's chain, following a finality violation. It
+// finds the last block saved which is still on the RPC's chain, or on the chain of both the RPC and recoveryClient if
+// set, deletes the blocks and logs after it, and polls them again.
+func (lp *logPoller) recoverFinalityViolation(ctx context.Context) error {
+	latest, err := lp.orm.SelectLatestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	lastValid, err := lp.findLastValidBlock(ctx, latest)
+	if err != nil {
+		return err
+	}
+	start := lastValid.BlockNumber + 1
+	lp.lggr.Warnw("Recovering from finality violation", "lastValidBlock", lastValid.BlockNumber, "lastValidBlockHash", lastValid.BlockHash, "latestBlock", latest.BlockNumber)
+
+	before, err := lp.orm.SelectLogsByBlockRange(ctx, start, latest.BlockNumber)
+	if err != nil {
+		return err
+	}
+	if err = lp.deleteLogsAndBlocksAfter(ctx, start); err != nil {
+		return err
+	}
+	if err = lp.pollAndSaveLogs(ctx, start); err != nil {
+		return fmt.Errorf("failed to poll the blocks after the last valid block %d: %w", lastValid.BlockNumber, err)
+	}
+	newLatest, err := lp.orm.SelectLatestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	after, err := lp.orm.SelectLogsByBlockRange(ctx, start, max(latest.BlockNumber, newLatest.BlockNumber))
+	if err != nil {
+		return err
+	}
+
+	incident := FinalityViolationIncident{LastValidBlock: lastValid.BlockNumber, LatestBlock: latest.BlockNumber}
+	incident.LogsRemoved, incident.LogsAdded, incident.ChangedFilters = lp.changedFilters(before, after)
+	lp.finalityViolated.Store(false)
+	lp.lggr.Warnw("Recovered from finality violation", "lastValidBlock", incident.LastValidBlock, "latestBlock", incident.LatestBlock,
+		"logsRemoved", incident.LogsRemoved, "logsAdded", incident.LogsAdded, "changedFilters", incident.ChangedFilters)
+	if lp.onFinalityViolationRecovered != nil {
+		lp.onFinalityViolationRecovered(incident)
+	}
+	return nil
+}
+
+// findLastValidBlock returns the last block saved up to latest which is still on the RPC's chain. As a block saved is
+// only on the chain if its ancestors are too, it's found with a binary search.
+func (lp *logPoller) findLastValidBlock(ctx context.Context, latest *Block) (*Block, error) {
+	oldest, err := lp.orm.SelectOldestBlock(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := lp.orm.GetBlocksRange(ctx, oldest.BlockNumber, latest.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var searchErr error
+	i := sort.Search(len(blocks), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		valid, err := lp.isBlockOnChain(ctx, blocks[i])
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return !valid
+	})
+	switch {
+	case searchErr != nil:
+		return nil, searchErr
+	case i == len(blocks):
+		return nil, fmt.Errorf("%w up to %d", errNoDivergence, latest.BlockNumber)
+	case i == 0:
+		return nil, pkgerrors.Errorf("oldest block saved %d is not on the RPC's chain, the divergence is deeper than the blocks saved", oldest.BlockNumber)
+	}
+	return &blocks[i-1], nil
+}
+
+// isBlockOnChain returns whether block is on the RPC's chain. If recoveryClient is set, both RPCs must agree.
+func (lp *logPoller) isBlockOnChain(ctx context.Context, block Block) (bool, error) {
+	head, err := lp.latencyMonitor.HeadByNumber(ctx, big.NewInt(block.BlockNumber))
+	if err != nil {
+		return false, err
+	}
+	if head == nil {
+		return false, pkgerrors.Errorf("Got nil block for %d", block.BlockNumber)
+	}
+	if lp.recoveryClient != nil {
+		head2, err := lp.recoveryClient.HeadByNumber(ctx, big.NewInt(block.BlockNumber))
+		if err != nil {
+			return false, err
+		}
+		if head2 == nil {
+			return false, pkgerrors.Errorf("Got nil block for %d from recovery client", block.BlockNumber)
+		}
+		if head.Hash != head2.Hash {
+			return false, pkgerrors.Errorf("RPCs disagree on block %d: hashes %s and %s", block.BlockNumber, head.Hash, head2.Hash)
+		}
+	}
+	return head.Hash == block.BlockHash, nil
+}
+
+// logContent identifies a log by its position and content, regardless of the hash of its block.
+type logContent struct {
+	blockNumber int64
+	logIndex    int64
+	txHash      common.Hash
+	address     common.Address
+	topics      string
+	data        string
+}
+
+func newLogContent(l *Log) logContent {
+	return logContent{
+		blockNumber: l.BlockNumber,
+		logIndex:    l.LogIndex,
+		txHash:      l.TxHash,
+		address:     l.Address,
+		topics:      string(bytes.Join(l.Topics, nil)),
+		data:        string(l.Data),
+	}
+}
+
+// changedFilters compares the logs saved before and after a recovery, and returns the number of logs removed and
+// added, along with the names of the registered filters matching them.
+func (lp *logPoller) changedFilters(before, after []Log) (removed, added int, names []string) {
+	beforeSet := make(map[logContent]struct{}, len(before))
+	for i := range before {
+		beforeSet[newLogContent(&before[i])] = struct{}{}
+	}
+	afterSet := make(map[logContent]struct{}, len(after))
+	for i := range after {
+		afterSet[newLogContent(&after[i])] = struct{}{}
+	}
+
+	var changed []Log
+	for i := range before {
+		if _, ok := afterSet[newLogContent(&before[i])]; !ok {
+			removed++
+			changed = append(changed, before[i])
+		}
+	}
+	for i := range after {
+		if _, ok := beforeSet[newLogContent(&after[i])]; !ok {
+			added++
+			changed = append(changed, after[i])
+		}
+	}
+
+	lp.filterMu.RLock()
+	defer lp.filterMu.RUnlock()
+	for name, filter := range lp.filters {
+		if slices.ContainsFunc(changed, func(l Log) bool { return filter.matches(&l) }) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return removed, added, names
+}
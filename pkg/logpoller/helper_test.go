@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
@@ -89,14 +90,47 @@ type TestHarness struct {
 	EmitterAddress1, EmitterAddress2 common.Address
 }
 
+// ORMBackend creates the ORMs of the two chains of a TestHarness, sharing the same storage.
+type ORMBackend func(t testing.TB, lggr logger.Logger, chainID, chainID2 *big.Int) (logpoller.ORM, logpoller.ORM)
+
+func PostgresBackend(t testing.TB, lggr logger.Logger, chainID, chainID2 *big.Int) (logpoller.ORM, logpoller.ORM) {
+	db := logpoller.NewTestSqlxDB(t)
+	return logpoller.NewORM(chainID, db, lggr), logpoller.NewORM(chainID2, db, lggr)
+}
+
+func EmbeddedBackend(t testing.TB, lggr logger.Logger, chainID, chainID2 *big.Int) (logpoller.ORM, logpoller.ORM) {
+	db := memorydb.New()
+	o, err := logpoller.NewEmbeddedORM(chainID, db, lggr)
+	require.NoError(t, err)
+	o2, err := logpoller.NewEmbeddedORM(chainID2, db, lggr)
+	require.NoError(t, err)
+	return o, o2
+}
+
+// ormBackends are the ORM implementations the ORM tests run against.
+var ormBackends = map[string]ORMBackend{
+	"Postgres": PostgresBackend,
+	"Embedded": EmbeddedBackend,
+}
+
+// forEachORMBackend runs test as a subtest for each of the ormBackends.
+func forEachORMBackend(t *testing.T, test func(t *testing.T, backend ORMBackend)) {
+	for name, backend := range ormBackends {
+		t.Run(name, func(t *testing.T) {
+			test(t, backend)
+		})
+	}
+}
+
 func SetupTH(t testing.TB, opts logpoller.Opts) TestHarness {
+	return SetupTHWithBackend(t, opts, PostgresBackend)
+}
+
+func SetupTHWithBackend(t testing.TB, opts logpoller.Opts, ormBackend ORMBackend) TestHarness {
 	lggr := logger.Test(t)
 	chainID := testutils.NewRandomEVMChainID()
 	chainID2 := testutils.NewRandomEVMChainID()
-	db := testutils.NewSqlxDB(t)
-
-	o := logpoller.NewORM(chainID, db, lggr)
-	o2 := logpoller.NewORM(chainID2, db, lggr)
+	o, o2 := ormBackend(t, lggr, chainID, chainID2)
 	owner := testutils.MustNewSimTransactor(t)
 	// Needed for the new sim if you are using Rollback
 	owner.GasTipCap = big.NewInt(1000000000)
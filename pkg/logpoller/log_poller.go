@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand/v2"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -30,7 +32,6 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/mathutil"
 
-	"github.com/smartcontractkit/chainlink-evm/pkg/client"
 	"github.com/smartcontractkit/chainlink-evm/pkg/config"
 	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
 	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
@@ -41,6 +42,8 @@ type LogPoller interface {
 	Healthy() error
 	Replay(ctx context.Context, fromBlock int64) error
 	ReplayAsync(fromBlock int64)
+	ReplayFilter(ctx context.Context, name string, fromBlock int64) error
+	GetFilterReplayStatus(name string) (FilterReplayStatus, bool)
 	RegisterFilter(ctx context.Context, filter Filter) error
 	UnregisterFilter(ctx context.Context, name string) error
 	HasFilter(name string) bool
@@ -72,6 +75,10 @@ type LogPoller interface {
 
 	// chainlink-common query filtering
 	FilteredLogs(ctx context.Context, filter []query.Expression, limitAndSort query.LimitAndSort, queryName string) ([]Log, error)
+
+	// Push based querying
+	Subscribe(ctx context.Context, filterName string, fromBlock int64) (*Subscription, error)
+	SubscribeFromCursor(ctx context.Context, filterName string, cursor string) (*Subscription, error)
 }
 
 type LogPollerTest interface {
@@ -115,29 +122,49 @@ type logPoller struct {
 	finalityDepth            int64         // finality depth is taken to mean that block (head - finality) is finalized. If `useFinalityTag` is set to true, this value is ignored, because finalityDepth is fetched from chain
 	keepFinalizedBlocksDepth int64         // the number of blocks behind the last finalized block we keep in database
 	backfillBatchSize        int64         // batch size to use when backfilling finalized logs
+	backfillConcurrency      int           // number of block ranges fetched in parallel when backfilling finalized logs
 	rpcBatchSize             int64         // batch size to use for fallback RPC calls made in GetBlocks
 	logPrunePageSize         int64
 	clientErrors             config.ClientErrors
 	backupPollerNextBlock    int64 // next block to be processed by Backup LogPoller
 	backupPollerBlockDelay   int64 // how far behind regular LogPoller should BackupLogPoller run. 0 = disabled
 
+	finalityViolationRecovery    bool   // whether to recover from finality violations automatically
+	recoveryClient               Client // optional second RPC confirming the chain found by the recovery
+	onFinalityViolationRecovered func(FinalityViolationIncident)
+
 	filterMu        sync.RWMutex
 	filters         map[string]Filter
 	filterDirty     bool
 	cachedAddresses []common.Address
 	cachedEventSigs []common.Hash
 
+	logQueryMaxAddresses int
+	queryPlanDirty       bool
+	cachedQueryPlan      *logQueryPlan
+
+	subscriptionsMu sync.RWMutex
+	subscriptions   map[*Subscription]struct{}
+
+	filterReplayStart chan filterReplayRequest
+	filterReplaysMu   sync.RWMutex
+	filterReplays     map[string]*FilterReplayStatus // status of the last ReplayFilter call, by filter name
+
 	replayStart    chan int64
 	replayComplete chan error
 	stopCh         services.StopChan
 	wg             sync.WaitGroup
 	// This flag is raised whenever the log poller detects that the chain's finality has been violated.
 	// It can happen when reorg is deeper than the latest finalized block that LogPoller saw in a previous PollAndSave tick.
-	// Usually the only way to recover is to manually remove the offending logs and block from the database.
+	// Usually the only way to recover is to manually remove the offending logs and block from the database, unless
+	// finalityViolationRecovery is enabled, in which case the next poll does it.
 	// LogPoller keeps running in infinite loop, so whenever the invalid state is removed from the database it should
 	// recover automatically without needing to restart the LogPoller.
 	finalityViolated           atomic.Bool
 	countBasedLogPruningActive atomic.Bool
+
+	logStream       *logStream    // nil unless StreamLogs is enabled
+	logStreamNotify chan struct{} // notifies the main loop of new blocks with streamed logs
 }
 
 type Opts struct {
@@ -150,6 +177,23 @@ type Opts struct {
 	BackupPollerBlockDelay   int64
 	LogPrunePageSize         int64
 	ClientErrors             config.ClientErrors
+	LogQueryMaxAddresses     int // maximum number of addresses in a single eth_getLogs query, 0 = unlimited
+	BackfillConcurrency      int // number of block ranges fetched in parallel when backfilling, defaults to 1
+
+	// RecoverFinalityViolation enables the automatic recovery from finality violations: the blocks and logs saved
+	// after the last block still on the RPC's chain are deleted and polled again.
+	RecoverFinalityViolation bool
+	// RecoveryClient is an optional second RPC, which must agree with the first one on the blocks checked by the
+	// recovery.
+	RecoveryClient Client
+	// OnFinalityViolationRecovered is called with each incident the LogPoller recovered from.
+	OnFinalityViolationRecovered func(FinalityViolationIncident)
+
+	// StreamLogs enables the subscription to the logs of the registered filters, if the client supports it. The logs
+	// streamed are saved as soon as their block is polled, instead of fetching them with eth_getLogs. Polling falls
+	// back to eth_getLogs while the subscription is down. As the subscription may miss logs without failing, the
+	// backup poller should stay enabled to reconcile the finalized blocks.
+	StreamLogs bool
 }
 
 // NewLogPoller creates a log poller. Note there is an assumption
@@ -164,25 +208,35 @@ type Opts struct {
 // support chain, polygon, which has 2s block times, we need RPCs roughly with <= 500ms latency
 func NewLogPoller(orm ORM, ec Client, lggr logger.Logger, headTracker HeadTracker, opts Opts) *logPoller {
 	return &logPoller{
-		stopCh:                   make(chan struct{}),
-		ec:                       ec,
-		orm:                      orm,
-		headTracker:              headTracker,
-		latencyMonitor:           NewLatencyMonitor(ec, lggr, opts.PollPeriod),
-		lggr:                     logger.Sugared(logger.Named(lggr, "LogPoller")),
-		replayStart:              make(chan int64),
-		replayComplete:           make(chan error),
-		pollPeriod:               opts.PollPeriod,
-		backupPollerBlockDelay:   opts.BackupPollerBlockDelay,
-		finalityDepth:            opts.FinalityDepth,
-		useFinalityTag:           opts.UseFinalityTag,
-		backfillBatchSize:        opts.BackfillBatchSize,
-		rpcBatchSize:             opts.RPCBatchSize,
-		keepFinalizedBlocksDepth: opts.KeepFinalizedBlocksDepth,
-		logPrunePageSize:         opts.LogPrunePageSize,
-		clientErrors:             opts.ClientErrors,
-		filters:                  make(map[string]Filter),
-		filterDirty:              true, // Always build Filter on first call to cache an empty filter if nothing registered yet.
+		stopCh:                       make(chan struct{}),
+		ec:                           ec,
+		orm:                          orm,
+		headTracker:                  headTracker,
+		latencyMonitor:               NewLatencyMonitor(ec, lggr, opts.PollPeriod),
+		lggr:                         logger.Sugared(logger.Named(lggr, "LogPoller")),
+		replayStart:                  make(chan int64),
+		replayComplete:               make(chan error),
+		filterReplayStart:            make(chan filterReplayRequest),
+		filterReplays:                make(map[string]*FilterReplayStatus),
+		pollPeriod:                   opts.PollPeriod,
+		backupPollerBlockDelay:       opts.BackupPollerBlockDelay,
+		finalityDepth:                opts.FinalityDepth,
+		useFinalityTag:               opts.UseFinalityTag,
+		backfillBatchSize:            opts.BackfillBatchSize,
+		backfillConcurrency:          max(opts.BackfillConcurrency, 1),
+		rpcBatchSize:                 opts.RPCBatchSize,
+		keepFinalizedBlocksDepth:     opts.KeepFinalizedBlocksDepth,
+		logPrunePageSize:             opts.LogPrunePageSize,
+		clientErrors:                 opts.ClientErrors,
+		logQueryMaxAddresses:         opts.LogQueryMaxAddresses,
+		filters:                      make(map[string]Filter),
+		filterDirty:                  true, // Always build Filter on first call to cache an empty filter if nothing registered yet.
+		subscriptions:                make(map[*Subscription]struct{}),
+		finalityViolationRecovery:    opts.RecoverFinalityViolation,
+		recoveryClient:               opts.RecoveryClient,
+		onFinalityViolationRecovered: opts.OnFinalityViolationRecovered,
+		logStream:                    newLogStream(opts.StreamLogs, ec),
+		logStreamNotify:              make(chan struct{}, 1),
 	}
 }
 
@@ -196,6 +250,20 @@ type Filter struct {
 	Retention    time.Duration      // maximum amount of time to retain logs
 	MaxLogsKept  uint64             // maximum number of logs to retain ( 0 = unlimited )
 	LogsPerBlock uint64             // rate limit ( maximum # of logs per block, 0 = unlimited )
+
+	// Events are the optional ABI of the events of EventSigs. Logs of these events which don't match their ABI are
+	// dropped, the others are returned decoded by FilteredLogs, which can query their fields by name with
+	// NewEventByFieldFilter. Events are saved with the filter, and replaced by those of the latest registration of it.
+	Events []abi.Event
+	// IndexedFields are the fields of Events which DSORM indexes in the logs of the filter, so that
+	// NewEventByFieldFilter queries of them don't scan the logs.
+	IndexedFields []IndexedField
+}
+
+// IndexedField is a field of one of the Events of a Filter, by name like in NewEventByFieldFilter.
+type IndexedField struct {
+	Event string
+	Field string
 }
 
 // FilterName is a suggested convenience function for clients to construct unique filter names
@@ -226,6 +294,12 @@ func (filter *Filter) Contains(other *Filter) bool {
 	if other.MaxLogsKept != filter.MaxLogsKept {
 		return false
 	}
+	if !slices.EqualFunc(filter.Events, other.Events, func(a, b abi.Event) bool { return sameEventABI(&a, &b) }) {
+		return false
+	}
+	if len(filter.IndexedFields) != len(other.IndexedFields) || !containsAll(filter.IndexedFields, other.IndexedFields) {
+		return false
+	}
 	addresses := make(map[common.Address]interface{})
 	for _, addr := range filter.Addresses {
 		addresses[addr] = struct{}{}
@@ -245,6 +319,21 @@ func (filter *Filter) Contains(other *Filter) bool {
 			return false
 		}
 	}
+	// An empty list of topic values matches any value, so it only contains an empty list.
+	for i, topics := range []evmtypes.HashArray{filter.Topic2, filter.Topic3, filter.Topic4} {
+		otherTopics := []evmtypes.HashArray{other.Topic2, other.Topic3, other.Topic4}[i]
+		if len(topics) == 0 {
+			continue
+		}
+		if len(otherTopics) == 0 {
+			return false
+		}
+		for _, topic := range otherTopics {
+			if !containsHash(topics, topic) {
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -253,13 +342,13 @@ func (filter *Filter) Contains(other *Filter) bool {
 // If an event matching any of the given event signatures is emitted from any of the provided Addresses,
 // the log poller will pick those up and save them. For topic specific queries see content based querying.
 // Clients may choose to MergeFilter and then Replay in order to ensure desired logs are present.
-// NOTE: due to constraints of the eth filter, there is "leakage" between successive MergeFilter calls, for example
+// The Topic2-4 values of the filter, if any, are sent to the RPC as well. Filters are grouped into a query plan
+// (see logQueryPlan) such that, for example
 //
 //	RegisterFilter(event1, addr1)
 //	RegisterFilter(event2, addr2)
 //
-// will result in the poller saving (event1, addr2) or (event2, addr1) as well, should it exist.
-// Generally speaking this is harmless. We enforce that EventSigs and Addresses are non-empty,
+// doesn't result in the poller saving (event1, addr2) or (event2, addr1). We enforce that EventSigs and Addresses are non-empty,
 // which means that anonymous events are not supported and log.Topics >= 1 always (log.Topics[0] is the event signature).
 // The filter may be unregistered later by Filter.Name
 // Warnings/debug information is keyed by filter name.
@@ -281,6 +370,9 @@ func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
 			return pkgerrors.Errorf("empty address")
 		}
 	}
+	if err := validateFilterEvents(&filter); err != nil {
+		return err
+	}
 
 	lp.filterMu.Lock()
 	defer lp.filterMu.Unlock()
@@ -299,7 +391,9 @@ func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
 	}
 	lp.filters[filter.Name] = filter
 	lp.filterDirty = true
-	if filter.MaxLogsKept > 0 {
+	lp.queryPlanDirty = true
+	lp.updateSubscriptions(filter.Name, &filter)
+	if filter.MaxLogsKept > 0 || filter.LogsPerBlock > 0 {
 		lp.countBasedLogPruningActive.Store(true)
 	}
 	return nil
@@ -323,6 +417,9 @@ func (lp *logPoller) UnregisterFilter(ctx context.Context, name string) error {
 	}
 	delete(lp.filters, name)
 	lp.filterDirty = true
+	lp.queryPlanDirty = true
+	lp.updateSubscriptions(name, nil)
+	lp.forgetFilterReplay(name)
 	return nil
 }
 
@@ -418,15 +515,7 @@ func (lp *logPoller) Filter(from, to *big.Int, bh *common.Hash) ethereum.FilterQ
 // guarantee that the replay is complete before proceeding, it should either avoid cancelling or retry until nil is returned
 func (lp *logPoller) Replay(ctx context.Context, fromBlock int64) (err error) {
 	defer func() {
-		if errors.Is(err, context.Canceled) {
-			err = ErrReplayRequestAborted
-		} else if errors.Is(err, commontypes.ErrFinalityViolated) {
-			// Replay only declares finality violation and does not resolve it, as it's possible that [fromBlock, savedFinalizedBlockNumber]
-			// does not contain the violation.
-			lp.lggr.Criticalw("Replay failed due to finality violation", "fromBlock", fromBlock, "err", err)
-			lp.finalityViolated.Store(true)
-			lp.SvcErrBuffer.Append(err)
-		}
+		err = lp.replayError(fromBlock, err)
 	}()
 
 	lp.lggr.Debugf("Replaying from block %d", fromBlock)
@@ -445,7 +534,7 @@ func (lp *logPoller) Replay(ctx context.Context, fromBlock int64) (err error) {
 		return err
 	}
 	if fromBlock <= savedFinalizedBlockNumber {
-		err = lp.backfill(ctx, fromBlock, savedFinalizedBlockNumber)
+		err = lp.replayBackfill(ctx, fromBlock, savedFinalizedBlockNumber)
 		if err != nil {
 			return err
 		}
@@ -476,6 +565,20 @@ func (lp *logPoller) Replay(ctx context.Context, fromBlock int64) (err error) {
 	}
 }
 
+// replayError returns the error of a replay from fromBlock, declaring a finality violation if that's the cause.
+func (lp *logPoller) replayError(fromBlock int64, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return ErrReplayRequestAborted
+	} else if errors.Is(err, commontypes.ErrFinalityViolated) {
+		// Replay only declares finality violation and does not resolve it, as it's possible that [fromBlock, savedFinalizedBlockNumber]
+		// does not contain the violation.
+		lp.lggr.Criticalw("Replay failed due to finality violation", "fromBlock", fromBlock, "err", err)
+		lp.finalityViolated.Store(true)
+		lp.SvcErrBuffer.Append(err)
+	}
+	return err
+}
+
 // savedFinalizedBlockNumber returns the FinalizedBlockNumber saved with the last processed block in the db
 // (latestFinalizedBlock at the time the last processed block was saved)
 // If this is the first poll and no blocks are in the db, it returns 0
@@ -516,6 +619,13 @@ func (lp *logPoller) Start(context.Context) error {
 		lp.wg.Add(2)
 		go lp.run()
 		go lp.backgroundWorkerRun()
+		if lp.logStream != nil {
+			if lp.backupPollerBlockDelay == 0 {
+				lp.lggr.Warn("Log streaming is enabled without the backup poller, logs missed by the subscription won't be recovered")
+			}
+			lp.wg.Add(1)
+			go lp.runLogStream()
+		}
 		return nil
 	})
 }
@@ -574,7 +684,7 @@ func (lp *logPoller) loadFilters(ctx context.Context) error {
 		return nil
 	}
 	for _, filter := range filters {
-		if filter.MaxLogsKept != 0 {
+		if filter.MaxLogsKept != 0 || filter.LogsPerBlock != 0 {
 			lp.countBasedLogPruningActive.Store(true)
 			return nil
 		}
@@ -594,6 +704,7 @@ func (lp *logPoller) lockAndLoadFilters(ctx context.Context) (filters map[string
 
 	lp.filters = filters
 	lp.filterDirty = true
+	lp.queryPlanDirty = true
 	return filters, nil
 }
 
@@ -626,37 +737,13 @@ func (lp *logPoller) run() {
 			return
 		case fromBlockReq := <-lp.replayStart:
 			lp.handleReplayRequest(ctx, fromBlockReq, filtersLoaded)
+		case req := <-lp.filterReplayStart:
+			lp.handleFilterReplayRequest(ctx, req)
 		case <-logPollTicker.C:
-			if !filtersLoaded {
-				if err := lp.loadFilters(ctx); err != nil {
-					lp.lggr.Errorw("Failed loading filters in main logpoller loop, retrying later", "err", err)
-					continue
-				}
-				filtersLoaded = true
-			}
-
-			// Always start from the latest block in the db.
-			var start int64
-			lastProcessed, err := lp.orm.SelectLatestBlock(ctx)
-			if err != nil {
-				if !pkgerrors.Is(err, sql.ErrNoRows) {
-					// Assume transient db reading issue, retry forever.
-					lp.lggr.Errorw("unable to get starting block", "err", err)
-					continue
-				}
-				// Otherwise this is the first poll _ever_ on a new chain.
-				// Only safe thing to do is to start at the first finalized block.
-				_, latestFinalizedBlockNumber, err := lp.latestBlocks(ctx)
-				if err != nil {
-					lp.lggr.Warnw("Unable to get latest for first poll", "err", err)
-					continue
-				}
-				// Starting at the first finalized block. We do not backfill the first finalized block.
-				start = latestFinalizedBlockNumber
-			} else {
-				start = lastProcessed.BlockNumber + 1
-			}
-			lp.PollAndSaveLogs(ctx, start)
+			lp.pollFromLatestBlock(ctx, &filtersLoaded)
+		case <-lp.logStreamNotify:
+			// Logs of a new block were streamed, poll it without waiting for the ticker.
+			lp.pollFromLatestBlock(ctx, &filtersLoaded)
 		case <-backupLogPollTicker.C:
 			if lp.backupPollerBlockDelay == 0 {
 				continue // backup poller is disabled
@@ -688,6 +775,40 @@ func (lp *logPoller) run() {
 	}
 }
 
+// pollFromLatestBlock polls the blocks after the latest block saved, loading the filters first if needed.
+func (lp *logPoller) pollFromLatestBlock(ctx context.Context, filtersLoaded *bool) {
+	if !*filtersLoaded {
+		if err := lp.loadFilters(ctx); err != nil {
+			lp.lggr.Errorw("Failed loading filters in main logpoller loop, retrying later", "err", err)
+			return
+		}
+		*filtersLoaded = true
+	}
+
+	// Always start from the latest block in the db.
+	var start int64
+	lastProcessed, err := lp.orm.SelectLatestBlock(ctx)
+	if err != nil {
+		if !pkgerrors.Is(err, sql.ErrNoRows) {
+			// Assume transient db reading issue, retry forever.
+			lp.lggr.Errorw("unable to get starting block", "err", err)
+			return
+		}
+		// Otherwise this is the first poll _ever_ on a new chain.
+		// Only safe thing to do is to start at the first finalized block.
+		_, latestFinalizedBlockNumber, err := lp.latestBlocks(ctx)
+		if err != nil {
+			lp.lggr.Warnw("Unable to get latest for first poll", "err", err)
+			return
+		}
+		// Starting at the first finalized block. We do not backfill the first finalized block.
+		start = latestFinalizedBlockNumber
+	} else {
+		start = lastProcessed.BlockNumber + 1
+	}
+	lp.PollAndSaveLogs(ctx, start)
+}
+
 func (lp *logPoller) backgroundWorkerRun() {
 	defer lp.wg.Done()
 	ctx, cancel := lp.stopCh.NewCtx()
@@ -892,53 +1013,6 @@ func (lp *logPoller) blocksFromFinalizedLogs(ctx context.Context, logs []types.L
 	return blocks, nil
 }
 
-// backfill will query FilterLogs in batches for logs in the
-// block range [start, end] and save them to the db.
-func (lp *logPoller) backfill(ctx context.Context, start, end int64) error {
-	batchSize := lp.backfillBatchSize
-	for from := start; from <= end; from += batchSize {
-		to := mathutil.Min(from+batchSize-1, end)
-
-		gethLogs, err := lp.latencyMonitor.FilterLogs(ctx, lp.Filter(big.NewInt(from), big.NewInt(to), nil))
-		if err != nil {
-			if !client.IsTooManyResults(err, lp.clientErrors) {
-				lp.lggr.Errorw("Unable to query for logs", "err", err, "from", from, "to", to)
-				return err
-			}
-
-			if batchSize == 1 {
-				lp.lggr.Criticalw("Too many log results in a single block, failed to retrieve logs! Node may be running in a degraded state.", "err", err, "from", from, "to", to, "LogBackfillBatchSize", lp.backfillBatchSize)
-				return err
-			}
-			batchSize /= 2
-			lp.lggr.Warnw("Too many log results, halving block range batch size.  Consider increasing LogBackfillBatchSize if this happens frequently", "err", err, "from", from, "to", to, "newBatchSize", batchSize, "LogBackfillBatchSize", lp.backfillBatchSize)
-			from -= batchSize // counteract +=batchSize on next loop iteration, so starting block does not change
-			continue
-		}
-		if len(gethLogs) == 0 {
-			continue
-		}
-		blocks, err := lp.blocksFromFinalizedLogs(ctx, gethLogs, uint64(to)) //nolint:gosec // G115
-		if err != nil {
-			return err
-		}
-
-		endblock := blocks[len(blocks)-1]
-		if gethLogs[len(gethLogs)-1].BlockNumber != uint64(to) {
-			// Pop endblock if there were no logs for it, so that length of blocks & gethLogs are the same to pass to convertLogs
-			blocks = blocks[:len(blocks)-1]
-		}
-
-		lp.lggr.Debugw("Backfill found logs", "from", from, "to", to, "logs", len(gethLogs), "blocks", blocks)
-		err = lp.orm.InsertLogsWithBlock(ctx, convertLogs(gethLogs, blocks, lp.lggr, lp.ec.ConfiguredChainID()), endblock)
-		if err != nil {
-			lp.lggr.Warnw("Unable to insert logs, retrying", "err", err, "from", from, "to", to)
-			return err
-		}
-	}
-	return nil
-}
-
 // getCurrentBlockMaybeHandleReorg accepts a block number
 // and will return that block if its parent points to our last saved block.
 // One can optionally pass the block header if it has already been queried to avoid an extra RPC call.
@@ -998,7 +1072,7 @@ func (lp *logPoller) getCurrentBlockMaybeHandleReorg(ctx context.Context, curren
 		// the canonical set per read. Typically, if an application took action on a log
 		// it would be saved elsewhere e.g. evm.txes, so it seems better to just support the fast reads.
 		// Its also nicely analogous to reading from the chain itself.
-		err2 = lp.orm.DeleteLogsAndBlocksAfter(ctx, blockAfterLCA.Number)
+		err2 = lp.deleteLogsAndBlocksAfter(ctx, blockAfterLCA.Number)
 		if err2 != nil {
 			// If we error on db commit, we can't know if the tx went through or not.
 			// We return an error here which will cause us to restart polling from lastBlockSaved + 1
@@ -1014,6 +1088,19 @@ func (lp *logPoller) getCurrentBlockMaybeHandleReorg(ctx context.Context, curren
 // currentBlockNumber is the block from where new logs are to be polled & saved. Under normal
 // conditions this would be equal to lastProcessed.BlockNumber + 1.
 func (lp *logPoller) PollAndSaveLogs(ctx context.Context, currentBlockNumber int64) {
+	if lp.finalityViolationRecovery && lp.finalityViolated.Load() {
+		// The recovery polls up to the latest block, the next poll resumes from there.
+		err := lp.recoverFinalityViolation(ctx)
+		if !errors.Is(err, errNoDivergence) {
+			if err != nil {
+				lp.lggr.Criticalw("Failed to recover from finality violation, retrying later", "err", err)
+			}
+			return
+		}
+		// Nothing to rewind, polling clears the flag unless the violation is hit again.
+		lp.lggr.Warnw("No finality violation found in the blocks saved, polling", "err", err)
+	}
+
 	err := lp.pollAndSaveLogs(ctx, currentBlockNumber)
 	if errors.Is(err, commontypes.ErrFinalityViolated) {
 		lp.lggr.Criticalw("Failed to poll and save logs due to finality violation, retrying later", "err", err)
@@ -1093,7 +1180,8 @@ func (lp *logPoller) pollAndSaveLogs(ctx context.Context, currentBlockNumber int
 
 		h := currentBlock.Hash
 		var logs []types.Log
-		logs, err = lp.latencyMonitor.FilterLogs(ctx, lp.Filter(nil, nil, &h))
+		var plan *logQueryPlan
+		logs, plan, err = lp.blockLogs(ctx, currentBlock)
 		if err != nil {
 			lp.lggr.Warnw("Unable to query for logs, retrying", "err", err, "block", currentBlockNumber)
 			return nil
@@ -1105,15 +1193,13 @@ func (lp *logPoller) pollAndSaveLogs(ctx context.Context, currentBlockNumber int
 			BlockTimestamp:       currentBlock.Timestamp,
 			FinalizedBlockNumber: latestFinalizedBlockNumber,
 		}
-		err = lp.orm.InsertLogsWithBlock(
-			ctx,
-			convertLogs(logs, []Block{block}, lp.lggr, lp.ec.ConfiguredChainID()),
-			block,
-		)
+		lgs := lp.dropUnmatchedLogs(plan, convertLogs(logs, []Block{block}, lp.lggr, lp.ec.ConfiguredChainID()))
+		err = lp.orm.InsertLogsWithBlock(ctx, lgs, block)
 		if err != nil {
 			lp.lggr.Warnw("Unable to save logs resuming from last saved block + 1", "err", err, "block", currentBlockNumber)
 			return nil
 		}
+		lp.publishLogs(lgs)
 		// Update current block.
 		// Same reorg detection on unfinalized blocks.
 		currentBlockNumber++
@@ -1620,7 +1706,7 @@ func (lp *logPoller) IndexedLogsWithSigsExcluding(ctx context.Context, address c
 
 // DeleteLogsAndBlocksAfter - removes blocks and logs starting from the specified block
 func (lp *logPoller) DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error {
-	return lp.orm.DeleteLogsAndBlocksAfter(ctx, start)
+	return lp.deleteLogsAndBlocksAfter(ctx, start)
 }
 
 func (lp *logPoller) FindLCA(ctx context.Context) (*Block, error) {
@@ -1722,7 +1808,16 @@ func EvmWord(i uint64) common.Hash {
 }
 
 func (lp *logPoller) FilteredLogs(ctx context.Context, queryFilter []query.Expression, limitAndSort query.LimitAndSort, queryName string) ([]Log, error) {
-	return lp.orm.FilteredLogs(ctx, queryFilter, limitAndSort, queryName)
+	queryFilter, err := lp.resolveEventFields(queryFilter)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := lp.orm.FilteredLogs(ctx, queryFilter, limitAndSort, queryName)
+	if err != nil {
+		return nil, err
+	}
+	lp.decodeLogs(logs)
+	return logs, nil
 }
 
 // Where is a query.Where wrapper that ignores the Key and returns a slice of query.Expression rather than query.KeyFilter.
@@ -2,8 +2,10 @@ package logpoller
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 	"sync"
@@ -11,10 +13,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/rpc"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -63,7 +68,7 @@ func TestLogPoller_RegisterFilter(t *testing.T) {
 
 	lggr, observedLogs := logger.TestObserved(t, zapcore.WarnLevel)
 	chainID := testutils.NewRandomEVMChainID()
-	db := testutils.NewSqlxDB(t)
+	db := NewTestSqlxDB(t)
 	ctx := testutils.Context(t)
 
 	orm := NewORM(chainID, db, lggr)
@@ -203,11 +208,271 @@ func TestFilterName(t *testing.T) {
 	assert.Equal(t, "empty args test", FilterName("empty args test"))
 }
 
+func TestLogQueryPlan(t *testing.T) {
+	t.Parallel()
+	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
+	a2 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	a3 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbd")
+	log1 := EmitterABI.Events["Log1"].ID
+	log2 := EmitterABI.Events["Log2"].ID
+	topic := common.HexToHash("0x1234")
+
+	t.Run("no filters", func(t *testing.T) {
+		plan := newLogQueryPlan(nil, 0)
+		require.Len(t, plan.queries, 1)
+		assert.Equal(t, []common.Address{{}}, plan.queries[0].addresses)
+		assert.Empty(t, plan.matchingLogs([]Log{{Address: a1, EventSig: log1}}))
+	})
+
+	t.Run("filters with the same topics share a query", func(t *testing.T) {
+		plan := newLogQueryPlan(map[string]Filter{
+			"a": {Name: "a", Addresses: []common.Address{a2}, EventSigs: []common.Hash{log1}},
+			"b": {Name: "b", Addresses: []common.Address{a1, a2}, EventSigs: []common.Hash{log1}},
+		}, 0)
+		assert.Equal(t, []logQuery{{addresses: []common.Address{a1, a2}, topics: [][]common.Hash{{log1}}}}, plan.queries)
+		assert.Equal(t, 2, plan.addressEventPairs)
+		assert.Equal(t, 2, plan.mergedAddressEventPairs)
+	})
+
+	t.Run("filters with the same addresses merge their events", func(t *testing.T) {
+		plan := newLogQueryPlan(map[string]Filter{
+			"a": {Name: "a", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log2}},
+			"b": {Name: "b", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log1}},
+		}, 0)
+		assert.Equal(t, []logQuery{{addresses: []common.Address{a1}, topics: [][]common.Hash{sortedHashes([]common.Hash{log1, log2})}}}, plan.queries)
+	})
+
+	t.Run("unrelated filters don't leak into each other", func(t *testing.T) {
+		plan := newLogQueryPlan(map[string]Filter{
+			"a": {Name: "a", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log1}},
+			"b": {Name: "b", Addresses: []common.Address{a2}, EventSigs: []common.Hash{log2}, Topic3: []common.Hash{topic}},
+		}, 0)
+		assert.ElementsMatch(t, []logQuery{
+			{addresses: []common.Address{a1}, topics: [][]common.Hash{{log1}}},
+			{addresses: []common.Address{a2}, topics: [][]common.Hash{{log2}, nil, {topic}}},
+		}, plan.queries)
+		assert.Equal(t, 2, plan.addressEventPairs)
+		assert.Equal(t, 4, plan.mergedAddressEventPairs)
+
+		matching := plan.matchingLogs([]Log{
+			{Address: a1, EventSig: log1, Topics: [][]byte{log1[:]}},
+			{Address: a1, EventSig: log2, Topics: [][]byte{log2[:], topic[:], topic[:]}},
+			{Address: a2, EventSig: log2, Topics: [][]byte{log2[:], topic[:], topic[:]}},
+			{Address: a2, EventSig: log2, Topics: [][]byte{log2[:], topic[:], log1[:]}},
+		})
+		require.Len(t, matching, 2)
+		assert.Equal(t, a1, matching[0].Address)
+		assert.Equal(t, a2, matching[1].Address)
+	})
+
+	t.Run("queries are split by max addresses", func(t *testing.T) {
+		plan := newLogQueryPlan(map[string]Filter{
+			"a": {Name: "a", Addresses: []common.Address{a1, a2, a3}, EventSigs: []common.Hash{log1}},
+		}, 2)
+		assert.Equal(t, []logQuery{
+			{addresses: []common.Address{a1, a2}, topics: [][]common.Hash{{log1}}},
+			{addresses: []common.Address{a3}, topics: [][]common.Hash{{log1}}},
+		}, plan.queries)
+	})
+
+	t.Run("logs per block", func(t *testing.T) {
+		plan := newLogQueryPlan(map[string]Filter{
+			"a": {Name: "a", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log1, log2}, LogsPerBlock: 2},
+			"b": {Name: "b", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log2}},
+			"c": {Name: "c", Addresses: []common.Address{a2}, EventSigs: []common.Hash{log1}, LogsPerBlock: 1},
+		}, 0)
+		var logs []Log
+		for n := int64(1); n <= 2; n++ {
+			// In descending log index order, to check the first logs of each block by log index are kept.
+			for i := int64(4); i >= 0; i-- {
+				event := log1
+				if i == 3 {
+					event = log2
+				}
+				logs = append(logs,
+					Log{BlockNumber: n, BlockHash: common.BigToHash(big.NewInt(n)), LogIndex: 2 * i, Address: a1, EventSig: event, Topics: [][]byte{event[:]}},
+					Log{BlockNumber: n, BlockHash: common.BigToHash(big.NewInt(n)), LogIndex: 2*i + 1, Address: a2, EventSig: log1, Topics: [][]byte{log1[:]}})
+			}
+		}
+
+		kept, limited := plan.limitLogsPerBlock(logs)
+		var keptIndexes []int64
+		for _, l := range kept {
+			if l.BlockNumber == 2 {
+				keptIndexes = append(keptIndexes, l.LogIndex)
+			}
+		}
+		// a keeps 0 and 2, b keeps 6 which a drops, and c keeps 1.
+		assert.Equal(t, []int64{6, 2, 0, 1}, keptIndexes)
+		assert.Len(t, kept, 8)
+		assert.Equal(t, []rateLimitedFilter{{name: "a", logsPerBlock: 2, excessLogs: 6}, {name: "c", logsPerBlock: 1, excessLogs: 8}}, limited)
+
+		unlimited := newLogQueryPlan(map[string]Filter{"b": {Name: "b", Addresses: []common.Address{a1}, EventSigs: []common.Hash{log2}}}, 0)
+		kept, limited = unlimited.limitLogsPerBlock(logs)
+		assert.Equal(t, logs, kept)
+		assert.Empty(t, limited)
+	})
+}
+
+func TestLogPoller_Backfill(t *testing.T) {
+	t.Parallel()
+	chainID := testutils.NewRandomEVMChainID()
+	addr := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	eventSig := EmitterABI.Events["Log1"].ID
+
+	setup := func(t *testing.T, db ethdb.KeyValueStore, filterLogs func(from, to int64) error) (*logPoller, ORM) {
+		lggr := logger.Test(t)
+		orm, err := NewEmbeddedORM(chainID, db, lggr)
+		require.NoError(t, err)
+		ec := clienttest.NewClient(t)
+		ec.On("ConfiguredChainID").Return(chainID).Maybe()
+		ec.On("FilterLogs", mock.Anything, mock.Anything).Return(func(ctx context.Context, fq ethereum.FilterQuery) ([]types.Log, error) {
+			from, to := fq.FromBlock.Int64(), fq.ToBlock.Int64()
+			if err := filterLogs(from, to); err != nil {
+				return nil, err
+			}
+			var logs []types.Log
+			for n := from; n <= to; n++ {
+				logs = append(logs, types.Log{
+					Address:     addr,
+					Topics:      []common.Hash{eventSig},
+					BlockNumber: uint64(n), //nolint:gosec // G115
+					BlockHash:   common.BigToHash(big.NewInt(n)),
+				})
+			}
+			return logs, nil
+		})
+		ec.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			for _, e := range args.Get(1).([]rpc.BatchElem) {
+				num := int64(200)
+				if block := e.Args[0].(string); block != "latest" {
+					n, err := hexutil.DecodeUint64(block)
+					require.NoError(t, err)
+					num = int64(n) //nolint:gosec // G115
+				}
+				*e.Result.(*evmtypes.Head) = newHeadVal(num)
+			}
+		})
+		lp := NewLogPoller(orm, ec, lggr, nil, Opts{
+			FinalityDepth:            2,
+			BackfillBatchSize:        4,
+			BackfillConcurrency:      4,
+			RPCBatchSize:             10,
+			KeepFinalizedBlocksDepth: 1000,
+		})
+		require.NoError(t, lp.RegisterFilter(testutils.Context(t), Filter{Name: "test", Addresses: []common.Address{addr}, EventSigs: []common.Hash{eventSig}}))
+		return lp, orm
+	}
+	requireLogs := func(t *testing.T, orm ORM, from, to int64) {
+		logs, err := orm.SelectLogs(testutils.Context(t), 0, 1000, addr, eventSig)
+		require.NoError(t, err)
+		require.Len(t, logs, int(to-from+1))
+		for i, log := range logs {
+			assert.Equal(t, from+int64(i), log.BlockNumber)
+		}
+	}
+
+	t.Run("fetches ranges in parallel and splits the ranges rejected", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		lp, orm := setup(t, memorydb.New(), func(from, to int64) error {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+			}
+			time.Sleep(10 * time.Millisecond)
+			if from <= 50 && 50 <= to && from < to {
+				return context.DeadlineExceeded
+			}
+			return nil
+		})
+		require.NoError(t, lp.backfill(testutils.Context(t), 1, 100))
+		requireLogs(t, orm, 1, 100)
+		assert.Greater(t, maxInFlight.Load(), int32(1))
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(4))
+	})
+
+	t.Run("interrupted replay resumes from the last range saved", func(t *testing.T) {
+		var failed atomic.Bool
+		var minFrom atomic.Int64
+		minFrom.Store(math.MaxInt64)
+		filterLogs := func(from, to int64) error {
+			if from <= 70 && 70 <= to && !failed.Swap(true) {
+				return errors.New("connection reset")
+			}
+			for m := minFrom.Load(); from < m && !minFrom.CompareAndSwap(m, from); m = minFrom.Load() {
+			}
+			return nil
+		}
+		db := memorydb.New()
+		lp, orm := setup(t, db, filterLogs)
+		ctx := testutils.Context(t)
+		require.Error(t, lp.replayBackfill(ctx, 1, 100))
+		checkpoint, err := orm.SelectReplayCheckpoint(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), checkpoint.FromBlock)
+		assert.Greater(t, checkpoint.NextBlock, int64(1))
+		assert.LessOrEqual(t, checkpoint.NextBlock, int64(70))
+
+		// The replay resumes after a restart, with the same filters.
+		lp, orm = setup(t, db, filterLogs)
+		minFrom.Store(math.MaxInt64)
+		require.NoError(t, lp.replayBackfill(ctx, 1, 100))
+		assert.Equal(t, checkpoint.NextBlock, minFrom.Load())
+		_, err = orm.SelectReplayCheckpoint(ctx)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		requireLogs(t, orm, 1, 100)
+	})
+}
+
+func TestDSORM_ReplayCheckpointWithoutTable(t *testing.T) {
+	ctx := testutils.Context(t)
+	// Without Migrations, evm.log_poller_replay_checkpoints doesn't exist.
+	orm := NewORM(testutils.NewRandomEVMChainID(), testutils.NewSqlxDB(t), logger.Test(t))
+	require.NoError(t, orm.UpsertReplayCheckpoint(ctx, ReplayCheckpoint{FromBlock: 1, NextBlock: 10}))
+	_, err := orm.SelectReplayCheckpoint(ctx)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.NoError(t, orm.DeleteReplayCheckpoint(ctx))
+}
+
+func TestLogPoller_IsBlockOnChain(t *testing.T) {
+	t.Parallel()
+	lggr := logger.Test(t)
+	ctx := testutils.Context(t)
+	block := Block{BlockNumber: 5, BlockHash: newHead(5).Hash}
+
+	ec := clienttest.NewClient(t)
+	ec.EXPECT().HeadByNumber(mock.Anything, big.NewInt(5)).Return(newHead(5), nil)
+	lp := NewLogPoller(nil, ec, lggr, nil, Opts{})
+	onChain, err := lp.isBlockOnChain(ctx, block)
+	require.NoError(t, err)
+	assert.True(t, onChain)
+	onChain, err = lp.isBlockOnChain(ctx, Block{BlockNumber: 5, BlockHash: utils.NewHash()})
+	require.NoError(t, err)
+	assert.False(t, onChain)
+
+	t.Run("recovery client agrees", func(t *testing.T) {
+		recoveryClient := clienttest.NewClient(t)
+		recoveryClient.EXPECT().HeadByNumber(mock.Anything, big.NewInt(5)).Return(newHead(5), nil)
+		lp := NewLogPoller(nil, ec, lggr, nil, Opts{RecoveryClient: recoveryClient})
+		onChain, err := lp.isBlockOnChain(ctx, block)
+		require.NoError(t, err)
+		assert.True(t, onChain)
+	})
+
+	t.Run("recovery client disagrees", func(t *testing.T) {
+		recoveryClient := clienttest.NewClient(t)
+		recoveryClient.EXPECT().HeadByNumber(mock.Anything, big.NewInt(5)).Return(&evmtypes.Head{Number: 5, Hash: utils.NewHash()}, nil)
+		lp := NewLogPoller(nil, ec, lggr, nil, Opts{RecoveryClient: recoveryClient})
+		_, err := lp.isBlockOnChain(ctx, block)
+		require.ErrorContains(t, err, "RPCs disagree on block 5")
+	})
+}
+
 func TestLogPoller_BackupPollerStartup(t *testing.T) {
 	addr := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
 	lggr, observedLogs := logger.TestObserved(t, zapcore.WarnLevel)
 	chainID := testutils.FixtureChainID
-	db := testutils.NewSqlxDB(t)
+	db := NewTestSqlxDB(t)
 	orm := NewORM(chainID, db, lggr)
 	latestBlock := int64(4)
 	const finalityDepth = 2
@@ -301,7 +566,7 @@ func TestLogPoller_Replay(t *testing.T) {
 
 	lggr, observedLogs := logger.TestObserved(t, zapcore.ErrorLevel)
 	chainID := testutils.FixtureChainID
-	db := testutils.NewSqlxDB(t)
+	db := NewTestSqlxDB(t)
 	orm := NewORM(chainID, db, lggr)
 
 	var head atomic.Pointer[evmtypes.Head]
@@ -605,7 +870,7 @@ func Test_latestBlockAndFinalityDepth(t *testing.T) {
 func Test_FetchBlocks(t *testing.T) {
 	lggr := logger.Test(t)
 	chainID := testutils.FixtureChainID
-	db := testutils.NewSqlxDB(t)
+	db := NewTestSqlxDB(t)
 	orm := NewORM(chainID, db, lggr)
 	ctx := testutils.Context(t)
 
@@ -674,10 +939,38 @@ func Test_FetchBlocks(t *testing.T) {
 	}
 }
 
+func Test_PollAndSaveLogs_FinalityViolationWithoutDivergence(t *testing.T) {
+	t.Parallel()
+	lggr := logger.Test(t)
+	chainID := testutils.NewRandomEVMChainID()
+	orm, err := NewEmbeddedORM(chainID, memorydb.New(), lggr)
+	require.NoError(t, err)
+	ctx := tests.Context(t)
+	for n := int64(1); n <= 5; n++ {
+		require.NoError(t, orm.InsertBlock(ctx, common.BigToHash(big.NewInt(n)), n, time.Unix(n, 0), 3))
+	}
+	headTracker := headstest.NewTracker[*evmtypes.Head, common.Hash](t)
+	headTracker.EXPECT().LatestAndFinalizedBlock(mock.Anything).Return(newHead(5), newHead(3), nil)
+	ec := clienttest.NewClient(t)
+	ec.EXPECT().ConfiguredChainID().Return(chainID).Maybe()
+	ec.EXPECT().HeadByNumber(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, number *big.Int) (*evmtypes.Head, error) {
+		return newHead(number.Int64()), nil
+	})
+	lp := NewLogPoller(orm, ec, lggr, headTracker, Opts{FinalityDepth: 2, BackfillBatchSize: 10, RPCBatchSize: 10, KeepFinalizedBlocksDepth: 1000, RecoverFinalityViolation: true})
+
+	// Declared by a replay or the backup poller, while all the blocks saved are canonical.
+	lp.finalityViolated.Store(true)
+	lp.PollAndSaveLogs(ctx, 6)
+	require.NoError(t, lp.Healthy())
+	latest, err := orm.SelectLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), latest.BlockNumber)
+}
+
 func Test_PollAndSaveLogs_BackfillFinalityViolation(t *testing.T) {
 	t.Parallel()
 
-	db := testutils.NewSqlxDB(t)
+	db := NewTestSqlxDB(t)
 	lpOpts := Opts{
 		PollPeriod:               time.Second,
 		FinalityDepth:            3,
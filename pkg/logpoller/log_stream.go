@@ -0,0 +1,240 @@
+package logpoller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+const (
+	// logStreamResubscribeDelay is the delay before subscribing again after a subscription error.
+	logStreamResubscribeDelay = 5 * time.Second
+	// logStreamMaxBlocks is the maximum number of blocks of which the streamed logs are kept until they're polled.
+	logStreamMaxBlocks = 1000
+)
+
+var promLpStreamedBlocks = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "log_poller_streamed_blocks",
+	Help: "Number of blocks polled by the log poller, by whether their logs were streamed or fetched with eth_getLogs",
+}, []string{"evmChainID", "source"})
+
+// logSubscriber is implemented by the clients which support subscribing to logs, like client.Client.
+type logSubscriber interface {
+	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+}
+
+// logStream keeps the logs received from a subscription to the logs of the merged filter, by block hash, until the
+// blocks are polled. The logs of a block are only used instead of eth_getLogs if the block has been produced after
+// the subscription started, with the current merged filter, and without errors since. As the logs of a block may
+// still be in flight when it's polled, the block must also be older than the latest block with streamed logs.
+type logStream struct {
+	subscriber logSubscriber
+
+	mu sync.Mutex
+	// query is the query of the subscription, nil if there isn't one.
+	query *ethereum.FilterQuery
+	// coveredFrom is the first block of which all the logs are received by the subscription.
+	coveredFrom int64
+	// latest is the number of the latest block of which logs were received by the subscription.
+	latest int64
+	blocks map[common.Hash]*streamedBlock
+}
+
+type streamedBlock struct {
+	number int64
+	logs   []types.Log
+}
+
+// newLogStream returns the log stream of ec if enabled, or nil if it's disabled or ec doesn't support subscriptions.
+func newLogStream(enabled bool, ec Client) *logStream {
+	subscriber, ok := ec.(logSubscriber)
+	if !enabled || !ok {
+		return nil
+	}
+	return &logStream{subscriber: subscriber, blocks: make(map[common.Hash]*streamedBlock)}
+}
+
+// subscribed resets the stream for a new subscription with query, covering the blocks from coveredFrom on.
+func (s *logStream) subscribed(query ethereum.FilterQuery, coveredFrom int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.query = &query
+	s.coveredFrom = coveredFrom
+	s.latest = 0
+	clear(s.blocks)
+}
+
+// unsubscribed resets the stream after the subscription ended, so that the logs are fetched with eth_getLogs.
+func (s *logStream) unsubscribed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.query = nil
+	s.latest = 0
+	clear(s.blocks)
+}
+
+// add keeps a log received from the subscription, and returns whether it's the first of its block.
+func (s *logStream) add(l types.Log) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.query == nil || l.Removed {
+		// Logs removed by a reorg are in blocks which aren't polled anymore.
+		return false
+	}
+	block, ok := s.blocks[l.BlockHash]
+	if !ok {
+		if len(s.blocks) >= logStreamMaxBlocks {
+			s.dropOldestBlock()
+		}
+		block = &streamedBlock{number: int64(l.BlockNumber)} //nolint:gosec // G115
+		s.blocks[l.BlockHash] = block
+	}
+	block.logs = append(block.logs, l)
+	s.latest = max(s.latest, block.number)
+	return !ok
+}
+
+// dropOldestBlock drops the logs of the oldest block, which is then no longer covered by the stream.
+func (s *logStream) dropOldestBlock() {
+	var oldest common.Hash
+	oldestNumber := int64(math.MaxInt64)
+	for hash, block := range s.blocks {
+		if block.number < oldestNumber {
+			oldest, oldestNumber = hash, block.number
+		}
+	}
+	delete(s.blocks, oldest)
+	s.coveredFrom = max(s.coveredFrom, oldestNumber+1)
+}
+
+// take returns the logs streamed for the block head, and drops the logs of the blocks up to head. It's false if the
+// stream doesn't cover the block with query, or hasn't moved past it yet, in which case the logs must be fetched with
+// eth_getLogs.
+func (s *logStream) take(head *evmtypes.Head, query ethereum.FilterQuery) ([]types.Log, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.query == nil {
+		return nil, false
+	}
+	var logs []types.Log
+	if block, ok := s.blocks[head.Hash]; ok {
+		logs = block.logs
+	}
+	for hash, block := range s.blocks {
+		if block.number <= head.Number {
+			delete(s.blocks, hash)
+		}
+	}
+	if head.Number < s.coveredFrom || head.Number >= s.latest || !reflect.DeepEqual(s.query.Addresses, query.Addresses) || !reflect.DeepEqual(s.query.Topics, query.Topics) {
+		return nil, false
+	}
+	slices.SortFunc(logs, func(a, b types.Log) int { return cmp.Compare(a.Index, b.Index) })
+	// Logs may be delivered twice after a reconnection.
+	return slices.CompactFunc(logs, func(a, b types.Log) bool { return a.Index == b.Index }), true
+}
+
+// blockLogs returns the logs of the registered filters in the block head, from the log stream if it covers the block,
+// or with eth_getLogs otherwise.
+func (lp *logPoller) blockLogs(ctx context.Context, head *evmtypes.Head) ([]types.Log, *logQueryPlan, error) {
+	if lp.logStream != nil {
+		plan := lp.queryPlan()
+		if logs, ok := lp.logStream.take(head, lp.Filter(nil, nil, nil)); ok {
+			promLpStreamedBlocks.WithLabelValues(lp.ec.ConfiguredChainID().String(), "stream").Inc()
+			return logs, plan, nil
+		}
+		promLpStreamedBlocks.WithLabelValues(lp.ec.ConfiguredChainID().String(), "eth_getLogs").Inc()
+	}
+	h := head.Hash
+	return lp.filterLogs(ctx, nil, nil, &h)
+}
+
+// runLogStream subscribes to the logs of the merged filter, and notifies the main loop of the new blocks with logs so
+// that they're polled without waiting for the poll period. It subscribes again whenever the merged filter changes, or
+// after an error. Meanwhile, the logs are fetched with eth_getLogs.
+func (lp *logPoller) runLogStream() {
+	defer lp.wg.Done()
+	ctx, cancel := lp.stopCh.NewCtx()
+	defer cancel()
+
+	for {
+		err := lp.streamLogs(ctx)
+		lp.logStream.unsubscribed()
+		if ctx.Err() != nil {
+			return
+		}
+		delay := time.Duration(0)
+		if err != nil {
+			lp.lggr.Warnw("Log subscription failed, falling back to polling logs", "err", err, "retryIn", logStreamResubscribeDelay)
+			delay = logStreamResubscribeDelay
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// streamLogs runs a subscription to the logs of the merged filter, until it fails or the merged filter changes.
+func (lp *logPoller) streamLogs(ctx context.Context) error {
+	query := lp.Filter(nil, nil, nil)
+	ch := make(chan types.Log, 256)
+	sub, err := lp.logStream.subscriber.SubscribeFilterLogs(ctx, query, ch)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	// The blocks after the latest block of the RPC are produced after the subscription started.
+	latest, err := lp.latencyMonitor.HeadByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		return errors.New("got nil latest block")
+	}
+	lp.logStream.subscribed(query, latest.Number+1)
+	lp.lggr.Debugw("Subscribed to logs", "coveredFromBlock", latest.Number+1)
+
+	filterCheck := services.NewTicker(lp.pollPeriod)
+	defer filterCheck.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-sub.Err():
+			if !ok || err == nil {
+				return errors.New("log subscription closed")
+			}
+			return err
+		case l := <-ch:
+			if lp.logStream.add(l) {
+				select {
+				case lp.logStreamNotify <- struct{}{}:
+				default:
+				}
+			}
+		case <-filterCheck.C:
+			current := lp.Filter(nil, nil, nil)
+			if !reflect.DeepEqual(query.Addresses, current.Addresses) || !reflect.DeepEqual(query.Topics, current.Topics) {
+				lp.lggr.Debugw("Filters changed, subscribing to logs again")
+				return nil
+			}
+		}
+	}
+}
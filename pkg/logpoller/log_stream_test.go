@@ -0,0 +1,147 @@
+package logpoller
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client/clienttest"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+func TestLogStream(t *testing.T) {
+	t.Parallel()
+	addr := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	eventSig := EmitterABI.Events["Log1"].ID
+	query := ethereum.FilterQuery{Addresses: []common.Address{addr}, Topics: [][]common.Hash{{eventSig}}}
+	newLog := func(block int64, index uint) types.Log {
+		return types.Log{
+			Address:     addr,
+			Topics:      []common.Hash{eventSig},
+			BlockNumber: uint64(block), //nolint:gosec // G115
+			BlockHash:   common.BigToHash(big.NewInt(block)),
+			Index:       index,
+		}
+	}
+	newStream := func() *logStream {
+		return newLogStream(true, clienttest.NewClient(t))
+	}
+
+	t.Run("not covered without subscription", func(t *testing.T) {
+		s := newStream()
+		assert.False(t, s.add(newLog(10, 0)))
+		_, ok := s.take(newHead(10), query)
+		assert.False(t, ok)
+	})
+
+	t.Run("returns the logs of covered blocks sorted and deduplicated", func(t *testing.T) {
+		s := newStream()
+		s.subscribed(query, 10)
+		assert.True(t, s.add(newLog(9, 0)))
+		assert.True(t, s.add(newLog(10, 1)))
+		assert.False(t, s.add(newLog(10, 0)))
+		assert.False(t, s.add(newLog(10, 1)))
+		assert.True(t, s.add(newLog(11, 0)))
+
+		_, ok := s.take(newHead(9), query)
+		assert.False(t, ok, "block produced before the subscription")
+
+		logs, ok := s.take(newHead(10), query)
+		require.True(t, ok)
+		require.Len(t, logs, 2)
+		assert.Equal(t, uint(0), logs[0].Index)
+		assert.Equal(t, uint(1), logs[1].Index)
+
+		_, ok = s.take(newHead(12), query)
+		assert.False(t, ok, "logs of the block may still be in flight")
+
+		s.add(newLog(13, 0))
+		logs, ok = s.take(newHead(12), query)
+		require.True(t, ok, "block without logs")
+		assert.Empty(t, logs)
+		assert.Len(t, s.blocks, 1, "only the logs of the later block are kept")
+	})
+
+	t.Run("not covered until the stream moves past the block", func(t *testing.T) {
+		s := newStream()
+		s.subscribed(query, 10)
+		s.add(newLog(10, 0))
+		_, ok := s.take(newHead(10), query)
+		assert.False(t, ok)
+		_, ok = s.take(newHead(11), query)
+		assert.False(t, ok)
+
+		s.add(newLog(11, 0))
+		s.add(newLog(12, 0))
+		logs, ok := s.take(newHead(11), query)
+		require.True(t, ok)
+		assert.Len(t, logs, 1)
+	})
+
+	t.Run("not covered with another query", func(t *testing.T) {
+		s := newStream()
+		s.subscribed(query, 10)
+		s.add(newLog(10, 0))
+		other := ethereum.FilterQuery{Addresses: []common.Address{addr, common.HexToAddress("0x1234")}, Topics: query.Topics}
+		_, ok := s.take(newHead(10), other)
+		assert.False(t, ok)
+	})
+
+	t.Run("not covered after unsubscribing", func(t *testing.T) {
+		s := newStream()
+		s.subscribed(query, 10)
+		s.add(newLog(10, 0))
+		s.unsubscribed()
+		_, ok := s.take(newHead(10), query)
+		assert.False(t, ok)
+	})
+
+	t.Run("dropping the oldest block stops covering it", func(t *testing.T) {
+		s := newStream()
+		s.subscribed(query, 1)
+		for n := int64(1); n <= logStreamMaxBlocks+1; n++ {
+			s.add(newLog(n, 0))
+		}
+		assert.Len(t, s.blocks, logStreamMaxBlocks)
+		_, ok := s.take(newHead(1), query)
+		assert.False(t, ok)
+		logs, ok := s.take(newHead(2), query)
+		require.True(t, ok)
+		assert.Len(t, logs, 1)
+	})
+
+	t.Run("blockLogs uses the stream instead of eth_getLogs", func(t *testing.T) {
+		chainID := testutils.NewRandomEVMChainID()
+		lggr := logger.Test(t)
+		orm, err := NewEmbeddedORM(chainID, memorydb.New(), lggr)
+		require.NoError(t, err)
+		ec := clienttest.NewClient(t)
+		ec.On("ConfiguredChainID").Return(chainID).Maybe()
+		lp := NewLogPoller(orm, ec, lggr, nil, Opts{FinalityDepth: 2, BackfillBatchSize: 10, RPCBatchSize: 10, KeepFinalizedBlocksDepth: 1000, StreamLogs: true})
+		ctx := tests.Context(t)
+		require.NoError(t, lp.RegisterFilter(ctx, Filter{Name: "test", Addresses: []common.Address{addr}, EventSigs: []common.Hash{eventSig}}))
+		require.NotNil(t, lp.logStream)
+
+		lp.logStream.subscribed(lp.Filter(nil, nil, nil), 10)
+		lp.logStream.add(newLog(10, 0))
+		lp.logStream.add(newLog(11, 0))
+		// FilterLogs isn't mocked, the logs must come from the stream.
+		logs, plan, err := lp.blockLogs(ctx, newHead(10))
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Len(t, plan.filters, 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newLogStream(false, clienttest.NewClient(t)))
+	})
+}
@@ -0,0 +1,99 @@
+package logpoller
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var promLpLogsRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "log_poller_logs_rate_limited",
+	Help: "Number of logs matching a filter beyond its LogsPerBlock rate limit",
+}, []string{"evmChainID", "filterName"})
+
+// rateLimitedFilter is a filter of which some logs exceeded its LogsPerBlock rate limit.
+type rateLimitedFilter struct {
+	name         string
+	logsPerBlock uint64
+	excessLogs   int
+}
+
+// limitLogsPerBlock drops the logs exceeding the LogsPerBlock rate limit of the filters of the plan. Within a block, a
+// filter keeps its first LogsPerBlock logs by log index, and a log is kept if any filter matching it keeps it, like
+// SelectExcessLogIDs prunes them. It also returns the filters which exceeded their rate limit, sorted by name.
+func (p *logQueryPlan) limitLogsPerBlock(logs []Log) ([]Log, []rateLimitedFilter) {
+	if !slices.ContainsFunc(p.filters, func(filter Filter) bool { return filter.LogsPerBlock > 0 }) {
+		return logs, nil
+	}
+
+	order := make([]int, len(logs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if c := cmp.Compare(logs[a].BlockNumber, logs[b].BlockNumber); c != 0 {
+			return c
+		}
+		return cmp.Compare(logs[a].LogIndex, logs[b].LogIndex)
+	})
+
+	type blockKey struct {
+		filter    int
+		blockHash common.Hash
+	}
+	counts := make(map[blockKey]uint64)
+	excess := make([]int, len(p.filters))
+	keep := make([]bool, len(logs))
+	for _, i := range order {
+		l := &logs[i]
+		for j := range p.filters {
+			filter := &p.filters[j]
+			if !filter.matches(l) {
+				continue
+			}
+			key := blockKey{j, l.BlockHash}
+			counts[key]++
+			if filter.LogsPerBlock == 0 || counts[key] <= filter.LogsPerBlock {
+				keep[i] = true
+			} else {
+				excess[j]++
+			}
+		}
+	}
+
+	kept := make([]Log, 0, len(logs))
+	for i := range logs {
+		if keep[i] {
+			kept = append(kept, logs[i])
+		}
+	}
+	var limited []rateLimitedFilter
+	for j, n := range excess {
+		if n > 0 {
+			limited = append(limited, rateLimitedFilter{name: p.filters[j].Name, logsPerBlock: p.filters[j].LogsPerBlock, excessLogs: n})
+		}
+	}
+	slices.SortFunc(limited, func(a, b rateLimitedFilter) int { return cmp.Compare(a.name, b.name) })
+	return kept, limited
+}
+
+// limitLogsPerBlock drops the logs exceeding the LogsPerBlock rate limit of the filters of the plan, and reports the
+// filters which exceeded it.
+func (lp *logPoller) limitLogsPerBlock(plan *logQueryPlan, logs []Log) []Log {
+	kept, limited := plan.limitLogsPerBlock(logs)
+	if len(limited) == 0 {
+		return kept
+	}
+
+	chainID := lp.ec.ConfiguredChainID().String()
+	for _, filter := range limited {
+		promLpLogsRateLimited.WithLabelValues(chainID, filter.name).Add(float64(filter.excessLogs))
+		lp.lggr.Warnw("Filter exceeded its LogsPerBlock rate limit, dropping the logs not matching other filters",
+			"filter", filter.name, "logsPerBlock", filter.logsPerBlock, "excessLogs", filter.excessLogs,
+			"fromBlock", logs[0].BlockNumber, "toBlock", logs[len(logs)-1].BlockNumber)
+	}
+	return kept
+}
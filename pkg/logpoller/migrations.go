@@ -0,0 +1,10 @@
+package logpoller
+
+import "embed"
+
+// Migrations are the goose migrations of the optional tables used by DSORM: the event ABIs of the filters and the
+// replay checkpoints. They are not part of the node's migrations, so they have to be run separately; DSORM only uses
+// these tables if they exist and otherwise works without the features they back.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evm.log_poller_filter_events (
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    name TEXT NOT NULL,
+    events JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (evm_chain_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS evm.log_poller_filter_events;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evm.log_poller_replay_checkpoints (
+    evm_chain_id NUMERIC(78,0) PRIMARY KEY,
+    from_block BIGINT NOT NULL,
+    next_block BIGINT NOT NULL,
+    filters_hash BYTEA NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS evm.log_poller_replay_checkpoints;
@@ -0,0 +1,66 @@
+-- +goose Up
+-- The fields of the events indexed by the filters, located in the logs like by logpoller.NewEventByFieldFilter: topic
+-- is the index of an indexed field in the topics, otherwise word is the first word of its head in the data, and size
+-- is the size of the head of a static field, or 0 for a dynamic field.
+CREATE TABLE IF NOT EXISTS evm.log_poller_filter_fields (
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    name TEXT NOT NULL,
+    address BYTEA NOT NULL,
+    event_sig BYTEA NOT NULL,
+    field TEXT NOT NULL,
+    topic INT NOT NULL,
+    word INT NOT NULL,
+    size INT NOT NULL,
+    PRIMARY KEY (evm_chain_id, name, address, event_sig, field)
+);
+
+-- The values of the indexed fields in the logs.
+CREATE TABLE IF NOT EXISTS evm.log_poller_event_fields (
+    log_id BIGINT NOT NULL REFERENCES evm.logs (id) ON DELETE CASCADE,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    event_sig BYTEA NOT NULL,
+    field TEXT NOT NULL,
+    value BYTEA,
+    PRIMARY KEY (log_id, field)
+);
+
+CREATE INDEX IF NOT EXISTS idx_log_poller_event_fields_value
+    ON evm.log_poller_event_fields (evm_chain_id, event_sig, field, value);
+
+-- f_log_poller_event_field returns the value of a field in a log, compared to the ABI encoding of a value: the topic of
+-- an indexed field, the head of a static field, or the tail of a dynamic field, its length followed by its padded value.
+-- It is NULL if the log is too short.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION evm.f_log_poller_event_field(topics BYTEA[], data BYTEA, topic INT, word INT, size INT)
+RETURNS BYTEA LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    tail BIGINT;
+    len BIGINT;
+BEGIN
+    IF topic > 0 THEN
+        RETURN topics[topic+1];
+    END IF;
+    IF size > 0 THEN
+        RETURN substring(data from 32*word+1 for size);
+    END IF;
+    IF length(data) < 32*word+32 THEN
+        RETURN NULL;
+    END IF;
+    -- The head of a dynamic field is the offset of its tail, read from its last 4 bytes like by logpoller.
+    tail := ('x' || encode(substring(data from 32*word+29 for 4), 'hex'))::bit(32)::int;
+    IF tail < 0 OR length(data) < tail+32 THEN
+        RETURN NULL;
+    END IF;
+    len := ('x' || encode(substring(data from tail+29 for 4), 'hex'))::bit(32)::int;
+    IF len < 0 THEN
+        RETURN NULL;
+    END IF;
+    RETURN substring(data from tail+1 for 32+(len+31)/32*32);
+END;
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION IF EXISTS evm.f_log_poller_event_field;
+DROP TABLE IF EXISTS evm.log_poller_event_fields;
+DROP TABLE IF EXISTS evm.log_poller_filter_fields;
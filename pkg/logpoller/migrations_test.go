@@ -0,0 +1,16 @@
+package logpoller
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+// NewTestSqlxDB returns a test db with the Migrations applied.
+func NewTestSqlxDB(t testing.TB) *sqlx.DB {
+	db := testutils.NewSqlxDB(t)
+	testutils.MigrateUp(t, db, Migrations)
+	return db
+}
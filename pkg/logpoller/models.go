@@ -22,6 +22,14 @@ type Block struct {
 	CreatedAt            time.Time
 }
 
+// ReplayCheckpoint is the progress of a Replay backfill which didn't complete: all the blocks in [FromBlock, NextBlock)
+// have been backfilled with the filters identified by FiltersHash.
+type ReplayCheckpoint struct {
+	FromBlock   int64
+	NextBlock   int64
+	FiltersHash common.Hash
+}
+
 // Log represents an EVM log.
 type Log struct {
 	EVMChainID     *big.Big
@@ -35,6 +43,9 @@ type Log struct {
 	TxHash         common.Hash
 	Data           []byte
 	CreatedAt      time.Time
+	// Decoded are the fields of the log by name, if a registered filter has the ABI of its event. It's only set by
+	// LogPoller.FilteredLogs.
+	Decoded map[string]any `db:"-"`
 }
 
 func (l *Log) GetTopics() []common.Hash {
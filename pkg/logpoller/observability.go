@@ -74,6 +74,12 @@ func (o *ObservedORM) LoadFilters(ctx context.Context) (map[string]Filter, error
 	})
 }
 
+func (o *ObservedORM) SelectFilterStartBlocks(ctx context.Context) (map[string]int64, error) {
+	return withObservedQuery(o, "SelectFilterStartBlocks", func() (map[string]int64, error) {
+		return o.ORM.SelectFilterStartBlocks(ctx)
+	})
+}
+
 func (o *ObservedORM) DeleteFilter(ctx context.Context, name string) error {
 	return withObservedExec(o, "DeleteFilter", metrics.Del, func() error {
 		return o.ORM.DeleteFilter(ctx, name)
@@ -189,7 +189,7 @@ func NewTestObservedORM(chainID *big.Int, ds sqlutil.DataSource, lggr logger.Log
 
 func createObservedORM(t *testing.T, chainId int64) *ObservedORM {
 	lggr := logger.Test(t)
-	db := testutils.NewSqlxDB(t)
+	db := NewTestSqlxDB(t)
 	observed, err := NewTestObservedORM(big.NewInt(chainId), db, lggr)
 	require.NoError(t, err)
 	return observed
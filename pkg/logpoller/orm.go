@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -30,6 +31,9 @@ type ORM interface {
 
 	LoadFilters(ctx context.Context) (map[string]Filter, error)
 	DeleteFilter(ctx context.Context, name string) error
+	// SelectFilterStartBlocks returns the first block saved after each filter was last extended with new addresses,
+	// events or topics, from which all its logs were polled. Filters without any block saved since are left out.
+	SelectFilterStartBlocks(ctx context.Context) (map[string]int64, error)
 
 	DeleteLogsByRowID(ctx context.Context, rowIDs []uint64) (int64, error)
 	InsertBlock(ctx context.Context, blockHash common.Hash, blockNumber int64, blockTimestamp time.Time, finalizedBlock int64) error
@@ -46,6 +50,10 @@ type ORM interface {
 	SelectOldestBlock(ctx context.Context, minAllowedBlockNumber int64) (*Block, error)
 	SelectLatestFinalizedBlock(ctx context.Context) (*Block, error)
 
+	SelectReplayCheckpoint(ctx context.Context) (*ReplayCheckpoint, error)
+	UpsertReplayCheckpoint(ctx context.Context, checkpoint ReplayCheckpoint) error
+	DeleteReplayCheckpoint(ctx context.Context) error
+
 	SelectLogs(ctx context.Context, start, end int64, address common.Address, eventSig common.Hash) ([]Log, error)
 	SelectLogsWithSigs(ctx context.Context, start, end int64, address common.Address, eventSigs []common.Hash) ([]Log, error)
 	SelectLogsCreatedAfter(ctx context.Context, address common.Address, eventSig common.Hash, after time.Time, confs evmtypes.Confirmations) ([]Log, error)
@@ -73,6 +81,7 @@ type DSORM struct {
 	chainID *big.Int
 	ds      sqlutil.DataSource
 	lggr    logger.Logger
+	tables  *optionalTables
 }
 
 var _ ORM = &DSORM{}
@@ -83,6 +92,7 @@ func NewORM(chainID *big.Int, ds sqlutil.DataSource, lggr logger.Logger) *DSORM
 		chainID: chainID,
 		ds:      ds,
 		lggr:    lggr,
+		tables:  &optionalTables{exists: make(map[string]bool)},
 	}
 }
 
@@ -91,7 +101,33 @@ func (o *DSORM) Transact(ctx context.Context, fn func(*DSORM) error) (err error)
 }
 
 // new returns a NewORM like o, but backed by ds.
-func (o *DSORM) new(ds sqlutil.DataSource) *DSORM { return NewORM(o.chainID, ds, o.lggr) }
+func (o *DSORM) new(ds sqlutil.DataSource) *DSORM {
+	return &DSORM{chainID: o.chainID, ds: ds, lggr: o.lggr, tables: o.tables}
+}
+
+// optionalTables caches which of the tables created by Migrations exist, so that DSORM keeps working without them.
+type optionalTables struct {
+	mu     sync.Mutex
+	exists map[string]bool
+}
+
+// hasTable reports whether the optional table exists. The result is cached, and a missing table is logged once.
+func (o *DSORM) hasTable(ctx context.Context, table string) (bool, error) {
+	o.tables.mu.Lock()
+	defer o.tables.mu.Unlock()
+	if exists, ok := o.tables.exists[table]; ok {
+		return exists, nil
+	}
+	var exists bool
+	if err := o.ds.GetContext(ctx, &exists, `SELECT to_regclass($1) IS NOT NULL`, table); err != nil {
+		return false, err
+	}
+	if !exists {
+		o.lggr.Warnw("Optional log poller table is missing, run logpoller.Migrations to create it", "table", table)
+	}
+	o.tables.exists[table] = exists
+	return exists, nil
+}
 
 // InsertBlock is idempotent to support replays.
 func (o *DSORM) InsertBlock(ctx context.Context, blockHash common.Hash, blockNumber int64, blockTimestamp time.Time, finalizedBlock int64) error {
@@ -153,20 +189,130 @@ func (o *DSORM) InsertFilter(ctx context.Context, filter Filter) (err error) {
 		topicsColumns.String(),
 		topicsSQL.String())
 
-	_, err = o.ds.NamedExecContext(ctx, query, args)
+	events, err := marshalEventABIs(filter.Events, filter.IndexedFields)
+	if err != nil {
+		return err
+	}
+	fields, err := filter.indexedEventFields()
+	if err != nil {
+		return err
+	}
+	hasEvents, err := o.hasTable(ctx, "evm.log_poller_filter_events")
+	if err != nil {
+		return err
+	}
+	if events != nil && !hasEvents {
+		o.lggr.Warnw("Not saving the event ABIs of filter, evm.log_poller_filter_events is missing", "name", filter.Name)
+	}
+	hasFields, err := o.hasTable(ctx, "evm.log_poller_event_fields")
+	if err != nil {
+		return err
+	}
+	return o.Transact(ctx, func(orm *DSORM) error {
+		if _, err := orm.ds.NamedExecContext(ctx, query, args); err != nil {
+			return err
+		}
+		if hasEvents {
+			// The event ABIs are replaced by those of the latest registration of the filter, like in LogPoller.
+			if events == nil {
+				_, err = orm.ds.ExecContext(ctx,
+					`DELETE FROM evm.log_poller_filter_events WHERE name = $1 AND evm_chain_id = $2`,
+					filter.Name, ubig.New(orm.chainID))
+			} else {
+				_, err = orm.ds.ExecContext(ctx, `INSERT INTO evm.log_poller_filter_events (evm_chain_id, name, events, created_at)
+					VALUES ($1, $2, $3, NOW())
+					ON CONFLICT (evm_chain_id, name) DO UPDATE SET events = EXCLUDED.events`,
+					ubig.New(orm.chainID), filter.Name, events)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if !hasFields {
+			return nil
+		}
+		return orm.insertFilterFields(ctx, filter, fields)
+	})
+}
+
+// insertFilterFields replaces the indexed fields of the filter, and indexes them in the logs already saved. It's called
+// after the filter is inserted, in the same transaction.
+func (o *DSORM) insertFilterFields(ctx context.Context, filter Filter, fields []*eventField) error {
+	if _, err := o.ds.ExecContext(ctx,
+		`DELETE FROM evm.log_poller_filter_fields WHERE name = $1 AND evm_chain_id = $2`,
+		filter.Name, ubig.New(o.chainID)); err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	for _, field := range fields {
+		topic, size := 0, 0
+		if field.arg.Indexed {
+			topic = field.topic
+		} else if !isDynamicType(field.arg.Type) {
+			size = 32 * headWords(field.arg.Type)
+		}
+		// Like LoadFilters, the addresses of the filter include those of its previous registrations.
+		if _, err := o.ds.ExecContext(ctx, `INSERT INTO evm.log_poller_filter_fields
+				(evm_chain_id, name, address, event_sig, field, topic, word, size)
+			SELECT DISTINCT $1::NUMERIC, $2, address, $3::BYTEA, $4, $5::INT, $6::INT, $7::INT
+			FROM evm.log_poller_filters WHERE evm_chain_id = $1 AND name = $2`,
+			ubig.New(o.chainID), filter.Name, field.event.ID.Bytes(), field.arg.Name, topic, field.word, size,
+		); err != nil {
+			return err
+		}
+	}
+	_, err := o.ds.ExecContext(ctx, eventFieldsInsertQuery(`AND f.name = $2`), ubig.New(o.chainID), filter.Name)
 	return err
 }
 
+// eventFieldsInsertQuery returns the query indexing the fields of the logs of the chain $1 indexed by the filters,
+// restricted by clause.
+func eventFieldsInsertQuery(clause string) string {
+	return `INSERT INTO evm.log_poller_event_fields (log_id, evm_chain_id, event_sig, field, value)
+		SELECT l.id, l.evm_chain_id, l.event_sig, f.field, evm.f_log_poller_event_field(l.topics, l.data, f.topic, f.word, f.size)
+		FROM evm.logs l
+		JOIN evm.log_poller_filter_fields f
+			ON f.evm_chain_id = l.evm_chain_id AND f.address = l.address AND f.event_sig = l.event_sig
+		WHERE l.evm_chain_id = $1 ` + clause + `
+		ON CONFLICT DO NOTHING`
+}
+
 // DeleteFilter removes all events,address pairs associated with the Filter
 func (o *DSORM) DeleteFilter(ctx context.Context, name string) error {
-	_, err := o.ds.ExecContext(ctx,
-		`DELETE FROM evm.log_poller_filters WHERE name = $1 AND evm_chain_id = $2`,
-		name, ubig.New(o.chainID))
-	return err
+	tables := []string{"evm.log_poller_filters"}
+	for _, table := range []string{"evm.log_poller_filter_events", "evm.log_poller_filter_fields"} {
+		exists, err := o.hasTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		if exists {
+			tables = append(tables, table)
+		}
+	}
+	return o.Transact(ctx, func(orm *DSORM) error {
+		for _, table := range tables {
+			if _, err := orm.ds.ExecContext(ctx,
+				`DELETE FROM `+table+` WHERE name = $1 AND evm_chain_id = $2`,
+				name, ubig.New(orm.chainID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // LoadFilters returns all filters for this chain
 func (o *DSORM) LoadFilters(ctx context.Context) (map[string]Filter, error) {
+	hasEvents, err := o.hasTable(ctx, "evm.log_poller_filter_events")
+	if err != nil {
+		return nil, err
+	}
+	eventABIs := `NULL::JSONB`
+	if hasEvents {
+		eventABIs = `(SELECT events FROM evm.log_poller_filter_events e WHERE e.evm_chain_id = $1 AND e.name = f.name)`
+	}
 	query := `SELECT name,
 			ARRAY_AGG(DISTINCT address)::BYTEA[] AS addresses,
 			ARRAY_AGG(DISTINCT event)::BYTEA[] AS event_sigs,
@@ -175,16 +321,46 @@ func (o *DSORM) LoadFilters(ctx context.Context) (map[string]Filter, error) {
 			ARRAY_AGG(DISTINCT topic4 ORDER BY topic4) FILTER(WHERE topic4 IS NOT NULL) AS topic4,
 			MAX(logs_per_block) AS logs_per_block,
 			MAX(retention) AS retention,
-			MAX(max_logs_kept) AS max_logs_kept
-		FROM evm.log_poller_filters WHERE evm_chain_id = $1
+			MAX(max_logs_kept) AS max_logs_kept,
+			` + eventABIs + ` AS event_abis
+		FROM evm.log_poller_filters f WHERE evm_chain_id = $1
 		GROUP BY name`
-	var rows []Filter
-	err := o.ds.SelectContext(ctx, &rows, query, ubig.New(o.chainID))
+	var rows []struct {
+		Filter
+		EventABIs []byte `db:"event_abis"`
+	}
+	if err := o.ds.SelectContext(ctx, &rows, query, ubig.New(o.chainID)); err != nil {
+		return nil, err
+	}
 	filters := make(map[string]Filter)
-	for _, filter := range rows {
+	for _, row := range rows {
+		filter := row.Filter
+		if filter.Events, filter.IndexedFields, err = unmarshalEventABIs(row.EventABIs); err != nil {
+			return nil, fmt.Errorf("failed to decode the event ABIs of filter %q: %w", filter.Name, err)
+		}
 		filters[filter.Name] = filter
 	}
-	return filters, err
+	return filters, nil
+}
+
+func (o *DSORM) SelectFilterStartBlocks(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Name       string
+		StartBlock int64 `db:"start_block"`
+	}
+	err := o.ds.SelectContext(ctx, &rows, `SELECT f.name, s.start_block
+		FROM (SELECT name, MAX(created_at) AS created_at FROM evm.log_poller_filters WHERE evm_chain_id = $1 GROUP BY name) f,
+		LATERAL (SELECT MIN(block_number) AS start_block FROM evm.log_poller_blocks
+			WHERE evm_chain_id = $1 AND created_at > f.created_at) s
+		WHERE s.start_block IS NOT NULL`, ubig.New(o.chainID))
+	if err != nil {
+		return nil, err
+	}
+	starts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		starts[row.Name] = row.StartBlock
+	}
+	return starts, nil
 }
 
 func blocksQuery(clause string) string {
@@ -270,6 +446,46 @@ func (o *DSORM) SelectLatestFinalizedBlock(ctx context.Context) (*Block, error)
 	return &b, nil
 }
 
+// SelectReplayCheckpoint returns sql.ErrNoRows when there is no checkpoint, including when evm.log_poller_replay_checkpoints
+// doesn't exist.
+func (o *DSORM) SelectReplayCheckpoint(ctx context.Context) (*ReplayCheckpoint, error) {
+	if ok, err := o.hasTable(ctx, "evm.log_poller_replay_checkpoints"); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrNoRows
+	}
+	var c ReplayCheckpoint
+	if err := o.ds.GetContext(ctx, &c,
+		`SELECT from_block, next_block, filters_hash FROM evm.log_poller_replay_checkpoints WHERE evm_chain_id = $1`,
+		ubig.New(o.chainID),
+	); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// UpsertReplayCheckpoint is a no-op when evm.log_poller_replay_checkpoints doesn't exist.
+func (o *DSORM) UpsertReplayCheckpoint(ctx context.Context, checkpoint ReplayCheckpoint) error {
+	if ok, err := o.hasTable(ctx, "evm.log_poller_replay_checkpoints"); err != nil || !ok {
+		return err
+	}
+	_, err := o.ds.ExecContext(ctx, `INSERT INTO evm.log_poller_replay_checkpoints
+			(evm_chain_id, from_block, next_block, filters_hash, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (evm_chain_id) DO UPDATE SET
+			from_block = EXCLUDED.from_block, next_block = EXCLUDED.next_block, filters_hash = EXCLUDED.filters_hash, updated_at = NOW()`,
+		ubig.New(o.chainID), checkpoint.FromBlock, checkpoint.NextBlock, checkpoint.FiltersHash.Bytes())
+	return err
+}
+
+func (o *DSORM) DeleteReplayCheckpoint(ctx context.Context) error {
+	if ok, err := o.hasTable(ctx, "evm.log_poller_replay_checkpoints"); err != nil || !ok {
+		return err
+	}
+	_, err := o.ds.ExecContext(ctx, `DELETE FROM evm.log_poller_replay_checkpoints WHERE evm_chain_id = $1`, ubig.New(o.chainID))
+	return err
+}
+
 func (o *DSORM) SelectOldestBlock(ctx context.Context, minAllowedBlockNumber int64) (*Block, error) {
 	var b Block
 	if err := o.ds.GetContext(ctx, &b,
@@ -461,20 +677,25 @@ func (o *DSORM) SelectUnmatchedLogIDs(ctx context.Context, limit int64) (ids []u
 	return r.AllResults(), err
 }
 
-// SelectExcessLogIDs finds any logs old enough that MaxLogsKept has been exceeded for every filter they match.
+// SelectExcessLogIDs finds any logs old enough that MaxLogsKept has been exceeded for every filter they match, or
+// beyond the LogsPerBlock first logs of their block for every filter they match.
 func (o *DSORM) SelectExcessLogIDs(ctx context.Context, limit int64) (results []uint64, err error) {
 	// Roll up the filter table into 1 row per filter
 	withSubQuery := `
 		SELECT name,
 				ARRAY_AGG(address) AS addresses, ARRAY_AGG(event) AS events,
-				MAX(max_logs_kept) AS max_logs_kept -- Should all be the same, just need MAX for GROUP BY
+				MAX(max_logs_kept) AS max_logs_kept, -- Should all be the same, just need MAX for GROUP BY
+				MAX(logs_per_block) AS logs_per_block
 			FROM evm.log_poller_filters WHERE evm_chain_id=$1
 			GROUP BY name`
 
-	// Count logs matching each filter in reverse order, labeling anything after the filter.max_logs_kept'th with old=true
+	// Count logs matching each filter in reverse order, labeling anything after the filter.max_logs_kept'th with old=true.
+	// Like at ingestion, anything after the filter.logs_per_block'th log of a block by log index is labeled old=true too.
 	countLogsSubQuery := `
-		SELECT l.id, block_number, log_index, max_logs_kept != 0 AND
-				ROW_NUMBER() OVER(PARTITION BY f.name ORDER BY block_number, log_index DESC) > max_logs_kept AS old
+		SELECT l.id, block_number, log_index, (max_logs_kept != 0 AND
+				ROW_NUMBER() OVER(PARTITION BY f.name ORDER BY block_number, log_index DESC) > max_logs_kept) OR
+				(logs_per_block != 0 AND
+				ROW_NUMBER() OVER(PARTITION BY f.name, block_number ORDER BY log_index) > logs_per_block) AS old
 			FROM filters f JOIN evm.logs l ON
 				l.address = ANY(f.addresses) AND l.event_sig = ANY(f.events)
 			WHERE evm_chain_id = $1 AND block_number >= $2 AND block_number <= $3
@@ -587,7 +808,23 @@ func (o *DSORM) insertLogsWithinTx(ctx context.Context, logs []Log, tx sqlutil.D
 			return err
 		}
 	}
-	return nil
+	return o.insertEventFields(ctx, logs, tx)
+}
+
+// insertEventFields indexes the fields of the logs inserted which are indexed by the filters.
+func (o *DSORM) insertEventFields(ctx context.Context, logs []Log, tx sqlutil.DataSource) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	if ok, err := o.hasTable(ctx, "evm.log_poller_event_fields"); err != nil || !ok {
+		return err
+	}
+	minBlock, maxBlock := logs[0].BlockNumber, logs[0].BlockNumber
+	for _, l := range logs[1:] {
+		minBlock, maxBlock = min(minBlock, l.BlockNumber), max(maxBlock, l.BlockNumber)
+	}
+	_, err := tx.ExecContext(ctx, eventFieldsInsertQuery(`AND l.block_number BETWEEN $2 AND $3`), ubig.New(o.chainID), minBlock, maxBlock)
+	return err
 }
 
 func (o *DSORM) validateLogs(logs []Log) error {
@@ -1130,7 +1367,11 @@ func (o *DSORM) SelectIndexedLogsWithSigsExcluding(ctx context.Context, sigA, si
 }
 
 func (o *DSORM) FilteredLogs(ctx context.Context, filter []query.Expression, limitAndSort query.LimitAndSort, _ string) ([]Log, error) {
-	qs, args, err := (&pgDSLParser{}).buildQuery(o.chainID, filter, limitAndSort)
+	fieldIndex, err := o.hasTable(ctx, "evm.log_poller_event_fields")
+	if err != nil {
+		return nil, err
+	}
+	qs, args, err := (&pgDSLParser{fieldIndex: fieldIndex}).buildQuery(o.chainID, filter, limitAndSort)
 	if err != nil {
 		return nil, err
 	}
@@ -77,9 +77,13 @@ func GenLogWithData(chainID *big.Int, address common.Address, eventSig common.Ha
 }
 
 func TestLogPoller_Batching(t *testing.T) {
+	forEachORMBackend(t, testLogPoller_Batching)
+}
+
+func testLogPoller_Batching(t *testing.T, backend ORMBackend) {
 	t.Parallel()
 	ctx := testutils.Context(t)
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	var logs []logpoller.Log
 	// Inserts are limited to 65535 parameters. A log being 10 parameters this results in
 	// a maximum of 6553 log inserts per tx. As inserting more than 6553 would result in
@@ -95,7 +99,11 @@ func TestLogPoller_Batching(t *testing.T) {
 }
 
 func TestORM_GetBlocks_From_Range(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testORM_GetBlocks_From_Range)
+}
+
+func testORM_GetBlocks_From_Range(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	ctx := testutils.Context(t)
 	// Insert many blocks and read them back together
@@ -151,7 +159,11 @@ func TestORM_GetBlocks_From_Range(t *testing.T) {
 }
 
 func TestORM_GetBlocks_From_Range_Recent_Blocks(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testORM_GetBlocks_From_Range_Recent_Blocks)
+}
+
+func testORM_GetBlocks_From_Range_Recent_Blocks(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	ctx := testutils.Context(t)
 	// Insert many blocks and read them back together
@@ -184,8 +196,12 @@ func TestORM_GetBlocks_From_Range_Recent_Blocks(t *testing.T) {
 }
 
 func TestORM(t *testing.T) {
+	forEachORMBackend(t, testORM)
+}
+
+func testORM(t *testing.T, backend ORMBackend) {
 	t.Parallel()
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	o2 := th.ORM2
 	ctx := testutils.Context(t)
@@ -543,8 +559,12 @@ func TestORM(t *testing.T) {
 }
 
 func TestORM_SelectExcessLogs(t *testing.T) {
+	forEachORMBackend(t, testORM_SelectExcessLogs)
+}
+
+func testORM_SelectExcessLogs(t *testing.T, backend ORMBackend) {
 	t.Parallel()
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	o2 := th.ORM2
 	ctx := testutils.Context(t)
@@ -698,11 +718,82 @@ func TestORM_SelectExcessLogs(t *testing.T) {
 	assert.Equal(t, int64(8), deleted)
 }
 
+func TestORM_SelectExcessLogs_LogsPerBlock(t *testing.T) {
+	forEachORMBackend(t, testORM_SelectExcessLogs_LogsPerBlock)
+}
+
+func testORM_SelectExcessLogs_LogsPerBlock(t *testing.T, backend ORMBackend) {
+	t.Parallel()
+	th := SetupTHWithBackend(t, lpOpts, backend)
+	o1 := th.ORM
+	ctx := testutils.Context(t)
+
+	topic := common.HexToHash("0x1599")
+	address := common.HexToAddress("0x1234")
+	for i := int64(0); i < 2; i++ {
+		blockNumber := 10 + i
+		blockHash := common.BigToHash(big.NewInt(blockNumber))
+		var logs []logpoller.Log
+		for j := int64(4); j >= 0; j-- {
+			logs = append(logs, logpoller.Log{
+				EVMChainID:     ubig.New(th.ChainID),
+				LogIndex:       j,
+				BlockHash:      blockHash,
+				BlockNumber:    blockNumber,
+				EventSig:       topic,
+				Topics:         [][]byte{topic[:]},
+				Address:        address,
+				TxHash:         common.HexToHash("0x1888"),
+				Data:           []byte("hello"),
+				BlockTimestamp: time.Now(),
+			})
+		}
+		require.NoError(t, o1.InsertLogsWithBlock(ctx, logs, logpoller.Block{BlockHash: blockHash, BlockNumber: blockNumber, BlockTimestamp: time.Now(), FinalizedBlockNumber: blockNumber}))
+	}
+
+	require.NoError(t, o1.InsertFilter(ctx, logpoller.Filter{
+		Name:         "LogsPerBlock = 2",
+		Addresses:    []common.Address{address},
+		EventSigs:    types.HashArray{topic},
+		LogsPerBlock: 2,
+	}))
+
+	// The logs after the first 2 of each block by log index are excess.
+	ids, err := o1.SelectExcessLogIDs(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, ids, 6)
+
+	// Unless another filter matching them keeps them.
+	require.NoError(t, o1.InsertFilter(ctx, logpoller.Filter{
+		Name:      "Unlimited",
+		Addresses: []common.Address{address},
+		EventSigs: types.HashArray{topic},
+	}))
+	ids, err = o1.SelectExcessLogIDs(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	require.NoError(t, o1.DeleteFilter(ctx, "Unlimited"))
+
+	ids, err = o1.SelectExcessLogIDs(ctx, 0)
+	require.NoError(t, err)
+	deleted, err := o1.DeleteLogsByRowID(ctx, ids)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), deleted)
+
+	logs, err := o1.SelectLogsByBlockRange(ctx, 10, 11)
+	require.NoError(t, err)
+	var kept []int64
+	for _, l := range logs {
+		kept = append(kept, l.BlockNumber*10+l.LogIndex)
+	}
+	assert.ElementsMatch(t, []int64{100, 101, 110, 111}, kept)
+}
+
 func TestLogPollerFilters(t *testing.T) {
 	lggr := logger.Test(t)
 	chainID := testutils.NewRandomEVMChainID()
 
-	dbx := testutils.NewSqlxDB(t)
+	dbx := logpoller.NewTestSqlxDB(t)
 	orm := logpoller.NewORM(chainID, dbx, lggr)
 
 	event1 := EmitterABI.Events["Log1"].ID
@@ -801,7 +892,11 @@ func insertLogsTopicValueRange(t *testing.T, chainID *big.Int, o logpoller.ORM,
 }
 
 func TestORM_IndexedLogs(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testORM_IndexedLogs)
+}
+
+func testORM_IndexedLogs(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	ctx := testutils.Context(t)
 	eventSig := common.HexToHash("0x1599")
@@ -968,7 +1063,11 @@ func TestORM_IndexedLogs(t *testing.T) {
 }
 
 func TestORM_SelectIndexedLogsByTxHash(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testORM_SelectIndexedLogsByTxHash)
+}
+
+func testORM_SelectIndexedLogsByTxHash(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	ctx := testutils.Context(t)
 	eventSig := common.HexToHash("0x1599")
@@ -1051,7 +1150,11 @@ func TestORM_SelectIndexedLogsByTxHash(t *testing.T) {
 }
 
 func TestORM_DataWords(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testORM_DataWords)
+}
+
+func testORM_DataWords(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	ctx := testutils.Context(t)
 	eventSig := common.HexToHash("0x1599")
@@ -1167,7 +1270,11 @@ func TestORM_DataWords(t *testing.T) {
 }
 
 func TestORM_SelectLogsWithSigsByBlockRangeFilter(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testORM_SelectLogsWithSigsByBlockRangeFilter)
+}
+
+func testORM_SelectLogsWithSigsByBlockRangeFilter(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	ctx := testutils.Context(t)
 
@@ -1321,7 +1428,7 @@ func Test_ExecPagedQuery(t *testing.T) {
 	ctx := testutils.Context(t)
 	lggr := logger.Test(t)
 	chainID := testutils.NewRandomEVMChainID()
-	db := testutils.NewSqlxDB(t)
+	db := logpoller.NewTestSqlxDB(t)
 	o := logpoller.NewORM(chainID, db, lggr)
 
 	m := mockQueryExecutor{}
@@ -1372,7 +1479,11 @@ func Test_ExecPagedQuery(t *testing.T) {
 }
 
 func TestORM_DeleteBlocksBefore(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testORM_DeleteBlocksBefore)
+}
+
+func testORM_DeleteBlocksBefore(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	ctx := testutils.Context(t)
 	require.NoError(t, o1.InsertBlock(ctx, common.HexToHash("0x1234"), 1, time.Now(), 0))
@@ -1399,9 +1510,13 @@ func TestORM_DeleteBlocksBefore(t *testing.T) {
 }
 
 func TestLogPoller_Logs(t *testing.T) {
+	forEachORMBackend(t, testLogPoller_Logs)
+}
+
+func testLogPoller_Logs(t *testing.T, backend ORMBackend) {
 	t.Parallel()
 	ctx := testutils.Context(t)
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	event1 := EmitterABI.Events["Log1"].ID
 	event2 := EmitterABI.Events["Log2"].ID
 	address1 := common.HexToAddress("0x2ab9a2Dc53736b361b72d900CdF9F78F9406fbbb")
@@ -1508,7 +1623,11 @@ func BenchmarkLogs(b *testing.B) {
 }
 
 func TestSelectLogsWithSigsExcluding(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testSelectLogsWithSigsExcluding)
+}
+
+func testSelectLogsWithSigsExcluding(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	orm := th.ORM
 	ctx := testutils.Context(t)
 	addressA := common.HexToAddress("0x11111")
@@ -1755,8 +1874,12 @@ func TestSelectLogsWithSigsExcluding(t *testing.T) {
 }
 
 func TestSelectLatestBlockNumberEventSigsAddrsWithConfs(t *testing.T) {
+	forEachORMBackend(t, testSelectLatestBlockNumberEventSigsAddrsWithConfs)
+}
+
+func testSelectLatestBlockNumberEventSigsAddrsWithConfs(t *testing.T, backend ORMBackend) {
 	ctx := testutils.Context(t)
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	event1 := EmitterABI.Events["Log1"].ID
 	event2 := EmitterABI.Events["Log2"].ID
 	address1 := utils.RandomAddress()
@@ -1853,8 +1976,12 @@ func TestSelectLatestBlockNumberEventSigsAddrsWithConfs(t *testing.T) {
 }
 
 func TestSelectLogsCreatedAfter(t *testing.T) {
+	forEachORMBackend(t, testSelectLogsCreatedAfter)
+}
+
+func testSelectLogsCreatedAfter(t *testing.T, backend ORMBackend) {
 	ctx := testutils.Context(t)
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	event := EmitterABI.Events["Log1"].ID
 	address := utils.RandomAddress()
 
@@ -2008,8 +2135,12 @@ func TestSelectLogsCreatedAfter(t *testing.T) {
 }
 
 func TestNestedLogPollerBlocksQuery(t *testing.T) {
+	forEachORMBackend(t, testNestedLogPollerBlocksQuery)
+}
+
+func testNestedLogPollerBlocksQuery(t *testing.T, backend ORMBackend) {
 	ctx := testutils.Context(t)
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	event := EmitterABI.Events["Log1"].ID
 	address := utils.RandomAddress()
 
@@ -2179,10 +2310,14 @@ func TestInsertLogsInTx(t *testing.T) {
 }
 
 func TestSelectLogsDataWordBetween(t *testing.T) {
+	forEachORMBackend(t, testSelectLogsDataWordBetween)
+}
+
+func testSelectLogsDataWordBetween(t *testing.T, backend ORMBackend) {
 	ctx := testutils.Context(t)
 	address := utils.RandomAddress()
 	eventSig := utils.RandomBytes32()
-	th := SetupTH(t, lpOpts)
+	th := SetupTHWithBackend(t, lpOpts, backend)
 
 	firstLogData := make([]byte, 0, 64)
 	firstLogData = append(firstLogData, logpoller.EvmWord(1).Bytes()...)
@@ -2385,7 +2520,11 @@ func Benchmark_DeleteExpiredLogs(b *testing.B) {
 }
 
 func TestSelectOldestBlock(t *testing.T) {
-	th := SetupTH(t, lpOpts)
+	forEachORMBackend(t, testSelectOldestBlock)
+}
+
+func testSelectOldestBlock(t *testing.T, backend ORMBackend) {
+	th := SetupTHWithBackend(t, lpOpts, backend)
 	o1 := th.ORM
 	o2 := th.ORM2
 	ctx := testutils.Context(t)
@@ -2415,8 +2554,12 @@ func TestSelectOldestBlock(t *testing.T) {
 }
 
 func TestSelectLatestFinalizedBlock(t *testing.T) {
+	forEachORMBackend(t, testSelectLatestFinalizedBlock)
+}
+
+func testSelectLatestFinalizedBlock(t *testing.T, backend ORMBackend) {
 	t.Run("If finalized block is not present in DB return error", func(t *testing.T) {
-		th := SetupTH(t, lpOpts)
+		th := SetupTHWithBackend(t, lpOpts, backend)
 		o1 := th.ORM
 		o2 := th.ORM2
 		ctx := testutils.Context(t)
@@ -2431,7 +2574,7 @@ func TestSelectLatestFinalizedBlock(t *testing.T) {
 		require.Nil(t, result)
 	})
 	t.Run("Returns latest finalized block even if there is no exact match by block number", func(t *testing.T) {
-		th := SetupTH(t, lpOpts)
+		th := SetupTHWithBackend(t, lpOpts, backend)
 		o1 := th.ORM
 		ctx := testutils.Context(t)
 		require.NoError(t, o1.InsertBlock(ctx, common.HexToHash("0x1233"), 12, time.Now(), 10))
@@ -36,6 +36,8 @@ var (
 // values after every call.
 type pgDSLParser struct {
 	args *queryArgs
+	// fieldIndex is whether evm.log_poller_event_fields exists, in which the indexed event fields are queried.
+	fieldIndex bool
 
 	// transient properties expected to be set and reset with every expression
 	expression string
@@ -191,6 +193,59 @@ func (v *pgDSLParser) VisitEventTopicsByValueFilter(p *eventByTopicFilter) {
 	v.expression = strings.Join(comps, " AND ")
 }
 
+func (v *pgDSLParser) VisitEventByFieldFilter(p *eventByFieldFilter) {
+	if p.field == nil {
+		v.err = errUnresolvedEventField
+
+		return
+	}
+
+	cmp, err := cmpOpToString(p.Operator)
+	if err != nil {
+		v.err = err
+
+		return
+	}
+
+	if v.fieldIndex && p.indexed {
+		eventSig := v.args.withIndexedField("field_event_sig", p.field.event.ID)
+		v.expression = fmt.Sprintf(
+			"(%s = :%s AND id IN (SELECT log_id FROM evm.log_poller_event_fields WHERE evm_chain_id = :evm_chain_id AND event_sig = :%s AND field = :%s AND value %s :%s))",
+			eventSigFieldName,
+			eventSig,
+			eventSig,
+			v.args.withIndexedField("field_name", p.field.arg.Name),
+			cmp,
+			v.args.withIndexedField("field_value", p.encoded),
+		)
+
+		return
+	}
+
+	var columnName string
+	switch field := p.field; {
+	case field.arg.Indexed:
+		// Add 1 since postgresql arrays are 1-indexed.
+		columnName = fmt.Sprintf("topics[%d]", field.topic+1)
+	case isDynamicType(field.arg.Type):
+		// The head of a dynamic field is the offset of its value, read from its last 4 bytes.
+		columnName = fmt.Sprintf(
+			"substring(data from ('x' || encode(substring(data from 32*%d+29 for 4), 'hex'))::bit(32)::int+1 for %d)",
+			field.word, len(p.encoded))
+	default:
+		columnName = fmt.Sprintf("substring(data from 32*%d+1 for %d)", field.word, len(p.encoded))
+	}
+
+	v.expression = fmt.Sprintf(
+		"(%s = :%s AND %s %s :%s)",
+		eventSigFieldName,
+		v.args.withIndexedField("field_event_sig", p.field.event.ID),
+		columnName,
+		cmp,
+		v.args.withIndexedField("field_value", p.encoded),
+	)
+}
+
 func (v *pgDSLParser) VisitConfirmationsFilter(p *confirmationsFilter) {
 	switch p.Confirmations {
 	case evmtypes.Finalized:
@@ -480,6 +535,8 @@ func (f *addressFilter) Accept(visitor primitives.Visitor) {
 	switch v := visitor.(type) {
 	case *pgDSLParser:
 		v.VisitAddressFilter(f)
+	case *embeddedDSLParser:
+		v.VisitAddressFilter(f)
 	}
 }
 
@@ -497,6 +554,8 @@ func (f *eventSigFilter) Accept(visitor primitives.Visitor) {
 	switch v := visitor.(type) {
 	case *pgDSLParser:
 		v.VisitEventSigFilter(f)
+	case *embeddedDSLParser:
+		v.VisitEventSigFilter(f)
 	}
 }
 
@@ -521,6 +580,8 @@ func (f *eventByWordFilter) Accept(visitor primitives.Visitor) {
 	switch v := visitor.(type) {
 	case *pgDSLParser:
 		v.VisitEventByWordFilter(f)
+	case *embeddedDSLParser:
+		v.VisitEventByWordFilter(f)
 	}
 }
 
@@ -540,6 +601,8 @@ func (f *eventByTopicFilter) Accept(visitor primitives.Visitor) {
 	switch v := visitor.(type) {
 	case *pgDSLParser:
 		v.VisitEventTopicsByValueFilter(f)
+	case *embeddedDSLParser:
+		v.VisitEventTopicsByValueFilter(f)
 	}
 }
 
@@ -557,5 +620,46 @@ func (f *confirmationsFilter) Accept(visitor primitives.Visitor) {
 	switch v := visitor.(type) {
 	case *pgDSLParser:
 		v.VisitConfirmationsFilter(f)
+	case *embeddedDSLParser:
+		v.VisitConfirmationsFilter(f)
+	}
+}
+
+// eventByFieldFilter compares a field of an event, by name in its ABI, to a value. The ABI of the event is found in the
+// registered filters by LogPoller.FilteredLogs.
+type eventByFieldFilter struct {
+	EventName string
+	Field     string
+	Operator  primitives.ComparisonOperator
+	Value     any
+
+	// set by LogPoller.FilteredLogs
+	field   *eventField
+	encoded []byte
+	indexed bool
+}
+
+// NewEventByFieldFilter compares the field of the event eventName to value, which must be of the Go type used by the
+// abi package for the type of the field. Fields of dynamic types, strings, bytes, slices and tuples or arrays of them,
+// can only be compared for equality. So can indexed fields of hashed types, for which value is hashed like in topics.
+//
+// DSORM queries the field in its index when every registered filter with the event has it in its IndexedFields.
+// Otherwise, fields which aren't indexed in the event are read from the data of the logs, so the other expressions of
+// the query, e.g. the address and a block range, should narrow down the logs scanned.
+func NewEventByFieldFilter(eventName, field string, operator primitives.ComparisonOperator, value any) query.Expression {
+	return query.Expression{Primitive: &eventByFieldFilter{
+		EventName: eventName,
+		Field:     field,
+		Operator:  operator,
+		Value:     value,
+	}}
+}
+
+func (f *eventByFieldFilter) Accept(visitor primitives.Visitor) {
+	switch v := visitor.(type) {
+	case *pgDSLParser:
+		v.VisitEventByFieldFilter(f)
+	case *embeddedDSLParser:
+		v.VisitEventByFieldFilter(f)
 	}
 }
@@ -2,8 +2,10 @@ package logpoller
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -273,6 +275,77 @@ func TestDSLParser(t *testing.T) {
 		assertArgs(t, args, 3)
 	})
 
+	t.Run("query for event field", func(t *testing.T) {
+		t.Parallel()
+
+		parsed, err := abi.JSON(strings.NewReader(transferABI))
+		require.NoError(t, err)
+		event := parsed.Events["Transfer"]
+		fieldFilter := func(name string, op primitives.ComparisonOperator, value any) query.Expression {
+			exp := NewEventByFieldFilter("Transfer", name, op, value)
+			p := exp.Primitive.(*eventByFieldFilter)
+			p.field, err = locateEventField(&event, name)
+			require.NoError(t, err)
+			p.encoded, err = p.field.encode(op, value)
+			require.NoError(t, err)
+			return exp
+		}
+
+		parser := &pgDSLParser{}
+		chainID := big.NewInt(1)
+		expressions := []query.Expression{
+			fieldFilter("to", primitives.Eq, common.HexToAddress("0x42")),
+			fieldFilter("value", primitives.Gt, big.NewInt(1)),
+			fieldFilter("memo", primitives.Eq, "rent"),
+		}
+		limiter := query.LimitAndSort{}
+
+		result, args, err := parser.buildQuery(chainID, expressions, limiter)
+		expected := logsQuery(
+			" WHERE evm_chain_id = :evm_chain_id " +
+				"AND ((event_sig = :field_event_sig_0 AND topics[3] = :field_value_0) " +
+				"AND (event_sig = :field_event_sig_1 AND substring(data from 32*0+1 for 32) > :field_value_1) " +
+				"AND (event_sig = :field_event_sig_2 AND substring(data from ('x' || encode(substring(data from 32*1+29 for 4), 'hex'))::bit(32)::int+1 for 64) = :field_value_2)) " +
+				"ORDER BY " + defaultSort)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		assertArgs(t, args, 7)
+
+		t.Run("indexed", func(t *testing.T) {
+			indexed := fieldFilter("memo", primitives.Eq, "rent")
+			indexed.Primitive.(*eventByFieldFilter).indexed = true
+
+			result, args, err := (&pgDSLParser{fieldIndex: true}).buildQuery(chainID, []query.Expression{indexed, expressions[1]}, limiter)
+			expected := logsQuery(
+				" WHERE evm_chain_id = :evm_chain_id " +
+					"AND ((event_sig = :field_event_sig_0 AND id IN (SELECT log_id FROM evm.log_poller_event_fields " +
+					"WHERE evm_chain_id = :evm_chain_id AND event_sig = :field_event_sig_0 AND field = :field_name_0 AND value = :field_value_0)) " +
+					"AND (event_sig = :field_event_sig_1 AND substring(data from 32*0+1 for 32) > :field_value_1)) " +
+					"ORDER BY " + defaultSort)
+
+			require.NoError(t, err)
+			assert.Equal(t, expected, result)
+			assertArgs(t, args, 6)
+
+			// Without the index table, the field is read from the logs.
+			result, _, err = parser.buildQuery(chainID, []query.Expression{indexed}, limiter)
+			require.NoError(t, err)
+			assert.Contains(t, result, "substring(data from ('x'")
+		})
+	})
+
+	t.Run("unresolved event field", func(t *testing.T) {
+		t.Parallel()
+
+		parser := &pgDSLParser{}
+		expressions := []query.Expression{NewEventByFieldFilter("Transfer", "to", primitives.Eq, common.HexToAddress("0x42"))}
+
+		_, _, err := parser.buildQuery(big.NewInt(1), expressions, query.LimitAndSort{})
+		require.ErrorIs(t, err, errUnresolvedEventField)
+	})
+
 	// nested query -> a & (b || c)
 	t.Run("nested query", func(t *testing.T) {
 		t.Parallel()
@@ -0,0 +1,346 @@
+package logpoller
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/binary"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+var (
+	promLpQueryPlanQueries = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "log_poller_query_plan_queries",
+		Help: "Number of eth_getLogs queries made for each block range polled by the log poller",
+	}, []string{"evmChainID"})
+	promLpQueryPlanAddressEventPairs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "log_poller_query_plan_address_event_pairs",
+		Help: "Number of (address, event) pairs fetched by the log poller queries. The plan label distinguishes the optimized query plan from a single query merging all filters",
+	}, []string{"evmChainID", "plan"})
+	promLpQueryPlanLogsFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_query_plan_logs_fetched",
+		Help: "Number of logs returned by the eth_getLogs queries of the log poller",
+	}, []string{"evmChainID"})
+	promLpQueryPlanLogsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_poller_query_plan_logs_dropped",
+		Help: "Number of logs fetched by the log poller which didn't match any filter and weren't saved",
+	}, []string{"evmChainID"})
+)
+
+// logQuery is an eth_getLogs query of a logQueryPlan, without the block range.
+type logQuery struct {
+	addresses []common.Address
+	topics    [][]common.Hash
+}
+
+// logQueryPlan is the set of eth_getLogs queries fetching the logs of the registered filters.
+//
+// Merging all the filters into a single query fetches the logs of every registered address for every registered event,
+// and ignores the topic constraints of the filters. Instead, filters with the same events and topic constraints are
+// grouped into a query for the union of their addresses, which fetches exactly their logs. Then, queries for the same
+// addresses and topic2-4 constraints are merged into a query for the union of their events. Queries are split when
+// they exceed the maximum number of addresses supported by the RPC.
+type logQueryPlan struct {
+	queries []logQuery
+	filters []Filter
+
+	// addressEventPairs is the number of (address, event) pairs fetched by the plan, while mergedAddressEventPairs is
+	// the number fetched by a single query merging all the filters.
+	addressEventPairs       int
+	mergedAddressEventPairs int
+}
+
+// newLogQueryPlan builds the query plan for filters. If maxAddresses is positive, no query has more addresses.
+func newLogQueryPlan(filters map[string]Filter, maxAddresses int) *logQueryPlan {
+	plan := &logQueryPlan{}
+	if len(filters) == 0 {
+		// If no filter specified, ignore everything.
+		// This allows us to keep the log poller up and running with no filters present (e.g. no jobs on the node),
+		// then as jobs are added dynamically start using their filters.
+		plan.queries = []logQuery{{
+			addresses: []common.Address{common.HexToAddress("0x0000000000000000000000000000000000000000")},
+			topics:    [][]common.Hash{{}},
+		}}
+		return plan
+	}
+
+	type group struct {
+		addresses []common.Address
+		topics    [4][]common.Hash
+	}
+
+	// Group the filters by topic constraints, including the events.
+	byTopics := make(map[string]*group)
+	mergedAddresses := make(map[common.Address]struct{})
+	mergedEvents := make(map[common.Hash]struct{})
+	type addressEvent struct {
+		address common.Address
+		event   common.Hash
+	}
+	pairs := make(map[addressEvent]struct{})
+	for _, filter := range filters {
+		plan.filters = append(plan.filters, filter)
+		topics := [4][]common.Hash{
+			sortedHashes(filter.EventSigs),
+			sortedHashes(filter.Topic2),
+			sortedHashes(filter.Topic3),
+			sortedHashes(filter.Topic4),
+		}
+		key := hashesKey(topics[:]...)
+		g, ok := byTopics[key]
+		if !ok {
+			g = &group{topics: topics}
+			byTopics[key] = g
+		}
+		g.addresses = append(g.addresses, filter.Addresses...)
+
+		for _, addr := range filter.Addresses {
+			mergedAddresses[addr] = struct{}{}
+			for _, event := range filter.EventSigs {
+				pairs[addressEvent{addr, event}] = struct{}{}
+			}
+		}
+		for _, event := range filter.EventSigs {
+			mergedEvents[event] = struct{}{}
+		}
+	}
+	plan.addressEventPairs = len(pairs)
+	plan.mergedAddressEventPairs = len(mergedAddresses) * len(mergedEvents)
+
+	// Merge the events of the groups with the same addresses and topic2-4 constraints.
+	byAddresses := make(map[string]*group)
+	for _, g := range byTopics {
+		addresses := sortedAddresses(g.addresses)
+		addressHashes := make([]common.Hash, len(addresses))
+		for i, addr := range addresses {
+			addressHashes[i] = common.BytesToHash(addr[:])
+		}
+		key := hashesKey(append([][]common.Hash{addressHashes}, g.topics[1:]...)...)
+		merged, ok := byAddresses[key]
+		if !ok {
+			merged = &group{addresses: addresses, topics: g.topics}
+			byAddresses[key] = merged
+			continue
+		}
+		merged.topics[0] = sortedHashes(append(merged.topics[0], g.topics[0]...))
+	}
+
+	for _, g := range byAddresses {
+		topics := g.topics[:]
+		// Trailing wildcards are omitted.
+		for len(topics) > 1 && len(topics[len(topics)-1]) == 0 {
+			topics = topics[:len(topics)-1]
+		}
+		// An empty constraint on topic2-4 is a wildcard, which is a nil slice for the RPC.
+		query := logQuery{topics: make([][]common.Hash, len(topics))}
+		for i, t := range topics {
+			if len(t) > 0 {
+				query.topics[i] = t
+			}
+		}
+		for addresses := range slices.Chunk(g.addresses, chunkSize(len(g.addresses), maxAddresses)) {
+			q := query
+			q.addresses = addresses
+			plan.queries = append(plan.queries, q)
+		}
+	}
+	sort.Slice(plan.queries, func(i, j int) bool {
+		return compareLogQueries(plan.queries[i], plan.queries[j]) < 0
+	})
+	return plan
+}
+
+func chunkSize(n, maxSize int) int {
+	if maxSize <= 0 || n <= maxSize {
+		return max(n, 1)
+	}
+	return maxSize
+}
+
+func compareLogQueries(a, b logQuery) int {
+	if c := slices.CompareFunc(a.addresses, b.addresses, func(x, y common.Address) int { return bytes.Compare(x[:], y[:]) }); c != 0 {
+		return c
+	}
+	return slices.CompareFunc(a.topics, b.topics, func(x, y []common.Hash) int {
+		return slices.CompareFunc(x, y, func(h1, h2 common.Hash) int { return bytes.Compare(h1[:], h2[:]) })
+	})
+}
+
+func sortedHashes(hashes []common.Hash) []common.Hash {
+	sorted := slices.Clone(hashes)
+	slices.SortFunc(sorted, func(a, b common.Hash) int { return bytes.Compare(a[:], b[:]) })
+	return slices.Compact(sorted)
+}
+
+func sortedAddresses(addresses []common.Address) []common.Address {
+	sorted := slices.Clone(addresses)
+	slices.SortFunc(sorted, func(a, b common.Address) int { return bytes.Compare(a[:], b[:]) })
+	return slices.Compact(sorted)
+}
+
+// hashesKey returns a map key identifying lists of sorted hashes.
+func hashesKey(lists ...[]common.Hash) string {
+	var key []byte
+	for _, list := range lists {
+		key = binary.BigEndian.AppendUint32(key, uint32(len(list))) //nolint:gosec // G115
+		for _, h := range list {
+			key = append(key, h[:]...)
+		}
+	}
+	return string(key)
+}
+
+// hash identifies the queries of the plan, which are the same for the same filters.
+func (p *logQueryPlan) hash() common.Hash {
+	var b []byte
+	for _, q := range p.queries {
+		addresses := make([]common.Hash, len(q.addresses))
+		for i, addr := range q.addresses {
+			addresses[i] = common.BytesToHash(addr[:])
+		}
+		key := hashesKey(append([][]common.Hash{addresses}, q.topics...)...)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(key))) //nolint:gosec // G115
+		b = append(b, key...)
+	}
+	return crypto.Keccak256Hash(b)
+}
+
+// matchingLogs returns the logs matching any filter of the plan.
+func (p *logQueryPlan) matchingLogs(logs []Log) []Log {
+	matching := make([]Log, 0, len(logs))
+	for _, l := range logs {
+		if slices.ContainsFunc(p.filters, func(filter Filter) bool { return filter.matches(&l) }) {
+			matching = append(matching, l)
+		}
+	}
+	return matching
+}
+
+// queryPlan returns the query plan for the registered filters.
+func (lp *logPoller) queryPlan() *logQueryPlan {
+	lp.filterMu.Lock()
+	defer lp.filterMu.Unlock()
+	if lp.cachedQueryPlan == nil || lp.queryPlanDirty {
+		lp.cachedQueryPlan = newLogQueryPlan(lp.filters, lp.logQueryMaxAddresses)
+		lp.queryPlanDirty = false
+	}
+	return lp.cachedQueryPlan
+}
+
+// filterLogs fetches the logs of the registered filters in the block range [from, to], or in the block bh, following
+// the query plan.
+func (lp *logPoller) filterLogs(ctx context.Context, from, to *big.Int, bh *common.Hash) ([]types.Log, *logQueryPlan, error) {
+	plan := lp.queryPlan()
+	logs, err := lp.filterLogsWithPlan(ctx, plan, from, to, bh)
+	if err != nil {
+		return nil, nil, err
+	}
+	return logs, plan, nil
+}
+
+// filterLogsWithPlan runs the queries of plan in the block range [from, to], or in the block bh. Logs fetched by
+// several queries are returned once, ordered by block number and log index.
+func (lp *logPoller) filterLogsWithPlan(ctx context.Context, plan *logQueryPlan, from, to *big.Int, bh *common.Hash) ([]types.Log, error) {
+	return plan.filterLogs(ctx, &lp.latencyMonitor, from, to, bh)
+}
+
+// filterLogs runs the queries of the plan with c, like logPoller.filterLogsWithPlan.
+func (p *logQueryPlan) filterLogs(ctx context.Context, c LatencyMonitorClient, from, to *big.Int, bh *common.Hash) ([]types.Log, error) {
+	var logs []types.Log
+	for _, q := range p.queries {
+		queryLogs, err := c.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: from, ToBlock: to, BlockHash: bh, Addresses: q.addresses, Topics: q.topics})
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, queryLogs...)
+	}
+
+	if len(p.queries) > 1 {
+		slices.SortStableFunc(logs, func(a, b types.Log) int {
+			if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Index, b.Index)
+		})
+		logs = slices.CompactFunc(logs, func(a, b types.Log) bool {
+			return a.BlockHash == b.BlockHash && a.Index == b.Index
+		})
+	}
+	return logs, nil
+}
+
+// dropUnmatchedLogs returns the logs fetched with plan which match any of its filters within their LogsPerBlock rate
+// limit, to be saved. It also reports the metrics of the plan.
+func (lp *logPoller) dropUnmatchedLogs(plan *logQueryPlan, logs []Log) []Log {
+	matching := plan.matchingLogs(logs)
+
+	chainID := lp.ec.ConfiguredChainID().String()
+	promLpQueryPlanQueries.WithLabelValues(chainID).Set(float64(len(plan.queries)))
+	promLpQueryPlanAddressEventPairs.WithLabelValues(chainID, "optimized").Set(float64(plan.addressEventPairs))
+	promLpQueryPlanAddressEventPairs.WithLabelValues(chainID, "merged").Set(float64(plan.mergedAddressEventPairs))
+	promLpQueryPlanLogsFetched.WithLabelValues(chainID).Add(float64(len(logs)))
+	promLpQueryPlanLogsDropped.WithLabelValues(chainID).Add(float64(len(logs) - len(matching)))
+	return lp.limitLogsPerBlock(plan, matching)
+}
+
+// matchingLogs returns the logs matching the filter.
+func (filter *Filter) matchingLogs(logs []Log) []Log {
+	var matching []Log
+	for _, l := range logs {
+		if filter.matches(&l) {
+			matching = append(matching, l)
+		}
+	}
+	return matching
+}
+
+func (filter *Filter) matches(l *Log) bool {
+	if !containsHash(filter.EventSigs, l.EventSig) {
+		return false
+	}
+	found := false
+	for _, addr := range filter.Addresses {
+		if addr == l.Address {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	for i, topics := range []evmtypes.HashArray{filter.Topic2, filter.Topic3, filter.Topic4} {
+		if len(topics) == 0 {
+			continue
+		}
+		if len(l.Topics) <= i+1 || !containsHash(topics, common.BytesToHash(l.Topics[i+1])) {
+			return false
+		}
+	}
+	if event := filter.eventABI(l.EventSig); event != nil {
+		// The log of an event with the same signature but a different ABI, e.g. indexing other fields.
+		if _, err := decodeEventLog(event, l); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
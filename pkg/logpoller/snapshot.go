@@ -0,0 +1,461 @@
+package logpoller
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+const (
+	// snapshotVersion is the version of the snapshot format written by ExportSnapshot.
+	snapshotVersion = 1
+	// snapshotPageSize is the number of blocks of which the logs are read at once by ExportSnapshot.
+	snapshotPageSize = 1000
+	// DefaultSnapshotSampleSize is the default number of blocks of a snapshot verified against the RPC by
+	// ImportSnapshot, in addition to its end block.
+	DefaultSnapshotSampleSize = 16
+)
+
+var ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+
+// SnapshotHeader describes the content of a snapshot: the logs of Filters in the finalized blocks [FromBlock, ToBlock].
+type SnapshotHeader struct {
+	Version   int
+	ChainID   string
+	FromBlock int64
+	ToBlock   int64
+	Filters   []SnapshotFilter
+}
+
+// SnapshotFilter is a Filter in a snapshot. The ABI of its events is not exported.
+type SnapshotFilter struct {
+	Name         string
+	Addresses    []common.Address
+	EventSigs    []common.Hash
+	Topic2       []common.Hash `json:",omitempty"`
+	Topic3       []common.Hash `json:",omitempty"`
+	Topic4       []common.Hash `json:",omitempty"`
+	Retention    time.Duration
+	MaxLogsKept  uint64
+	LogsPerBlock uint64
+}
+
+func newSnapshotFilter(filter Filter) SnapshotFilter {
+	return SnapshotFilter{
+		Name:         filter.Name,
+		Addresses:    filter.Addresses,
+		EventSigs:    filter.EventSigs,
+		Topic2:       filter.Topic2,
+		Topic3:       filter.Topic3,
+		Topic4:       filter.Topic4,
+		Retention:    filter.Retention,
+		MaxLogsKept:  filter.MaxLogsKept,
+		LogsPerBlock: filter.LogsPerBlock,
+	}
+}
+
+func (f SnapshotFilter) filter() Filter {
+	return Filter{
+		Name:         f.Name,
+		Addresses:    f.Addresses,
+		EventSigs:    f.EventSigs,
+		Topic2:       f.Topic2,
+		Topic3:       f.Topic3,
+		Topic4:       f.Topic4,
+		Retention:    f.Retention,
+		MaxLogsKept:  f.MaxLogsKept,
+		LogsPerBlock: f.LogsPerBlock,
+	}
+}
+
+// snapshotBlock is a block of a snapshot, with the logs of the snapshot's filters in it.
+type snapshotBlock struct {
+	Hash                 common.Hash
+	Number               int64
+	Timestamp            time.Time
+	FinalizedBlockNumber int64
+	Logs                 []snapshotLog `json:",omitempty"`
+}
+
+type snapshotLog struct {
+	LogIndex int64
+	TxHash   common.Hash
+	Address  common.Address
+	Topics   []hexutil.Bytes
+	Data     hexutil.Bytes
+}
+
+// snapshotLine is a line of a snapshot file. A snapshot is a header line, followed by a line per block in ascending
+// order, and a checksum line, the hex SHA-256 of all the lines before it.
+type snapshotLine struct {
+	Header   *SnapshotHeader `json:",omitempty"`
+	Block    *snapshotBlock  `json:",omitempty"`
+	Checksum string          `json:",omitempty"`
+}
+
+// ExportSnapshot writes the logs of filters saved by orm in the block range [fromBlock, toBlock] to w, along with their
+// blocks, so that ImportSnapshot can import them in the db of another node instead of fetching them from the RPC.
+//
+// The blocks must be finalized, and toBlock must be saved by orm. It's the latest block saved by ImportSnapshot, from
+// which the LogPoller of the other node polls.
+func ExportSnapshot(ctx context.Context, orm ORM, chainID *big.Int, w io.Writer, filters []Filter, fromBlock, toBlock int64) (*SnapshotHeader, error) {
+	if len(filters) == 0 {
+		return nil, pkgerrors.New("at least one filter must be specified")
+	}
+	if fromBlock < 1 || fromBlock > toBlock {
+		return nil, pkgerrors.Errorf("invalid snapshot block range [%d, %d]", fromBlock, toBlock)
+	}
+	latest, err := orm.SelectLatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if toBlock > latest.FinalizedBlockNumber {
+		return nil, pkgerrors.Errorf("snapshot end block %d is not finalized, latest finalized block is %d", toBlock, latest.FinalizedBlockNumber)
+	}
+	end, err := orm.SelectBlockByNumber(ctx, toBlock)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.Errorf("snapshot end block %d is not saved", toBlock)
+		}
+		return nil, err
+	}
+
+	header := &SnapshotHeader{Version: snapshotVersion, ChainID: chainID.String(), FromBlock: fromBlock, ToBlock: toBlock}
+	for _, filter := range filters {
+		header.Filters = append(header.Filters, newSnapshotFilter(filter))
+	}
+	matchingLogs := func(logs []Log) []Log {
+		return slices.DeleteFunc(logs, func(l Log) bool {
+			return !slices.ContainsFunc(filters, func(filter Filter) bool { return filter.matches(&l) })
+		})
+	}
+
+	sw := newSnapshotWriter(w)
+	if err = sw.write(snapshotLine{Header: header}); err != nil {
+		return nil, err
+	}
+	for start := fromBlock; start <= toBlock; start += snapshotPageSize {
+		logs, err := orm.SelectLogsByBlockRange(ctx, start, min(start+snapshotPageSize-1, toBlock))
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range snapshotBlocks(matchingLogs(logs)) {
+			if block.Number == end.BlockNumber {
+				// Written below, with the block saved.
+				continue
+			}
+			if err = sw.write(snapshotLine{Block: block}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	endLogs, err := orm.SelectLogsByBlockRange(ctx, toBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	endBlock := &snapshotBlock{Hash: end.BlockHash, Number: end.BlockNumber, Timestamp: end.BlockTimestamp, FinalizedBlockNumber: end.FinalizedBlockNumber}
+	if blocks := snapshotBlocks(matchingLogs(endLogs)); len(blocks) > 0 {
+		endBlock.Logs = blocks[0].Logs
+	}
+	if err = sw.write(snapshotLine{Block: endBlock}); err != nil {
+		return nil, err
+	}
+	if err = sw.close(); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// snapshotBlocks groups the logs by block, in ascending order. As the blocks are finalized, they are their own
+// finalized block.
+func snapshotBlocks(logs []Log) []*snapshotBlock {
+	slices.SortFunc(logs, func(a, b Log) int {
+		if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.LogIndex, b.LogIndex)
+	})
+	var blocks []*snapshotBlock
+	for _, l := range logs {
+		if len(blocks) == 0 || blocks[len(blocks)-1].Number != l.BlockNumber {
+			blocks = append(blocks, &snapshotBlock{Hash: l.BlockHash, Number: l.BlockNumber, Timestamp: l.BlockTimestamp, FinalizedBlockNumber: l.BlockNumber})
+		}
+		topics := make([]hexutil.Bytes, len(l.Topics))
+		for i, topic := range l.Topics {
+			topics[i] = topic
+		}
+		block := blocks[len(blocks)-1]
+		block.Logs = append(block.Logs, snapshotLog{LogIndex: l.LogIndex, TxHash: l.TxHash, Address: l.Address, Topics: topics, Data: l.Data})
+	}
+	return blocks
+}
+
+type snapshotWriter struct {
+	w    *bufio.Writer
+	enc  *json.Encoder
+	hash hash.Hash
+}
+
+func newSnapshotWriter(w io.Writer) *snapshotWriter {
+	bw := bufio.NewWriter(w)
+	h := sha256.New()
+	return &snapshotWriter{w: bw, enc: json.NewEncoder(io.MultiWriter(bw, h)), hash: h}
+}
+
+func (sw *snapshotWriter) write(line snapshotLine) error {
+	return sw.enc.Encode(line)
+}
+
+// close writes the checksum line and flushes the snapshot.
+func (sw *snapshotWriter) close() error {
+	if err := json.NewEncoder(sw.w).Encode(snapshotLine{Checksum: hex.EncodeToString(sw.hash.Sum(nil))}); err != nil {
+		return err
+	}
+	return sw.w.Flush()
+}
+
+// scanSnapshot reads a snapshot written by ExportSnapshot line by line, calling onBlock with each of its blocks, and
+// verifies its checksum at the end. It returns the header of the snapshot.
+func scanSnapshot(r io.Reader, onBlock func(*snapshotBlock) error) (*SnapshotHeader, error) {
+	br := bufio.NewReader(r)
+	h := sha256.New()
+
+	var header *SnapshotHeader
+	var lastBlock *snapshotBlock
+	for {
+		b, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(b) == 0 {
+				return nil, pkgerrors.New("snapshot is truncated, missing its checksum")
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		b = bytes.TrimSuffix(b, []byte{'\n'})
+		var line snapshotLine
+		if err = json.NewDecoder(bytes.NewReader(b)).Decode(&line); err != nil {
+			return nil, fmt.Errorf("invalid snapshot line: %w", err)
+		}
+		switch {
+		case line.Checksum != "":
+			if line.Checksum != hex.EncodeToString(h.Sum(nil)) {
+				return nil, ErrSnapshotChecksum
+			}
+			if header == nil || lastBlock == nil {
+				return nil, pkgerrors.New("snapshot has no header or blocks")
+			}
+			return header, nil
+		case line.Header != nil && header == nil:
+			header = line.Header
+			if header.Version != snapshotVersion {
+				return nil, pkgerrors.Errorf("unsupported snapshot version %d", header.Version)
+			}
+		case line.Block != nil && header != nil:
+			if lastBlock != nil && lastBlock.Number >= line.Block.Number {
+				return nil, pkgerrors.Errorf("snapshot block %d is out of order", line.Block.Number)
+			}
+			lastBlock = line.Block
+			if err = onBlock(line.Block); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, pkgerrors.New("unexpected snapshot line")
+		}
+		h.Write(b)
+		h.Write([]byte{'\n'})
+	}
+}
+
+// readSnapshot verifies the checksum of a snapshot written by ExportSnapshot, and returns its header and blocks,
+// without their logs.
+func readSnapshot(r io.Reader) (*SnapshotHeader, []*snapshotBlock, error) {
+	var blocks []*snapshotBlock
+	header, err := scanSnapshot(r, func(block *snapshotBlock) error {
+		blocks = append(blocks, &snapshotBlock{Hash: block.Hash, Number: block.Number})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, blocks, nil
+}
+
+// ImportSnapshot imports a snapshot written by ExportSnapshot in the db of orm, before the LogPoller is started. Its
+// filters are saved, and the logs of each block are saved with InsertLogsWithBlock. The LogPoller then polls from the
+// end block of the snapshot onward.
+//
+// The snapshot is read twice from r, streaming its blocks: nothing is saved unless its checksum is valid, and the hashes
+// of its end block and of sampleSize other blocks picked at random match the blocks of the RPC of ec. The db must be
+// empty, other than the blocks saved by a previous import of the same snapshot: the import is idempotent, and resumes
+// after the latest block saved if it was interrupted. The filters registered in the db must be in the snapshot, so that
+// none of their logs are missing from the blocks imported.
+func ImportSnapshot(ctx context.Context, orm ORM, ec Client, r io.ReadSeeker, sampleSize int, lggr logger.Logger) (*SnapshotHeader, error) {
+	header, blocks, err := readSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	chainID := ec.ConfiguredChainID()
+	if header.ChainID != chainID.String() {
+		return nil, pkgerrors.Errorf("snapshot is for chain %s, not %s", header.ChainID, chainID)
+	}
+	if last := blocks[len(blocks)-1]; last.Number != header.ToBlock || blocks[0].Number < header.FromBlock {
+		return nil, pkgerrors.Errorf("snapshot blocks [%d, %d] don't match its range [%d, %d]", blocks[0].Number, last.Number, header.FromBlock, header.ToBlock)
+	}
+	latest, err := importedSnapshotBlock(ctx, orm, header, blocks)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSnapshotFilters(ctx, orm, header); err != nil {
+		return nil, err
+	}
+
+	lggr = logger.With(lggr, "fromBlock", header.FromBlock, "toBlock", header.ToBlock)
+	if err = verifySnapshotBlocks(ctx, ec, blocks, sampleSize); err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		lggr.Infow("Resuming snapshot import", "latestBlock", latest.BlockNumber)
+	}
+
+	for _, filter := range header.Filters {
+		if err = orm.InsertFilter(ctx, filter.filter()); err != nil {
+			return nil, pkgerrors.Wrapf(err, "error inserting filter %s", filter.Name)
+		}
+	}
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	blockCount, logCount := 0, 0
+	_, err = scanSnapshot(r, func(block *snapshotBlock) error {
+		if latest != nil && block.Number <= latest.BlockNumber {
+			return nil
+		}
+		logs := make([]Log, 0, len(block.Logs))
+		for _, l := range block.Logs {
+			if len(l.Topics) == 0 {
+				return pkgerrors.Errorf("log %d of snapshot block %d has no topics", l.LogIndex, block.Number)
+			}
+			topics := make(pq.ByteaArray, len(l.Topics))
+			for i, topic := range l.Topics {
+				topics[i] = topic
+			}
+			logs = append(logs, Log{
+				EVMChainID:     ubig.New(chainID),
+				LogIndex:       l.LogIndex,
+				BlockHash:      block.Hash,
+				BlockNumber:    block.Number,
+				BlockTimestamp: block.Timestamp,
+				Topics:         topics,
+				EventSig:       common.BytesToHash(l.Topics[0]),
+				Address:        l.Address,
+				TxHash:         l.TxHash,
+				Data:           l.Data,
+			})
+		}
+		err := orm.InsertLogsWithBlock(ctx, logs, Block{
+			EVMChainID:           ubig.New(chainID),
+			BlockHash:            block.Hash,
+			BlockNumber:          block.Number,
+			BlockTimestamp:       block.Timestamp,
+			FinalizedBlockNumber: block.FinalizedBlockNumber,
+		})
+		if err != nil {
+			return pkgerrors.Wrapf(err, "error inserting snapshot block %d", block.Number)
+		}
+		blockCount++
+		logCount += len(logs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	lggr.Infow("Imported snapshot", "filters", len(header.Filters), "blocks", blockCount, "logs", logCount)
+	return header, nil
+}
+
+// importedSnapshotBlock returns the latest block saved by a previous import of the snapshot, or nil if the db is empty.
+// Each block is saved atomically with its logs, so a previous import which failed part way through saved the blocks of
+// the snapshot from its first one up to the latest one, and is resumed after it. Any other block in the db is an error.
+func importedSnapshotBlock(ctx context.Context, orm ORM, header *SnapshotHeader, blocks []*snapshotBlock) (*Block, error) {
+	latest, err := orm.SelectLatestBlock(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	oldest, err := orm.SelectOldestBlock(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	i, found := slices.BinarySearchFunc(blocks, latest.BlockNumber, func(b *snapshotBlock, n int64) int { return cmp.Compare(b.Number, n) })
+	if !found || blocks[i].Hash != latest.BlockHash || oldest.BlockNumber != blocks[0].Number || oldest.BlockHash != blocks[0].Hash {
+		return nil, pkgerrors.Errorf("db already has blocks [%d, %d], which aren't from an import of the snapshot [%d, %d]",
+			oldest.BlockNumber, latest.BlockNumber, header.FromBlock, header.ToBlock)
+	}
+	return latest, nil
+}
+
+// checkSnapshotFilters checks that the filters registered in the db of orm are in the snapshot, with their addresses and
+// events, as the LogPoller doesn't fetch the logs of the blocks imported.
+func checkSnapshotFilters(ctx context.Context, orm ORM, header *SnapshotHeader) error {
+	filters, err := orm.LoadFilters(ctx)
+	if err != nil {
+		return err
+	}
+	for name, filter := range filters {
+		i := slices.IndexFunc(header.Filters, func(f SnapshotFilter) bool { return f.Name == name })
+		if i < 0 {
+			return pkgerrors.Errorf("filter %s is registered, but isn't in the snapshot", name)
+		}
+		snapshotFilter := header.Filters[i]
+		if !containsAll(snapshotFilter.Addresses, filter.Addresses) || !containsAll(snapshotFilter.EventSigs, filter.EventSigs) {
+			return pkgerrors.Errorf("filter %s is registered with addresses or events which aren't in the snapshot", name)
+		}
+	}
+	return nil
+}
+
+// verifySnapshotBlocks checks that the last block and sampleSize other blocks picked at random are on the RPC's chain.
+func verifySnapshotBlocks(ctx context.Context, ec Client, blocks []*snapshotBlock, sampleSize int) error {
+	sample := []*snapshotBlock{blocks[len(blocks)-1]}
+	for _, i := range rand.Perm(len(blocks) - 1)[:min(max(sampleSize, 0), len(blocks)-1)] {
+		sample = append(sample, blocks[i])
+	}
+	for _, block := range sample {
+		head, err := ec.HeadByNumber(ctx, big.NewInt(block.Number))
+		if err != nil {
+			return err
+		}
+		if head == nil {
+			return pkgerrors.Errorf("Got nil block for %d", block.Number)
+		}
+		if head.Hash != block.Hash {
+			return pkgerrors.Errorf("snapshot block %d has hash %s, but the RPC's block has hash %s", block.Number, block.Hash, head.Hash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package logpoller_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client/clienttest"
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+func TestSnapshot(t *testing.T) {
+	forEachORMBackend(t, testSnapshot)
+}
+
+func testSnapshot(t *testing.T, backend ORMBackend) {
+	lpOpts := logpoller.Opts{
+		FinalityDepth:            2,
+		BackfillBatchSize:        3,
+		RPCBatchSize:             2,
+		KeepFinalizedBlocksDepth: 1000,
+	}
+	th := SetupTHWithBackend(t, lpOpts, backend)
+	ctx := testutils.Context(t)
+
+	log1 := EmitterABI.Events["Log1"].ID
+	filter := logpoller.Filter{
+		Name:      "Emitter 1 - Log1",
+		EventSigs: []common.Hash{log1},
+		Addresses: []common.Address{th.EmitterAddress1},
+	}
+	require.NoError(t, th.LogPoller.RegisterFilter(ctx, filter))
+	require.NoError(t, th.LogPoller.RegisterFilter(ctx, logpoller.Filter{
+		Name:      "Emitter 2 - Log1",
+		EventSigs: []common.Hash{log1},
+		Addresses: []common.Address{th.EmitterAddress2},
+	}))
+
+	// Emit some logs in blocks 2->7.
+	for i := int64(1); i <= 6; i++ {
+		_, err := th.Emitter1.EmitLog1(th.Owner, []*big.Int{big.NewInt(i)})
+		require.NoError(t, err)
+		_, err = th.Emitter2.EmitLog1(th.Owner, []*big.Int{big.NewInt(i)})
+		require.NoError(t, err)
+		th.Backend.Commit()
+	}
+	th.Backend.Commit()
+	require.Equal(t, int64(9), th.PollAndSaveLogs(ctx, 1))
+
+	var snapshot bytes.Buffer
+	_, err := logpoller.ExportSnapshot(ctx, th.ORM, th.ChainID, &snapshot, []logpoller.Filter{filter}, 2, 7)
+	require.ErrorContains(t, err, "not finalized")
+	header, err := logpoller.ExportSnapshot(ctx, th.ORM, th.ChainID, &snapshot, []logpoller.Filter{filter}, 2, 6)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), header.ToBlock)
+
+	newORM := func(t *testing.T) logpoller.ORM {
+		orm, err := logpoller.NewEmbeddedORM(th.ChainID, memorydb.New(), th.Lggr)
+		require.NoError(t, err)
+		return orm
+	}
+
+	t.Run("import", func(t *testing.T) {
+		orm := newORM(t)
+		imported, err := logpoller.ImportSnapshot(ctx, orm, th.Client, bytes.NewReader(snapshot.Bytes()), logpoller.DefaultSnapshotSampleSize, th.Lggr)
+		require.NoError(t, err)
+		assert.Equal(t, header, imported)
+
+		logs, err := orm.SelectLogsByBlockRange(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, logs, 5)
+		for _, l := range logs {
+			assert.Equal(t, th.EmitterAddress1, l.Address)
+		}
+		latest, err := orm.SelectLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), latest.BlockNumber)
+		filters, err := orm.LoadFilters(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, filters, filter.Name)
+
+		// Importing the snapshot again is a no-op.
+		_, err = logpoller.ImportSnapshot(ctx, orm, th.Client, bytes.NewReader(snapshot.Bytes()), logpoller.DefaultSnapshotSampleSize, th.Lggr)
+		require.NoError(t, err)
+		logs, err = orm.SelectLogsByBlockRange(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, logs, 5)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		src := newORM(t)
+		_, err := logpoller.ImportSnapshot(ctx, src, th.Client, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.NoError(t, err)
+
+		// Interrupted after saving blocks 2 and 3.
+		orm := newORM(t)
+		for n := int64(2); n <= 3; n++ {
+			block, err := src.SelectBlockByNumber(ctx, n)
+			require.NoError(t, err)
+			logs, err := src.SelectLogsByBlockRange(ctx, n, n)
+			require.NoError(t, err)
+			require.NoError(t, orm.InsertLogsWithBlock(ctx, logs, *block))
+		}
+
+		_, err = logpoller.ImportSnapshot(ctx, orm, th.Client, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.NoError(t, err)
+		logs, err := orm.SelectLogsByBlockRange(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, logs, 5)
+		latest, err := orm.SelectLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), latest.BlockNumber)
+	})
+
+	t.Run("db already has other blocks", func(t *testing.T) {
+		orm := newORM(t)
+		require.NoError(t, orm.InsertBlock(ctx, common.HexToHash("0x1234"), 3, time.Now(), 3))
+		_, err := logpoller.ImportSnapshot(ctx, orm, th.Client, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.ErrorContains(t, err, "db already has blocks [3, 3]")
+
+		// A partial import of the snapshot, after a block the snapshot doesn't have.
+		src := newORM(t)
+		_, err = logpoller.ImportSnapshot(ctx, src, th.Client, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.NoError(t, err)
+		block, err := src.SelectBlockByNumber(ctx, 2)
+		require.NoError(t, err)
+		orm = newORM(t)
+		require.NoError(t, orm.InsertBlock(ctx, common.HexToHash("0x1234"), 1, time.Now(), 1))
+		require.NoError(t, orm.InsertBlock(ctx, block.BlockHash, block.BlockNumber, block.BlockTimestamp, block.FinalizedBlockNumber))
+		_, err = logpoller.ImportSnapshot(ctx, orm, th.Client, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.ErrorContains(t, err, "db already has blocks [1, 2]")
+	})
+
+	t.Run("registered filter not in the snapshot", func(t *testing.T) {
+		orm := newORM(t)
+		require.NoError(t, orm.InsertFilter(ctx, logpoller.Filter{Name: "Emitter 2 - Log1", EventSigs: []common.Hash{log1}, Addresses: []common.Address{th.EmitterAddress2}}))
+		_, err := logpoller.ImportSnapshot(ctx, orm, th.Client, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.ErrorContains(t, err, "filter Emitter 2 - Log1 is registered, but isn't in the snapshot")
+
+		orm = newORM(t)
+		require.NoError(t, orm.InsertFilter(ctx, logpoller.Filter{Name: filter.Name, EventSigs: []common.Hash{log1}, Addresses: []common.Address{th.EmitterAddress1, th.EmitterAddress2}}))
+		_, err = logpoller.ImportSnapshot(ctx, orm, th.Client, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.ErrorContains(t, err, "which aren't in the snapshot")
+
+		logs, err := orm.SelectLogsByBlockRange(ctx, 1, 10)
+		require.NoError(t, err)
+		assert.Empty(t, logs)
+	})
+
+	t.Run("invalid checksum", func(t *testing.T) {
+		tampered := bytes.Replace(snapshot.Bytes(), []byte(`"FromBlock":2`), []byte(`"FromBlock":3`), 1)
+		require.NotEqual(t, snapshot.Bytes(), tampered)
+		_, err := logpoller.ImportSnapshot(ctx, newORM(t), th.Client, bytes.NewReader(tampered), logpoller.DefaultSnapshotSampleSize, th.Lggr)
+		require.ErrorIs(t, err, logpoller.ErrSnapshotChecksum)
+
+		truncated := snapshot.Bytes()[:bytes.LastIndexByte(snapshot.Bytes()[:snapshot.Len()-1], '\n')+1]
+		_, err = logpoller.ImportSnapshot(ctx, newORM(t), th.Client, bytes.NewReader(truncated), logpoller.DefaultSnapshotSampleSize, th.Lggr)
+		require.ErrorContains(t, err, "truncated")
+	})
+
+	t.Run("block hash mismatch", func(t *testing.T) {
+		ec := clienttest.NewClient(t)
+		ec.On("ConfiguredChainID").Return(th.ChainID)
+		ec.On("HeadByNumber", mock.Anything, mock.Anything).Return(&evmtypes.Head{Hash: common.HexToHash("0x1234")}, nil)
+		orm := newORM(t)
+		_, err := logpoller.ImportSnapshot(ctx, orm, ec, bytes.NewReader(snapshot.Bytes()), 0, th.Lggr)
+		require.ErrorContains(t, err, "snapshot block 6 has hash")
+
+		logs, err := orm.SelectLogsByBlockRange(ctx, 1, 10)
+		require.NoError(t, err)
+		assert.Empty(t, logs)
+	})
+}
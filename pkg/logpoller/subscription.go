@@ -0,0 +1,400 @@
+package logpoller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+const (
+	// subscriptionBufferSize is the capacity of the channel returned by Subscription.Logs.
+	subscriptionBufferSize = 100
+	// maxPendingSubscriptionLogs is the number of logs waiting to be delivered to a subscriber, after which the
+	// subscription is considered lagging and is failed with ErrSubscriptionLagging.
+	maxPendingSubscriptionLogs = 10_000
+	// subscriptionCatchUpPageSize is the number of blocks read at once when delivering the logs saved before subscribing.
+	subscriptionCatchUpPageSize = 1000
+)
+
+var (
+	ErrSubscriptionLagging = pkgerrors.New("subscription is lagging behind, resubscribe from the cursor of the last delivered log")
+	ErrFilterUnregistered  = pkgerrors.New("filter unregistered")
+)
+
+// LogEvent is delivered by a Subscription for each log of its filter persisted by the LogPoller.
+type LogEvent struct {
+	Log
+	// Removed is set if the log was delivered before and has since been removed by a reorg.
+	Removed bool
+}
+
+// Cursor returns the cursor of the log, which can be passed to SubscribeFromCursor to resume a subscription right
+// after this log, e.g. after a restart.
+func (e LogEvent) Cursor() string {
+	return FormatContractReaderCursor(e.Log)
+}
+
+// Subscription streams the logs matching a registered filter as they are persisted by the LogPoller.
+//
+// Logs are delivered at most once and in (block number, log index) order, starting with the logs already saved when
+// subscribing. When a reorg removes logs which were delivered, a LogEvent with Removed set is delivered for each of
+// them, followed by the logs of the new canonical chain. Logs saved for blocks before the last delivered log, e.g. by
+// the backup poller or a Replay, are not delivered; query them with FilteredLogs if needed.
+//
+// The subscription ends when the context passed to Subscribe is done, Unsubscribe is called, the filter is
+// unregistered or the LogPoller is closed. The Logs channel is closed afterward, and the reason, if any, is sent on
+// Err.
+type Subscription struct {
+	lp     *logPoller
+	logs   chan LogEvent
+	errCh  chan error
+	stopCh services.StopChan
+
+	unsubscribeOnce sync.Once
+
+	// start is the position right before the first log to deliver, requested when subscribing.
+	start subscriptionPosition
+	// delivered is the position of the last delivered log, only accessed by the run goroutine.
+	delivered subscriptionPosition
+
+	mu      sync.Mutex
+	filter  Filter
+	pending []subscriptionUpdate
+	pendLen int
+	failed  error
+	wake    chan struct{}
+}
+
+var _ ethereum.Subscription = (*Subscription)(nil)
+
+type subscriptionPosition struct {
+	blockNumber int64
+	logIndex    int64
+}
+
+func (p subscriptionPosition) before(l *Log) bool {
+	return p.blockNumber < l.BlockNumber || (p.blockNumber == l.BlockNumber && p.logIndex < l.LogIndex)
+}
+
+type subscriptionUpdate struct {
+	logs []Log
+	// removedAfter is set for the logs removed by deleting everything from block removedAfter onward.
+	removedAfter int64
+	removed      bool
+}
+
+// Subscribe streams the logs of the registered filter filterName starting at block fromBlock. See Subscription.
+func (lp *logPoller) Subscribe(ctx context.Context, filterName string, fromBlock int64) (*Subscription, error) {
+	if fromBlock < 0 {
+		return nil, fmt.Errorf("invalid from block: %d", fromBlock)
+	}
+	return lp.subscribe(ctx, filterName, subscriptionPosition{blockNumber: fromBlock, logIndex: -1})
+}
+
+// SubscribeFromCursor streams the logs of the registered filter filterName after the log identified by cursor, as
+// returned by LogEvent.Cursor. See Subscription.
+func (lp *logPoller) SubscribeFromCursor(ctx context.Context, filterName string, cursor string) (*Subscription, error) {
+	blockNumber, logIndex, _, err := valuesFromCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	return lp.subscribe(ctx, filterName, subscriptionPosition{blockNumber: blockNumber, logIndex: int64(logIndex)})
+}
+
+func (lp *logPoller) subscribe(ctx context.Context, filterName string, start subscriptionPosition) (*Subscription, error) {
+	lp.filterMu.RLock()
+	filter, ok := lp.filters[filterName]
+	lp.filterMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("filter %s is not registered", filterName)
+	}
+
+	sub := &Subscription{
+		lp:        lp,
+		logs:      make(chan LogEvent, subscriptionBufferSize),
+		errCh:     make(chan error, 1),
+		stopCh:    make(chan struct{}),
+		start:     start,
+		delivered: start,
+		filter:    filter,
+		wake:      make(chan struct{}, 1),
+	}
+
+	// The subscription is registered before reading the saved logs, so that no log persisted meanwhile is missed.
+	started := lp.IfNotStopped(func() {
+		lp.subscriptionsMu.Lock()
+		lp.subscriptions[sub] = struct{}{}
+		lp.subscriptionsMu.Unlock()
+
+		lp.wg.Add(1)
+		go func() {
+			defer lp.wg.Done()
+			sub.run(ctx)
+		}()
+	})
+	if !started {
+		return nil, ErrLogPollerShutdown
+	}
+	return sub, nil
+}
+
+// Logs returns the channel on which the logs are delivered. It's closed when the subscription ends.
+func (s *Subscription) Logs() <-chan LogEvent {
+	return s.logs
+}
+
+// Err returns a channel receiving the error which ended the subscription, if any. It's closed when the subscription ends.
+func (s *Subscription) Err() <-chan error {
+	return s.errCh
+}
+
+// Unsubscribe ends the subscription. It's safe to call it multiple times.
+func (s *Subscription) Unsubscribe() {
+	s.unsubscribeOnce.Do(func() {
+		close(s.stopCh)
+	})
+}
+
+// push queues an update for delivery, unless the subscription has failed.
+func (s *Subscription) push(update subscriptionUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed != nil {
+		return
+	}
+	update.logs = s.filter.matchingLogs(update.logs)
+	if len(update.logs) == 0 {
+		return
+	}
+	if s.pendLen+len(update.logs) > maxPendingSubscriptionLogs {
+		s.failLocked(ErrSubscriptionLagging)
+		return
+	}
+	s.pending = append(s.pending, update)
+	s.pendLen += len(update.logs)
+	s.notify()
+}
+
+// fail ends the subscription with err.
+func (s *Subscription) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failLocked(err)
+}
+
+func (s *Subscription) failLocked(err error) {
+	if s.failed == nil {
+		s.failed = err
+		s.pending, s.pendLen = nil, 0
+		s.notify()
+	}
+}
+
+func (s *Subscription) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Subscription) setFilter(filter Filter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filter = filter
+}
+
+// takePending returns the queued updates, or the error the subscription failed with.
+func (s *Subscription) takePending() ([]subscriptionUpdate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending := s.pending
+	s.pending, s.pendLen = nil, 0
+	return pending, s.failed
+}
+
+func (s *Subscription) run(ctx context.Context) {
+	ctx, cancel := s.lp.stopCh.Ctx(ctx)
+	defer cancel()
+
+	err := s.deliverAll(ctx)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// The subscriber is gone or the log poller is closing, there is nobody to report to.
+		err = nil
+	}
+
+	s.lp.subscriptionsMu.Lock()
+	delete(s.lp.subscriptions, s)
+	s.lp.subscriptionsMu.Unlock()
+
+	if err != nil {
+		s.errCh <- err
+	}
+	close(s.errCh)
+	close(s.logs)
+}
+
+func (s *Subscription) deliverAll(ctx context.Context) error {
+	if err := s.catchUp(ctx); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.stopCh:
+			return nil
+		case <-s.wake:
+		}
+
+		pending, err := s.takePending()
+		if err != nil {
+			return err
+		}
+		for _, update := range pending {
+			if err = s.deliverUpdate(ctx, update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// catchUp delivers the logs saved before subscribing, up to the latest saved block.
+func (s *Subscription) catchUp(ctx context.Context) error {
+	latest, err := s.lp.orm.SelectLatestBlock(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to select latest block: %w", err)
+	}
+	for from := s.start.blockNumber; from <= latest.BlockNumber; from += subscriptionCatchUpPageSize {
+		to := min(from+subscriptionCatchUpPageSize-1, latest.BlockNumber)
+		logs, err := s.lp.orm.SelectLogsByBlockRange(ctx, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to select logs from %d to %d: %w", from, to, err)
+		}
+		s.mu.Lock()
+		logs = s.filter.matchingLogs(logs)
+		s.mu.Unlock()
+		if err = s.deliverUpdate(ctx, subscriptionUpdate{logs: logs}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Subscription) deliverUpdate(ctx context.Context, update subscriptionUpdate) error {
+	if update.removed {
+		var rewind bool
+		for _, l := range update.logs {
+			if s.delivered.before(&l) {
+				continue
+			}
+			rewind = true
+			if err := s.send(ctx, LogEvent{Log: l, Removed: true}); err != nil {
+				return err
+			}
+		}
+		if rewind {
+			// The logs of the new canonical chain are delivered from the reorged block onward, but never before start.
+			s.delivered = subscriptionPosition{blockNumber: update.removedAfter - 1, logIndex: math.MaxInt64}
+			if s.delivered.blockNumber < s.start.blockNumber ||
+				(s.delivered.blockNumber == s.start.blockNumber && s.delivered.logIndex < s.start.logIndex) {
+				s.delivered = s.start
+			}
+		}
+		return nil
+	}
+
+	for _, l := range update.logs {
+		if !s.delivered.before(&l) {
+			continue
+		}
+		if err := s.send(ctx, LogEvent{Log: l}); err != nil {
+			return err
+		}
+		s.delivered = subscriptionPosition{blockNumber: l.BlockNumber, logIndex: l.LogIndex}
+	}
+	return nil
+}
+
+func (s *Subscription) send(ctx context.Context, event LogEvent) error {
+	select {
+	case s.logs <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.stopCh:
+		return context.Canceled
+	}
+}
+
+// publishLogs queues the persisted logs for delivery to the subscriptions.
+func (lp *logPoller) publishLogs(logs []Log) {
+	lp.subscriptionsMu.RLock()
+	defer lp.subscriptionsMu.RUnlock()
+	for sub := range lp.subscriptions {
+		sub.push(subscriptionUpdate{logs: logs})
+	}
+}
+
+// publishRemovedLogs notifies the subscriptions that the logs from block start onward have been removed.
+func (lp *logPoller) publishRemovedLogs(start int64, logs []Log) {
+	lp.subscriptionsMu.RLock()
+	defer lp.subscriptionsMu.RUnlock()
+	for sub := range lp.subscriptions {
+		sub.push(subscriptionUpdate{logs: logs, removedAfter: start, removed: true})
+	}
+}
+
+func (lp *logPoller) hasSubscriptions() bool {
+	lp.subscriptionsMu.RLock()
+	defer lp.subscriptionsMu.RUnlock()
+	return len(lp.subscriptions) > 0
+}
+
+// updateSubscriptions applies a change of the filter filterName to its subscriptions. A nil filter means it was
+// unregistered, which ends them.
+func (lp *logPoller) updateSubscriptions(filterName string, filter *Filter) {
+	lp.subscriptionsMu.RLock()
+	defer lp.subscriptionsMu.RUnlock()
+	for sub := range lp.subscriptions {
+		sub.mu.Lock()
+		name := sub.filter.Name
+		sub.mu.Unlock()
+		if name != filterName {
+			continue
+		}
+		if filter == nil {
+			sub.fail(fmt.Errorf("%w: %s", ErrFilterUnregistered, filterName))
+			continue
+		}
+		sub.setFilter(*filter)
+	}
+}
+
+// deleteLogsAndBlocksAfter deletes the logs and blocks from block start onward, and notifies the subscriptions of the
+// removed logs.
+func (lp *logPoller) deleteLogsAndBlocksAfter(ctx context.Context, start int64) error {
+	var removed []Log
+	if lp.hasSubscriptions() {
+		var err error
+		removed, err = lp.orm.SelectLogsByBlockRange(ctx, start, math.MaxInt64)
+		if err != nil {
+			return fmt.Errorf("failed to select removed logs: %w", err)
+		}
+	}
+	if err := lp.orm.DeleteLogsAndBlocksAfter(ctx, start); err != nil {
+		return err
+	}
+	lp.publishRemovedLogs(start, removed)
+	return nil
+}
@@ -0,0 +1,122 @@
+package logpoller_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+)
+
+func requireLogEvent(t *testing.T, sub *logpoller.Subscription, removed bool, data int64) logpoller.LogEvent {
+	select {
+	case event, ok := <-sub.Logs():
+		require.True(t, ok, "subscription ended")
+		assert.Equal(t, removed, event.Removed)
+		assert.Equal(t, common.BigToHash(big.NewInt(data)).Bytes(), event.Data)
+		return event
+	case <-testutils.Context(t).Done():
+		t.Fatal("timed out waiting for log event")
+		return logpoller.LogEvent{}
+	}
+}
+
+func requireNoLogEvent(t *testing.T, sub *logpoller.Subscription) {
+	select {
+	case event := <-sub.Logs():
+		t.Fatalf("unexpected log event: %v", event)
+	default:
+	}
+}
+
+func TestLogPoller_Subscribe(t *testing.T) {
+	forEachORMBackend(t, testLogPollerSubscribe)
+}
+
+func testLogPollerSubscribe(t *testing.T, backend ORMBackend) {
+	lpOpts := logpoller.Opts{
+		FinalityDepth:            2,
+		BackfillBatchSize:        3,
+		RPCBatchSize:             2,
+		KeepFinalizedBlocksDepth: 1000,
+	}
+	th := SetupTHWithBackend(t, lpOpts, backend)
+	ctx := testutils.Context(t)
+
+	filter := logpoller.Filter{
+		Name:      "Subscribed",
+		EventSigs: []common.Hash{EmitterABI.Events["Log1"].ID},
+		Addresses: []common.Address{th.EmitterAddress1},
+	}
+	require.NoError(t, th.LogPoller.RegisterFilter(ctx, filter))
+
+	_, err := th.LogPoller.Subscribe(ctx, "Unknown", 0)
+	require.Error(t, err)
+
+	// Chain genesis <- 1 <- 2 (L1_1, L2_1)
+	_, err = th.Emitter1.EmitLog1(th.Owner, []*big.Int{big.NewInt(1)})
+	require.NoError(t, err)
+	_, err = th.Emitter1.EmitLog2(th.Owner, []*big.Int{big.NewInt(1)})
+	require.NoError(t, err)
+	th.Backend.Commit()
+	newStart := th.PollAndSaveLogs(ctx, 1)
+	require.Equal(t, int64(3), newStart)
+
+	// Logs saved before subscribing are delivered first.
+	sub, err := th.LogPoller.Subscribe(ctx, filter.Name, 0)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	first := requireLogEvent(t, sub, false, 1)
+
+	// Chain genesis <- 1 <- 2 (L1_1, L2_1) <- 3 (L1_2)
+	_, err = th.Emitter1.EmitLog1(th.Owner, []*big.Int{big.NewInt(2)})
+	require.NoError(t, err)
+	th.Backend.Commit()
+	newStart = th.PollAndSaveLogs(ctx, newStart)
+	require.Equal(t, int64(4), newStart)
+	requireLogEvent(t, sub, false, 2)
+
+	// Chain genesis <- 1 <- 2 (L1_1, L2_1) <- 3 (L1_2)
+	//                                        \ 3' (L1_3) <- 4'
+	lca, err := th.Client.BlockByNumber(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	require.NoError(t, th.Backend.Fork(lca.Hash()))
+	_, err = th.Emitter1.EmitLog1(th.Owner, []*big.Int{big.NewInt(3)})
+	require.NoError(t, err)
+	th.Backend.Commit()
+	th.Backend.Commit()
+	newStart = th.PollAndSaveLogs(ctx, newStart)
+	require.Equal(t, int64(5), newStart)
+	requireLogEvent(t, sub, true, 2)
+	requireLogEvent(t, sub, false, 3)
+	requireNoLogEvent(t, sub)
+
+	// Resuming from a cursor delivers the logs after it.
+	resumed, err := th.LogPoller.SubscribeFromCursor(ctx, filter.Name, first.Cursor())
+	require.NoError(t, err)
+	defer resumed.Unsubscribe()
+	requireLogEvent(t, resumed, false, 3)
+
+	_, err = th.LogPoller.SubscribeFromCursor(ctx, filter.Name, "invalid")
+	require.ErrorIs(t, err, logpoller.ErrUnexpectedCursorFormat)
+
+	// Unsubscribing ends the subscription without an error.
+	resumed.Unsubscribe()
+	_, ok := <-resumed.Err()
+	assert.False(t, ok)
+
+	// Unregistering the filter ends its subscriptions.
+	require.NoError(t, th.LogPoller.UnregisterFilter(ctx, filter.Name))
+	select {
+	case err = <-sub.Err():
+		require.ErrorIs(t, err, logpoller.ErrFilterUnregistered)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for subscription to end")
+	}
+	_, ok = <-sub.Logs()
+	assert.False(t, ok)
+}
@@ -0,0 +1,511 @@
+package testutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// EnvJSONRPCRecordURL is the environment variable of the RPC URL that NewJSONRPCCassetteServer records from, instead of
+// replaying its cassette.
+const EnvJSONRPCRecordURL = "EVM_JSONRPC_RECORD_URL"
+
+// replayNotifyInterval is the delay between the notifications of a replayed subscription.
+const replayNotifyInterval = 10 * time.Millisecond
+
+// JSONRPCCassette is the JSON-RPC traffic of a test run, saved as JSON by a recording JSONRPCCassetteServer and served
+// back by a replaying one.
+type JSONRPCCassette struct {
+	Interactions  []JSONRPCInteraction  `json:"interactions"`
+	Subscriptions []JSONRPCSubscription `json:"subscriptions,omitempty"`
+}
+
+// JSONRPCInteraction is a request and its response. The requests of batch calls are recorded individually.
+type JSONRPCInteraction struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// JSONRPCSubscription is an eth_subscribe request and the notifications received for it, like newHeads.
+type JSONRPCSubscription struct {
+	Params        json.RawMessage   `json:"params"`
+	Notifications []json.RawMessage `json:"notifications"`
+}
+
+type jsonrpcMessage struct {
+	Version string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+type jsonrpcSubscriptionParams struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// JSONRPCCassetteServer is a JSON-RPC server over websocket and HTTP which either records the traffic with another
+// RPC into a cassette file, or replays a cassette file. See NewJSONRPCCassetteServer.
+type JSONRPCCassetteServer struct {
+	t        *testing.T
+	s        *httptest.Server
+	path     string
+	upstream *url.URL // nil when replaying
+
+	mu       sync.Mutex
+	cassette JSONRPCCassette
+	// replayed is the number of responses replayed by request key, and subscriptions by params.
+	replayed      map[string]int
+	subscriptions int // number of subscriptions replayed, to generate their ids
+
+	wsMu    sync.Mutex
+	wsConns map[*websocket.Conn]struct{} // nil once closed
+	wsWG    sync.WaitGroup
+}
+
+// NewJSONRPCCassetteServer returns a server replaying the cassette at path, or recording it from the RPC URL of the
+// EnvJSONRPCRecordURL environment variable if it's set. This turns a run against a real chain into a hermetic test:
+//
+//	EVM_JSONRPC_RECORD_URL=wss://... go test ./pkg/... -run TestFoo
+//
+// records pkg/testdata/jsonrpc/<cassette>.json, which later runs replay.
+func NewJSONRPCCassetteServer(t *testing.T, path string) *JSONRPCCassetteServer {
+	if rawURL := os.Getenv(EnvJSONRPCRecordURL); rawURL != "" {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err, "invalid "+EnvJSONRPCRecordURL)
+		return NewJSONRPCRecorder(t, path, u)
+	}
+	return NewJSONRPCReplayer(t, path)
+}
+
+// NewJSONRPCRecorder returns a server proxying the requests to upstream, which saves the traffic to the cassette file at
+// path when the test ends. Websocket connections are proxied to upstream, and HTTP requests to the HTTP equivalent of
+// upstream if it's a websocket URL.
+func NewJSONRPCRecorder(t *testing.T, path string, upstream *url.URL) *JSONRPCCassetteServer {
+	cs := &JSONRPCCassetteServer{t: t, path: path, upstream: upstream, wsConns: make(map[*websocket.Conn]struct{})}
+	cs.s = httptest.NewServer(http.HandlerFunc(cs.handle))
+	t.Cleanup(func() {
+		cs.close()
+		cs.save()
+	})
+	return cs
+}
+
+// NewJSONRPCReplayer returns a server serving the responses of the cassette file at path. Requests are matched by
+// method and params. Identical requests get the responses recorded in order, and then the last one again. Each
+// eth_subscribe request gets the notifications of the next subscription recorded with the same params.
+func NewJSONRPCReplayer(t *testing.T, path string) *JSONRPCCassetteServer {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err, "failed to read cassette")
+	cs := &JSONRPCCassetteServer{t: t, path: path, replayed: make(map[string]int), wsConns: make(map[*websocket.Conn]struct{})}
+	require.NoError(t, json.Unmarshal(b, &cs.cassette), "failed to parse cassette")
+	cs.s = httptest.NewServer(http.HandlerFunc(cs.handle))
+	t.Cleanup(cs.close)
+	return cs
+}
+
+func (cs *JSONRPCCassetteServer) WSURL() *url.URL {
+	return WSServerURL(cs.t, cs.s)
+}
+
+func (cs *JSONRPCCassetteServer) HTTPURL() *url.URL {
+	u, err := url.Parse(cs.s.URL)
+	require.NoError(cs.t, err, "Failed to parse url")
+	return u
+}
+
+// Cassette returns a copy of the cassette recorded or replayed.
+func (cs *JSONRPCCassetteServer) Cassette() JSONRPCCassette {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return JSONRPCCassette{
+		Interactions:  append([]JSONRPCInteraction(nil), cs.cassette.Interactions...),
+		Subscriptions: append([]JSONRPCSubscription(nil), cs.cassette.Subscriptions...),
+	}
+}
+
+// close closes the server and its websocket connections, and waits for their handlers to return.
+func (cs *JSONRPCCassetteServer) close() {
+	cs.wsMu.Lock()
+	for conn := range cs.wsConns {
+		conn.Close()
+	}
+	cs.wsConns = nil
+	cs.wsMu.Unlock()
+	cs.wsWG.Wait()
+	cs.s.CloseClientConnections()
+	cs.s.Close()
+}
+
+func (cs *JSONRPCCassetteServer) save() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	b, err := json.MarshalIndent(cs.cassette, "", "  ")
+	if err != nil {
+		cs.t.Errorf("failed to marshal cassette: %v", err)
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(cs.path), 0700); err == nil {
+		err = os.WriteFile(cs.path, append(b, '\n'), 0600)
+	}
+	if err != nil {
+		cs.t.Errorf("failed to save cassette: %v", err)
+		return
+	}
+	cs.t.Logf("Saved JSON-RPC cassette %s with %d interactions", cs.path, len(cs.cassette.Interactions))
+}
+
+func (cs *JSONRPCCassetteServer) handle(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		cs.handleWS(w, r)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var resp []byte
+	if cs.upstream != nil {
+		resp, err = cs.recordHTTP(r, body)
+	} else {
+		resp, err = cs.replayMessage(body, nil)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(resp)
+}
+
+func (cs *JSONRPCCassetteServer) handleWS(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	cs.wsMu.Lock()
+	if cs.wsConns == nil { // closed
+		cs.wsMu.Unlock()
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		cs.wsMu.Unlock()
+		return
+	}
+	cs.wsConns[conn] = struct{}{}
+	cs.wsWG.Add(1)
+	cs.wsMu.Unlock()
+	defer cs.wsWG.Done()
+	defer conn.Close()
+
+	if cs.upstream != nil {
+		cs.recordWS(r, conn)
+		return
+	}
+
+	var writeMu sync.Mutex
+	write := func(msg []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteMessage(websocket.TextMessage, msg)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var subscriptions []func()
+		resp, err := cs.replayMessage(data, func(id string, notifications []json.RawMessage) {
+			subscriptions = append(subscriptions, func() { replayNotifications(done, id, notifications, write) })
+		})
+		if err != nil {
+			return
+		}
+		if err = write(resp); err != nil {
+			return
+		}
+		// Notifications are only sent once the subscription id is.
+		for _, notify := range subscriptions {
+			go notify()
+		}
+	}
+}
+
+// replayNotifications sends the notifications of the subscription id, with replayNotifyInterval between them.
+func replayNotifications(done <-chan struct{}, id string, notifications []json.RawMessage, write func([]byte) error) {
+	for _, n := range notifications {
+		select {
+		case <-done:
+			return
+		case <-time.After(replayNotifyInterval):
+		}
+		params, err := json.Marshal(jsonrpcSubscriptionParams{Subscription: id, Result: n})
+		if err != nil {
+			return
+		}
+		msg, err := json.Marshal(jsonrpcMessage{Version: "2.0", Method: "eth_subscription", Params: params})
+		if err != nil || write(msg) != nil {
+			return
+		}
+	}
+}
+
+// replayMessage returns the response to a single or batch request. subscribed is called with the notifications to send
+// for eth_subscribe requests, and nil for HTTP requests.
+func (cs *JSONRPCCassetteServer) replayMessage(data []byte, subscribed func(id string, notifications []json.RawMessage)) ([]byte, error) {
+	if batch := bytes.TrimSpace(data); len(batch) > 0 && batch[0] == '[' {
+		var reqs []jsonrpcMessage
+		if err := json.Unmarshal(data, &reqs); err != nil {
+			return nil, err
+		}
+		resps := make([]jsonrpcMessage, len(reqs))
+		for i, req := range reqs {
+			resps[i] = cs.replayRequest(req, subscribed)
+		}
+		return json.Marshal(resps)
+	}
+	var req jsonrpcMessage
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	return json.Marshal(cs.replayRequest(req, subscribed))
+}
+
+func (cs *JSONRPCCassetteServer) replayRequest(req jsonrpcMessage, subscribed func(id string, notifications []json.RawMessage)) jsonrpcMessage {
+	resp := jsonrpcMessage{Version: "2.0", ID: req.ID}
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	key := requestKey(req.Method, req.Params)
+
+	switch req.Method {
+	case "eth_subscribe":
+		if subscribed == nil {
+			resp.Error = replayError("subscriptions are only supported over websocket")
+			return resp
+		}
+		subKey := "eth_subscribe/" + key
+		n := cs.replayed[subKey]
+		var matched int
+		for _, sub := range cs.cassette.Subscriptions {
+			if requestKey(req.Method, sub.Params) != key {
+				continue
+			}
+			if matched == n {
+				cs.replayed[subKey]++
+				cs.subscriptions++
+				id := fmt.Sprintf("0x%x", cs.subscriptions)
+				resp.Result, _ = json.Marshal(id)
+				subscribed(id, sub.Notifications)
+				return resp
+			}
+			matched++
+		}
+		// Subscribe without notifications, like a chain without new blocks.
+		cs.replayed[subKey]++
+		resp.Result = json.RawMessage(`"0x0"`)
+		return resp
+	case "eth_unsubscribe":
+		resp.Result = json.RawMessage(`true`)
+		return resp
+	}
+
+	n := cs.replayed[key]
+	var matched []JSONRPCInteraction
+	for _, i := range cs.cassette.Interactions {
+		if requestKey(i.Method, i.Params) == key {
+			matched = append(matched, i)
+		}
+	}
+	if len(matched) == 0 {
+		resp.Error = replayError(fmt.Sprintf("no recorded response for %s %s", req.Method, req.Params))
+		return resp
+	}
+	cs.replayed[key]++
+	i := matched[min(n, len(matched)-1)]
+	resp.Result, resp.Error = i.Result, i.Error
+	if resp.Result == nil && resp.Error == nil {
+		resp.Result = json.RawMessage(`null`)
+	}
+	return resp
+}
+
+func replayError(msg string) json.RawMessage {
+	b, _ := json.Marshal(struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{-32000, "cassette: " + msg})
+	return b
+}
+
+// requestKey identifies the requests with the same method and params, regardless of the params formatting.
+func requestKey(method string, params json.RawMessage) string {
+	var compact bytes.Buffer
+	if len(params) > 0 && json.Compact(&compact, params) != nil {
+		compact.Reset()
+		compact.Write(params)
+	}
+	if compact.String() == "null" {
+		compact.Reset()
+	}
+	return method + compact.String()
+}
+
+// recorder matches the responses received from upstream with their requests.
+type recorder struct {
+	cs *JSONRPCCassetteServer
+
+	mu            sync.Mutex
+	pending       map[string]jsonrpcMessage // requests by id
+	subscriptions map[string]int            // indexes of the cassette subscriptions by subscription id
+}
+
+func (cs *JSONRPCCassetteServer) newRecorder() *recorder {
+	return &recorder{cs: cs, pending: make(map[string]jsonrpcMessage), subscriptions: make(map[string]int)}
+}
+
+// requests records the requests of a single or batch request message.
+func (rec *recorder) requests(data []byte) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for _, req := range parseMessages(data) {
+		if req.Method != "" && len(req.ID) > 0 {
+			rec.pending[string(req.ID)] = req
+		}
+	}
+}
+
+// responses records the responses and notifications of a message received from upstream.
+func (rec *recorder) responses(data []byte) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.cs.mu.Lock()
+	defer rec.cs.mu.Unlock()
+	cassette := &rec.cs.cassette
+	for _, resp := range parseMessages(data) {
+		if resp.Method == "eth_subscription" {
+			var params jsonrpcSubscriptionParams
+			if err := json.Unmarshal(resp.Params, &params); err != nil {
+				continue
+			}
+			if i, ok := rec.subscriptions[params.Subscription]; ok {
+				cassette.Subscriptions[i].Notifications = append(cassette.Subscriptions[i].Notifications, params.Result)
+			}
+			continue
+		}
+		req, ok := rec.pending[string(resp.ID)]
+		if !ok {
+			continue
+		}
+		delete(rec.pending, string(resp.ID))
+		switch req.Method {
+		case "eth_subscribe":
+			var id string
+			if resp.Error == nil && json.Unmarshal(resp.Result, &id) == nil {
+				rec.subscriptions[id] = len(cassette.Subscriptions)
+				cassette.Subscriptions = append(cassette.Subscriptions, JSONRPCSubscription{Params: req.Params})
+			}
+		case "eth_unsubscribe":
+		default:
+			cassette.Interactions = append(cassette.Interactions, JSONRPCInteraction{
+				Method: req.Method,
+				Params: req.Params,
+				Result: resp.Result,
+				Error:  resp.Error,
+			})
+		}
+	}
+}
+
+func parseMessages(data []byte) []jsonrpcMessage {
+	var msgs []jsonrpcMessage
+	if err := json.Unmarshal(data, &msgs); err == nil {
+		return msgs
+	}
+	var msg jsonrpcMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil
+	}
+	return []jsonrpcMessage{msg}
+}
+
+func (cs *JSONRPCCassetteServer) recordHTTP(r *http.Request, body []byte) ([]byte, error) {
+	u := *cs.upstream
+	switch u.Scheme {
+	case "ws":
+		u.Scheme = "http"
+	case "wss":
+		u.Scheme = "https"
+	}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	rec := cs.newRecorder()
+	rec.requests(body)
+	rec.responses(respBody)
+	return respBody, nil
+}
+
+func (cs *JSONRPCCassetteServer) recordWS(r *http.Request, conn *websocket.Conn) {
+	upstream, _, err := websocket.DefaultDialer.DialContext(r.Context(), cs.upstream.String(), nil)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+
+	rec := cs.newRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			msgType, data, err := upstream.ReadMessage()
+			if err != nil {
+				conn.Close()
+				return
+			}
+			rec.responses(data)
+			if err = conn.WriteMessage(msgType, data); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		rec.requests(data)
+		if err = upstream.WriteMessage(msgType, data); err != nil {
+			break
+		}
+	}
+	upstream.Close()
+	<-done
+}
@@ -0,0 +1,142 @@
+package testutils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRPCCassetteServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	const head = `{"number":"0x2a","hash":"0x41800b5c3f1717687d85fc9018faac0a6e90b39deaa0b99e7fe4fe796ddeb26a"}`
+
+	// exercise makes the same calls over websocket and HTTP, whether recording or replaying.
+	exercise := func(t *testing.T, cs *JSONRPCCassetteServer) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		ws, err := rpc.DialContext(ctx, cs.WSURL().String())
+		require.NoError(t, err)
+		defer ws.Close()
+
+		var blockNumber hexutil.Uint64
+		require.NoError(t, ws.CallContext(ctx, &blockNumber, "eth_blockNumber"))
+		assert.Equal(t, hexutil.Uint64(1), blockNumber)
+		require.NoError(t, ws.CallContext(ctx, &blockNumber, "eth_blockNumber"))
+		assert.Equal(t, hexutil.Uint64(2), blockNumber)
+
+		var balance, code hexutil.Big
+		batch := []rpc.BatchElem{
+			{Method: "eth_getBalance", Args: []any{"0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc", "latest"}, Result: &balance},
+			{Method: "eth_getCode", Args: []any{"0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc", "latest"}, Result: &code},
+		}
+		require.NoError(t, ws.BatchCallContext(ctx, batch))
+		require.NoError(t, batch[0].Error)
+		assert.Equal(t, "0x64", balance.String())
+		require.ErrorContains(t, batch[1].Error, "no code")
+
+		heads := make(chan map[string]any)
+		sub, err := ws.EthSubscribe(ctx, heads, "newHeads")
+		require.NoError(t, err)
+		select {
+		case h := <-heads:
+			assert.Equal(t, "0x2a", h["number"])
+		case <-ctx.Done():
+			t.Fatal("no head received")
+		}
+		sub.Unsubscribe()
+
+		http, err := rpc.DialContext(ctx, cs.HTTPURL().String())
+		require.NoError(t, err)
+		defer http.Close()
+		var chainID hexutil.Big
+		require.NoError(t, http.CallContext(ctx, &chainID, "eth_chainId"))
+		assert.Equal(t, "0x1", chainID.String())
+	}
+
+	t.Run("record", func(t *testing.T) {
+		srv := rpc.NewServer()
+		require.NoError(t, srv.RegisterName("eth", &cassetteTestService{head: head}))
+		wsHandler := srv.WebsocketHandler([]string{"*"})
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Upgrade") == "websocket" {
+				wsHandler.ServeHTTP(w, r)
+				return
+			}
+			srv.ServeHTTP(w, r)
+		}))
+		t.Cleanup(upstream.Close)
+		t.Cleanup(srv.Stop)
+		exercise(t, NewJSONRPCRecorder(t, path, WSServerURL(t, upstream)))
+	})
+
+	cassette := NewJSONRPCReplayer(t, path).Cassette()
+	var methods []string
+	for _, i := range cassette.Interactions {
+		methods = append(methods, i.Method)
+	}
+	assert.ElementsMatch(t, []string{"eth_blockNumber", "eth_blockNumber", "eth_getBalance", "eth_getCode", "eth_chainId"}, methods)
+	require.Len(t, cassette.Subscriptions, 1)
+	assert.JSONEq(t, `["newHeads"]`, string(cassette.Subscriptions[0].Params))
+	require.Len(t, cassette.Subscriptions[0].Notifications, 1)
+	assert.JSONEq(t, head, string(cassette.Subscriptions[0].Notifications[0]))
+
+	t.Run("replay", func(t *testing.T) {
+		exercise(t, NewJSONRPCReplayer(t, path))
+	})
+
+	t.Run("replay unrecorded request", func(t *testing.T) {
+		cs := NewJSONRPCReplayer(t, path)
+		c, err := rpc.DialContext(context.Background(), cs.WSURL().String())
+		require.NoError(t, err)
+		defer c.Close()
+		var result any
+		require.ErrorContains(t, c.CallContext(context.Background(), &result, "eth_gasPrice"), "cassette: no recorded response for eth_gasPrice")
+	})
+}
+
+// cassetteTestService is the eth namespace of the RPC recorded.
+type cassetteTestService struct {
+	blockNumber atomic.Uint64
+	head        string
+}
+
+func (s *cassetteTestService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.blockNumber.Add(1))
+}
+
+func (s *cassetteTestService) GetBalance(string, string) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(100))
+}
+
+func (s *cassetteTestService) GetCode(string, string) (hexutil.Bytes, error) {
+	return nil, errors.New("no code")
+}
+
+func (s *cassetteTestService) ChainId() *hexutil.Big { //nolint:revive // eth_chainId
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+func (s *cassetteTestService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = notifier.Notify(sub.ID, json.RawMessage(s.head))
+	}()
+	return sub, nil
+}
@@ -1,6 +1,7 @@
 package testutils
 
 import (
+	"io/fs"
 	"net/url"
 	"os"
 	"strings"
@@ -38,6 +39,20 @@ func MustExec(t *testing.T, ds sqlutil.DataSource, stmt string, args ...interfac
 	require.NoError(t, utils.JustError(ds.ExecContext(Context(t), stmt, args...)))
 }
 
+// MigrateUp applies the Up sections of the goose migrations migrations/*.sql of fsys, which must be idempotent, to db.
+// They are the migrations of packages which are run by the node along with its own.
+func MigrateUp(t testing.TB, db *sqlx.DB, fsys fs.FS) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	require.NoError(t, err)
+	for _, f := range files {
+		b, err := fs.ReadFile(fsys, f)
+		require.NoError(t, err)
+		up, _, _ := strings.Cut(string(b), "-- +goose Down")
+		_, err = db.ExecContext(Context(t), up)
+		require.NoError(t, err, f)
+	}
+}
+
 // pristineDBName is a clean copy of test DB with migrations.
 const pristineDBName = "chainlink_test_pristine" // TODO update when splitting schemas
 
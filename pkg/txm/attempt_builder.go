@@ -2,38 +2,75 @@ package txm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	evmtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
 	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
 	"github.com/smartcontractkit/chainlink-evm/pkg/keys"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	pkgtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
 )
 
+// attemptBuilderKeystore signs attempts and the EIP-7702 authorizations of set code transactions.
+type attemptBuilderKeystore interface {
+	keys.TxSigner
+	keys.RawUnhashedSigner
+}
+
 type attemptBuilder struct {
 	gas.EvmFeeEstimator
-	priceMaxKey func(common.Address) *assets.Wei
-	keystore    keys.TxSigner
+	blobEstimator *gas.BlobEstimator
+	priceMaxKey   func(common.Address) *assets.Wei
+	keystore      attemptBuilderKeystore
 }
 
-func NewAttemptBuilder(priceMaxKey func(common.Address) *assets.Wei, estimator gas.EvmFeeEstimator, keystore keys.TxSigner) *attemptBuilder {
+// NewAttemptBuilder creates a new attemptBuilder. blobEstimator is only required to send EIP-4844 blob transactions.
+// If it's nil, attempts of transactions with a blob sidecar can't be built.
+func NewAttemptBuilder(priceMaxKey func(common.Address) *assets.Wei, estimator gas.EvmFeeEstimator, blobEstimator *gas.BlobEstimator, keystore attemptBuilderKeystore) *attemptBuilder {
 	return &attemptBuilder{
 		priceMaxKey:     priceMaxKey,
 		EvmFeeEstimator: estimator,
+		blobEstimator:   blobEstimator,
 		keystore:        keystore,
 	}
 }
 
+func (a *attemptBuilder) OnNewLongestChain(ctx context.Context, head *pkgtypes.Head) {
+	a.EvmFeeEstimator.OnNewLongestChain(ctx, head)
+	if a.blobEstimator != nil {
+		a.blobEstimator.OnNewLongestChain(ctx, head)
+	}
+}
+
 func (a *attemptBuilder) NewAttempt(ctx context.Context, lggr logger.Logger, tx *types.Transaction, dynamic bool) (*types.Attempt, error) {
 	fee, estimatedGasLimit, err := a.EvmFeeEstimator.GetFee(ctx, tx.Data, tx.SpecifiedGasLimit, a.priceMaxKey(tx.FromAddress), &tx.FromAddress, &tx.ToAddress)
 	if err != nil {
 		return nil, err
 	}
+	if tx.BlobSidecar != nil {
+		// Blob transactions can only be replaced by blob transactions with bumped fees, so the latest attempt is bumped
+		// if there is one, i.e. when the transaction gets rebroadcasted or purged.
+		var latestAttempt *types.Attempt
+		if len(tx.Attempts) > 0 {
+			latestAttempt = tx.Attempts[len(tx.Attempts)-1]
+		}
+		if fee, err = a.blobFee(ctx, lggr, tx, fee, latestAttempt, 1); err != nil {
+			return nil, err
+		}
+		return a.newCustomAttempt(ctx, tx, fee, estimatedGasLimit, evmtypes.BlobTxType, lggr)
+	}
+	if len(tx.AuthorizationList) > 0 && !tx.IsPurgeable {
+		return a.newCustomAttempt(ctx, tx, fee, estimatedGasLimit, evmtypes.SetCodeTxType, lggr)
+	}
 	txType := evmtypes.LegacyTxType
 	if dynamic {
 		txType = evmtypes.DynamicFeeTxType
@@ -41,14 +78,81 @@ func (a *attemptBuilder) NewAttempt(ctx context.Context, lggr logger.Logger, tx
 	return a.newCustomAttempt(ctx, tx, fee, estimatedGasLimit, byte(txType), lggr)
 }
 
-func (a *attemptBuilder) NewBumpAttempt(ctx context.Context, lggr logger.Logger, tx *types.Transaction, previousAttempt types.Attempt) (*types.Attempt, error) {
-	bumpedFee, bumpedFeeLimit, err := a.EvmFeeEstimator.BumpFee(ctx, previousAttempt.Fee, tx.SpecifiedGasLimit, a.priceMaxKey(tx.FromAddress), nil)
-	if err != nil {
-		return nil, err
+// NewBumpAttempt bumps the fee of the previous attempt the given number of times. Bumping stops early if the fee
+// reaches the max price of the key, in which case the last bumped fee is used. If the first bump already exceeds
+// the max price, fees.ErrBumpFeeExceedsLimit is returned.
+func (a *attemptBuilder) NewBumpAttempt(ctx context.Context, lggr logger.Logger, tx *types.Transaction, previousAttempt types.Attempt, bumps uint32) (*types.Attempt, error) {
+	if previousAttempt.Type == evmtypes.BlobTxType {
+		currentFee, estimatedGasLimit, err := a.EvmFeeEstimator.GetFee(ctx, tx.Data, tx.SpecifiedGasLimit, a.priceMaxKey(tx.FromAddress), &tx.FromAddress, &tx.ToAddress)
+		if err != nil {
+			return nil, err
+		}
+		fee, err := a.blobFee(ctx, lggr, tx, currentFee, &previousAttempt, bumps)
+		if err != nil {
+			return nil, err
+		}
+		return a.newCustomAttempt(ctx, tx, fee, estimatedGasLimit, evmtypes.BlobTxType, lggr)
+	}
+
+	priorAttempts := make([]gas.EvmPriorAttempt, 0, len(tx.Attempts))
+	for _, attempt := range tx.Attempts {
+		priorAttempts = append(priorAttempts, gas.EvmPriorAttempt{
+			ChainSpecificFeeLimit:   attempt.GasLimit,
+			BroadcastBeforeBlockNum: attempt.BroadcastBeforeBlockNum,
+			TxHash:                  attempt.Hash,
+			TxType:                  int(attempt.Type),
+			GasPrice:                attempt.Fee.GasPrice,
+			DynamicFee:              attempt.Fee.DynamicFee,
+		})
+	}
+
+	bumpedFee := previousAttempt.Fee
+	var bumpedFeeLimit uint64
+	for i := range max(bumps, 1) {
+		fee, feeLimit, err := a.EvmFeeEstimator.BumpFee(ctx, bumpedFee, tx.SpecifiedGasLimit, a.priceMaxKey(tx.FromAddress), priorAttempts)
+		if err != nil {
+			if i > 0 && errors.Is(err, fees.ErrBumpFeeExceedsLimit) {
+				lggr.Warnw("Reached max price while bumping attempt", "txID", tx.ID, "bumps", i, "fee", bumpedFee, "err", err)
+				break
+			}
+			return nil, err
+		}
+		bumpedFee, bumpedFeeLimit = fee, feeLimit
 	}
 	return a.newCustomAttempt(ctx, tx, bumpedFee, bumpedFeeLimit, previousAttempt.Type, lggr)
 }
 
+// blobFee returns the fee of a new blob attempt. If previousAttempt is nil, the blob fee cap is estimated and added to
+// currentFee. Otherwise, the fees of previousAttempt are bumped the given number of times, by at least
+// gas.BlobTxBumpPercentage as required by the blob pool. Bumping stops early if the fee reaches the max price.
+func (a *attemptBuilder) blobFee(ctx context.Context, lggr logger.Logger, tx *types.Transaction, currentFee gas.EvmFee, previousAttempt *types.Attempt, bumps uint32) (fee gas.EvmFee, err error) {
+	if a.blobEstimator == nil {
+		return fee, fmt.Errorf("cannot build blob attempt for txID: %v: blob estimator is not configured", tx.ID)
+	}
+	if !currentFee.ValidDynamic() {
+		return fee, fmt.Errorf("cannot build blob attempt for txID: %v: estimator did not return dynamic fee", tx.ID)
+	}
+	if previousAttempt == nil || previousAttempt.Type != evmtypes.BlobTxType {
+		fee.DynamicFee = currentFee.DynamicFee
+		fee.BlobFeeCap, err = a.blobEstimator.GetBlobFee(ctx)
+		return fee, err
+	}
+
+	fee = previousAttempt.Fee
+	for i := range max(bumps, 1) {
+		bumpedFee, err := a.blobEstimator.BumpFee(ctx, fee, currentFee.DynamicFee, a.priceMaxKey(tx.FromAddress))
+		if err != nil {
+			if i > 0 && errors.Is(err, fees.ErrBumpFeeExceedsLimit) {
+				lggr.Warnw("Reached max price while bumping blob attempt", "txID", tx.ID, "bumps", i, "fee", fee, "err", err)
+				break
+			}
+			return fee, err
+		}
+		fee = bumpedFee
+	}
+	return fee, nil
+}
+
 func (a *attemptBuilder) newCustomAttempt(
 	ctx context.Context,
 	tx *types.Transaction,
@@ -72,6 +176,24 @@ func (a *attemptBuilder) newCustomAttempt(
 			return
 		}
 		return a.newDynamicFeeAttempt(ctx, tx, fee.DynamicFee, estimatedGasLimit)
+	case 0x3:
+		if !fee.ValidDynamic() || fee.BlobFeeCap == nil {
+			err = fmt.Errorf("tried to create attempt of type %v for txID: %v but estimator did not return blob fee", txType, tx.ID)
+			logger.Sugared(lggr).AssumptionViolation(err.Error())
+			return
+		}
+		return a.newBlobAttempt(ctx, tx, fee, estimatedGasLimit)
+	case 0x4:
+		if !fee.ValidDynamic() {
+			err = fmt.Errorf("tried to create attempt of type %v for txID: %v but estimator did not return dynamic fee", txType, tx.ID)
+			logger.Sugared(lggr).AssumptionViolation(err.Error())
+			return
+		}
+		if tx.IsPurgeable {
+			// Purge attempts don't need to set any code
+			return a.newDynamicFeeAttempt(ctx, tx, fee.DynamicFee, estimatedGasLimit)
+		}
+		return a.newSetCodeAttempt(ctx, tx, fee.DynamicFee, estimatedGasLimit)
 	default:
 		return nil, fmt.Errorf("cannot build attempt, unrecognized transaction type: %v", txType)
 	}
@@ -153,3 +275,141 @@ func (a *attemptBuilder) newDynamicFeeAttempt(ctx context.Context, tx *types.Tra
 
 	return attempt, nil
 }
+
+func (a *attemptBuilder) newBlobAttempt(ctx context.Context, tx *types.Transaction, fee gas.EvmFee, estimatedGasLimit uint64) (*types.Attempt, error) {
+	// Purge attempts keep the sidecar, since a blob transaction can only be replaced by another blob transaction.
+	var data []byte
+	var toAddress common.Address
+	value := new(uint256.Int)
+	if !tx.IsPurgeable {
+		data = tx.Data
+		toAddress = tx.ToAddress
+		var err error
+		if value, err = txValue(tx); err != nil {
+			return nil, err
+		}
+	}
+	if tx.Nonce == nil {
+		return nil, fmt.Errorf("failed to create attempt for txID: %v: nonce empty", tx.ID)
+	}
+	if tx.BlobSidecar == nil {
+		return nil, fmt.Errorf("failed to create blob attempt for txID: %v: blob sidecar empty", tx.ID)
+	}
+	blobTx := evmtypes.BlobTx{
+		Nonce:      *tx.Nonce,
+		To:         toAddress,
+		Value:      value,
+		Gas:        estimatedGasLimit,
+		GasFeeCap:  uint256.MustFromBig(fee.GasFeeCap.ToInt()),
+		GasTipCap:  uint256.MustFromBig(fee.GasTipCap.ToInt()),
+		Data:       data,
+		BlobFeeCap: uint256.MustFromBig(fee.BlobFeeCap.ToInt()),
+		BlobHashes: tx.BlobSidecar.BlobHashes(),
+		Sidecar:    tx.BlobSidecar,
+	}
+
+	signedTx, err := a.keystore.SignTx(ctx, tx.FromAddress, evmtypes.NewTx(&blobTx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attempt for txID: %v, err: %w", tx.ID, err)
+	}
+
+	attempt := &types.Attempt{
+		TxID:              tx.ID,
+		Fee:               gas.EvmFee{DynamicFee: gas.DynamicFee{GasFeeCap: fee.GasFeeCap, GasTipCap: fee.GasTipCap}, BlobFeeCap: fee.BlobFeeCap},
+		Hash:              signedTx.Hash(),
+		GasLimit:          estimatedGasLimit,
+		Type:              evmtypes.BlobTxType,
+		SignedTransaction: signedTx,
+	}
+
+	return attempt, nil
+}
+
+// newSetCodeAttempt creates an EIP-7702 attempt and signs its authorization list. eth_estimateGas can't simulate the
+// authorizations, so the gas limit is the max of the estimated and the specified gas limit, plus the intrinsic cost of
+// each authorization. Part of that cost is refunded if the authority already exists.
+func (a *attemptBuilder) newSetCodeAttempt(ctx context.Context, tx *types.Transaction, dynamicFee gas.DynamicFee, estimatedGasLimit uint64) (*types.Attempt, error) {
+	if tx.Nonce == nil {
+		return nil, fmt.Errorf("failed to create attempt for txID: %v: nonce empty", tx.ID)
+	}
+	authList, err := a.signAuthorizationList(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	value, err := txValue(tx)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := max(estimatedGasLimit, tx.SpecifiedGasLimit) + uint64(len(authList))*params.CallNewAccountGas
+	setCodeTx := evmtypes.SetCodeTx{
+		Nonce:     *tx.Nonce,
+		To:        tx.ToAddress,
+		Value:     value,
+		Gas:       gasLimit,
+		GasFeeCap: uint256.MustFromBig(dynamicFee.GasFeeCap.ToInt()),
+		GasTipCap: uint256.MustFromBig(dynamicFee.GasTipCap.ToInt()),
+		Data:      tx.Data,
+		AuthList:  authList,
+	}
+
+	signedTx, err := a.keystore.SignTx(ctx, tx.FromAddress, evmtypes.NewTx(&setCodeTx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attempt for txID: %v, err: %w", tx.ID, err)
+	}
+
+	attempt := &types.Attempt{
+		TxID:              tx.ID,
+		Fee:               gas.EvmFee{DynamicFee: gas.DynamicFee{GasFeeCap: dynamicFee.GasFeeCap, GasTipCap: dynamicFee.GasTipCap}},
+		Hash:              signedTx.Hash(),
+		GasLimit:          gasLimit,
+		Type:              evmtypes.SetCodeTxType,
+		SignedTransaction: signedTx,
+	}
+
+	return attempt, nil
+}
+
+// signAuthorizationList signs the authorizations of tx for its chain. The sender's nonce is incremented before the
+// authorizations are processed, so the authorization nonce of FromAddress is the transaction nonce + 1.
+func (a *attemptBuilder) signAuthorizationList(ctx context.Context, tx *types.Transaction) ([]evmtypes.SetCodeAuthorization, error) {
+	if tx.ChainID == nil {
+		return nil, fmt.Errorf("failed to sign authorizations for txID: %v: chain ID empty", tx.ID)
+	}
+	chainID, overflow := uint256.FromBig(tx.ChainID)
+	if overflow {
+		return nil, fmt.Errorf("failed to sign authorizations for txID: %v: chain ID: %v overflows", tx.ID, tx.ChainID)
+	}
+	authList := make([]evmtypes.SetCodeAuthorization, 0, len(tx.AuthorizationList))
+	for _, auth := range tx.AuthorizationList {
+		var nonce uint64
+		switch {
+		case auth.Authority == tx.FromAddress:
+			nonce = *tx.Nonce + 1
+		case auth.Nonce != nil:
+			nonce = *auth.Nonce
+		default:
+			return nil, fmt.Errorf("failed to sign authorization of authority: %v for txID: %v: nonce empty", auth.Authority, tx.ID)
+		}
+		signed, err := keys.SignSetCodeAuthorization(ctx, a.keystore, auth.Authority, evmtypes.SetCodeAuthorization{
+			ChainID: *chainID,
+			Address: auth.Address,
+			Nonce:   nonce,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign authorization of authority: %v for txID: %v: %w", auth.Authority, tx.ID, err)
+		}
+		authList = append(authList, signed)
+	}
+	return authList, nil
+}
+
+// txValue returns the value of tx as a uint256. It fails if the value is negative or doesn't fit in 256 bits.
+func txValue(tx *types.Transaction) (*uint256.Int, error) {
+	if tx.Value == nil {
+		return new(uint256.Int), nil
+	}
+	if err := types.ValidateValue(tx.Value); err != nil {
+		return nil, fmt.Errorf("failed to create attempt for txID: %v: %w", tx.ID, err)
+	}
+	return uint256.MustFromBig(tx.Value), nil
+}
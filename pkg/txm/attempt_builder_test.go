@@ -1,22 +1,31 @@
 package txm
 
 import (
+	"context"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	evmtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
 	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
+	gasmocks "github.com/smartcontractkit/chainlink-evm/pkg/gas/mocks"
+	"github.com/smartcontractkit/chainlink-evm/pkg/keys"
 	"github.com/smartcontractkit/chainlink-evm/pkg/keys/keystest"
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
 )
 
 func TestAttemptBuilder_newLegacyAttempt(t *testing.T) {
-	ab := NewAttemptBuilder(nil, nil, keystest.TxSigner(nil))
+	ab := NewAttemptBuilder(nil, nil, nil, &keystest.FakeChainStore{})
 	address := testutils.NewAddress()
 	lggr := logger.Test(t)
 	var gasLimit uint64 = 100
@@ -51,7 +60,7 @@ func TestAttemptBuilder_newLegacyAttempt(t *testing.T) {
 }
 
 func TestAttemptBuilder_newDynamicFeeAttempt(t *testing.T) {
-	ab := NewAttemptBuilder(nil, nil, keystest.TxSigner(nil))
+	ab := NewAttemptBuilder(nil, nil, nil, &keystest.FakeChainStore{})
 	address := testutils.NewAddress()
 
 	lggr := logger.Test(t)
@@ -85,3 +94,204 @@ func TestAttemptBuilder_newDynamicFeeAttempt(t *testing.T) {
 		assert.Equal(t, gasLimit, a.GasLimit)
 	})
 }
+
+func TestAttemptBuilder_NewBumpAttempt(t *testing.T) {
+	address := testutils.NewAddress()
+	lggr := logger.Test(t)
+	priceMax := assets.NewWeiI(100)
+	var nonce uint64 = 1
+	previousAttempt := types.Attempt{TxID: 10, Fee: gas.EvmFee{GasPrice: assets.NewWeiI(10)}, GasLimit: 22000, Type: evmtypes.LegacyTxType}
+	tx := &types.Transaction{ID: 10, FromAddress: address, Nonce: &nonce, SpecifiedGasLimit: 22000, Attempts: []*types.Attempt{&previousAttempt}}
+
+	t.Run("bumps fee with prior attempts", func(t *testing.T) {
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		ab := NewAttemptBuilder(func(common.Address) *assets.Wei { return priceMax }, estimator, nil, &keystest.FakeChainStore{})
+		estimator.On("BumpFee", mock.Anything, previousAttempt.Fee, tx.SpecifiedGasLimit, priceMax, mock.MatchedBy(func(attempts []gas.EvmPriorAttempt) bool {
+			return len(attempts) == 1 && attempts[0].GasPrice == previousAttempt.Fee.GasPrice
+		})).Return(gas.EvmFee{GasPrice: assets.NewWeiI(12)}, uint64(22000), nil).Once()
+
+		a, err := ab.NewBumpAttempt(t.Context(), lggr, tx, previousAttempt, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "12 wei", a.Fee.GasPrice.String())
+		assert.Equal(t, uint64(22000), a.GasLimit)
+	})
+
+	t.Run("applies consecutive bumps and stops at max price", func(t *testing.T) {
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		ab := NewAttemptBuilder(func(common.Address) *assets.Wei { return priceMax }, estimator, nil, &keystest.FakeChainStore{})
+		estimator.On("BumpFee", mock.Anything, gas.EvmFee{GasPrice: assets.NewWeiI(10)}, mock.Anything, mock.Anything, mock.Anything).
+			Return(gas.EvmFee{GasPrice: assets.NewWeiI(50)}, uint64(22000), nil).Once()
+		estimator.On("BumpFee", mock.Anything, gas.EvmFee{GasPrice: assets.NewWeiI(50)}, mock.Anything, mock.Anything, mock.Anything).
+			Return(gas.EvmFee{}, uint64(0), fees.ErrBumpFeeExceedsLimit).Once()
+
+		a, err := ab.NewBumpAttempt(t.Context(), lggr, tx, previousAttempt, 4)
+		require.NoError(t, err)
+		assert.Equal(t, "50 wei", a.Fee.GasPrice.String())
+	})
+
+	t.Run("fails if the first bump exceeds max price", func(t *testing.T) {
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		ab := NewAttemptBuilder(func(common.Address) *assets.Wei { return priceMax }, estimator, nil, &keystest.FakeChainStore{})
+		estimator.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(gas.EvmFee{}, uint64(0), fees.ErrBumpFeeExceedsLimit).Once()
+
+		_, err := ab.NewBumpAttempt(t.Context(), lggr, tx, previousAttempt, 2)
+		require.ErrorIs(t, err, fees.ErrBumpFeeExceedsLimit)
+	})
+}
+
+type fakeBlobBaseFeeClient func(context.Context) (*big.Int, error)
+
+func (f fakeBlobBaseFeeClient) BlobBaseFee(ctx context.Context) (*big.Int, error) { return f(ctx) }
+
+func newTestBlobEstimator(t *testing.T, blobBaseFee int64, priceMax *assets.Wei) *gas.BlobEstimator {
+	return gas.NewBlobEstimator(logger.Test(t), fakeBlobBaseFeeClient(func(context.Context) (*big.Int, error) {
+		return big.NewInt(blobBaseFee), nil
+	}), gas.BlobEstimatorConfig{PriceMax: priceMax})
+}
+
+func TestAttemptBuilder_BlobAttempts(t *testing.T) {
+	address := testutils.NewAddress()
+	lggr := logger.Test(t)
+	priceMax := assets.NewWeiI(1000)
+	priceMaxKey := func(common.Address) *assets.Wei { return priceMax }
+	sidecar := &evmtypes.BlobTxSidecar{Blobs: []kzg4844.Blob{{}}, Commitments: []kzg4844.Commitment{{}}, Proofs: []kzg4844.Proof{{}}}
+	currentFee := gas.EvmFee{DynamicFee: gas.DynamicFee{GasTipCap: assets.NewWeiI(5), GasFeeCap: assets.NewWeiI(50)}}
+
+	t.Run("fails if blob estimator is not configured", func(t *testing.T) {
+		var nonce uint64 = 1
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		estimator.On("GetFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(currentFee, uint64(22000), nil).Once()
+		ab := NewAttemptBuilder(priceMaxKey, estimator, nil, &keystest.FakeChainStore{})
+		tx := &types.Transaction{ID: 10, FromAddress: address, Nonce: &nonce, BlobSidecar: sidecar}
+		_, err := ab.NewAttempt(t.Context(), lggr, tx, true)
+		require.ErrorContains(t, err, "blob estimator is not configured")
+	})
+
+	t.Run("creates blob attempt with sidecar", func(t *testing.T) {
+		var nonce uint64 = 1
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		estimator.On("GetFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(currentFee, uint64(22000), nil).Once()
+		ab := NewAttemptBuilder(priceMaxKey, estimator, newTestBlobEstimator(t, 10, priceMax), &keystest.FakeChainStore{})
+		tx := &types.Transaction{ID: 10, FromAddress: address, ToAddress: testutils.NewAddress(), Value: big.NewInt(1), Nonce: &nonce, BlobSidecar: sidecar}
+
+		a, err := ab.NewAttempt(t.Context(), lggr, tx, true)
+		require.NoError(t, err)
+		assert.Equal(t, evmtypes.BlobTxType, int(a.Type))
+		assert.Equal(t, "20 wei", a.Fee.BlobFeeCap.String())
+		assert.Equal(t, currentFee.DynamicFee, a.Fee.DynamicFee)
+		assert.Equal(t, sidecar.BlobHashes(), a.SignedTransaction.BlobHashes())
+		assert.Equal(t, sidecar, a.SignedTransaction.BlobTxSidecar())
+		assert.Equal(t, tx.ToAddress, *a.SignedTransaction.To())
+		// The sidecar is preserved when the attempt is copied
+		assert.Equal(t, sidecar, a.DeepCopy().SignedTransaction.BlobTxSidecar())
+	})
+
+	t.Run("fails if value is negative", func(t *testing.T) {
+		var nonce uint64 = 1
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		estimator.On("GetFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(currentFee, uint64(22000), nil).Once()
+		ab := NewAttemptBuilder(priceMaxKey, estimator, newTestBlobEstimator(t, 10, priceMax), &keystest.FakeChainStore{})
+		tx := &types.Transaction{ID: 10, FromAddress: address, ToAddress: testutils.NewAddress(), Value: big.NewInt(-1), Nonce: &nonce, BlobSidecar: sidecar}
+		_, err := ab.NewAttempt(t.Context(), lggr, tx, true)
+		require.ErrorContains(t, err, "value can't be negative")
+	})
+
+	t.Run("bumps all fees of the previous blob attempt when rebroadcasting or purging", func(t *testing.T) {
+		var nonce uint64 = 1
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		estimator.On("GetFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(currentFee, uint64(22000), nil).Once()
+		ab := NewAttemptBuilder(priceMaxKey, estimator, newTestBlobEstimator(t, 10, priceMax), &keystest.FakeChainStore{})
+		previousAttempt := &types.Attempt{TxID: 10, Type: evmtypes.BlobTxType, Fee: gas.EvmFee{DynamicFee: currentFee.DynamicFee, BlobFeeCap: assets.NewWeiI(20)}}
+		tx := &types.Transaction{ID: 10, FromAddress: address, ToAddress: testutils.NewAddress(), Data: []byte{1}, Nonce: &nonce, BlobSidecar: sidecar, IsPurgeable: true, Attempts: []*types.Attempt{previousAttempt}}
+
+		a, err := ab.NewAttempt(t.Context(), lggr, tx, true)
+		require.NoError(t, err)
+		assert.Equal(t, evmtypes.BlobTxType, int(a.Type))
+		assert.Equal(t, "10 wei", a.Fee.GasTipCap.String())
+		assert.Equal(t, "100 wei", a.Fee.GasFeeCap.String())
+		assert.Equal(t, "40 wei", a.Fee.BlobFeeCap.String())
+		assert.Empty(t, a.SignedTransaction.Data())
+		assert.Equal(t, sidecar, a.SignedTransaction.BlobTxSidecar())
+	})
+
+	t.Run("applies consecutive blob bumps and stops at max price", func(t *testing.T) {
+		var nonce uint64 = 1
+		estimator := gasmocks.NewEvmFeeEstimator(t)
+		estimator.On("GetFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(currentFee, uint64(22000), nil).Once()
+		ab := NewAttemptBuilder(priceMaxKey, estimator, newTestBlobEstimator(t, 10, priceMax), &keystest.FakeChainStore{})
+		previousAttempt := types.Attempt{TxID: 10, Type: evmtypes.BlobTxType, Fee: gas.EvmFee{DynamicFee: currentFee.DynamicFee, BlobFeeCap: assets.NewWeiI(20)}}
+		tx := &types.Transaction{ID: 10, FromAddress: address, Nonce: &nonce, BlobSidecar: sidecar, Attempts: []*types.Attempt{&previousAttempt}}
+
+		a, err := ab.NewBumpAttempt(t.Context(), lggr, tx, previousAttempt, 10)
+		require.NoError(t, err)
+		assert.Equal(t, "800 wei", a.Fee.GasFeeCap.String())
+		assert.Equal(t, "320 wei", a.Fee.BlobFeeCap.String())
+	})
+}
+
+func TestAttemptBuilder_SetCodeAttempts(t *testing.T) {
+	ks := keystest.NewMemoryChainStore()
+	address := ks.MustCreate(t)
+	authority := ks.MustCreate(t)
+	keystore := keys.NewChainStore(ks, testutils.FixtureChainID)
+	delegate := testutils.NewAddress()
+	lggr := logger.Test(t)
+	fee := gas.EvmFee{DynamicFee: gas.DynamicFee{GasTipCap: assets.NewWeiI(1), GasFeeCap: assets.NewWeiI(2)}}
+	ab := NewAttemptBuilder(nil, nil, nil, keystore)
+
+	t.Run("creates attempt with signed authorizations", func(t *testing.T) {
+		var nonce uint64 = 5
+		authorityNonce := uint64(9)
+		tx := &types.Transaction{ID: 10, ChainID: testutils.FixtureChainID, FromAddress: address, ToAddress: address, Nonce: &nonce, SpecifiedGasLimit: 50000,
+			AuthorizationList: []types.SetCodeAuthorization{
+				{Authority: address, Address: delegate},
+				{Authority: authority, Address: delegate, Nonce: &authorityNonce},
+			}}
+
+		a, err := ab.newCustomAttempt(t.Context(), tx, fee, 30000, evmtypes.SetCodeTxType, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, evmtypes.SetCodeTxType, int(a.Type))
+		assert.Equal(t, uint64(50000+2*params.CallNewAccountGas), a.GasLimit)
+		auths := a.SignedTransaction.SetCodeAuthorizations()
+		require.Len(t, auths, 2)
+		// The authorization of the sender uses the nonce after the tx nonce
+		assert.Equal(t, nonce+1, auths[0].Nonce)
+		assert.Equal(t, authorityNonce, auths[1].Nonce)
+		for i, expected := range []common.Address{address, authority} {
+			assert.Equal(t, delegate, auths[i].Address)
+			assert.Equal(t, testutils.FixtureChainID.Uint64(), auths[i].ChainID.Uint64())
+			recovered, err := auths[i].Authority()
+			require.NoError(t, err)
+			assert.Equal(t, expected, recovered)
+		}
+		sender, err := evmtypes.Sender(evmtypes.LatestSignerForChainID(testutils.FixtureChainID), a.SignedTransaction)
+		require.NoError(t, err)
+		assert.Equal(t, address, sender)
+	})
+
+	t.Run("fails if the authority is not in the keystore", func(t *testing.T) {
+		var nonce uint64 = 5
+		tx := &types.Transaction{ID: 10, ChainID: testutils.FixtureChainID, FromAddress: address, Nonce: &nonce,
+			AuthorizationList: []types.SetCodeAuthorization{{Authority: testutils.NewAddress(), Address: delegate, Nonce: &nonce}}}
+		_, err := ab.newCustomAttempt(t.Context(), tx, fee, 30000, evmtypes.SetCodeTxType, lggr)
+		require.ErrorContains(t, err, "failed to sign authorization")
+	})
+
+	t.Run("fails if value overflows", func(t *testing.T) {
+		var nonce uint64 = 5
+		tx := &types.Transaction{ID: 10, ChainID: testutils.FixtureChainID, FromAddress: address, ToAddress: address, Nonce: &nonce, Value: new(big.Int).Lsh(big.NewInt(1), 256),
+			AuthorizationList: []types.SetCodeAuthorization{{Authority: address, Address: delegate}}}
+		_, err := ab.newCustomAttempt(t.Context(), tx, fee, 30000, evmtypes.SetCodeTxType, lggr)
+		require.ErrorContains(t, err, "value doesn't fit in 256 bits")
+	})
+
+	t.Run("purge attempt doesn't set code", func(t *testing.T) {
+		var nonce uint64 = 5
+		tx := &types.Transaction{ID: 10, ChainID: testutils.FixtureChainID, FromAddress: address, Nonce: &nonce, IsPurgeable: true,
+			AuthorizationList: []types.SetCodeAuthorization{{Authority: address, Address: delegate}}}
+		a, err := ab.newCustomAttempt(t.Context(), tx, fee, 30000, evmtypes.SetCodeTxType, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, evmtypes.DynamicFeeTxType, int(a.Type))
+	})
+}
@@ -0,0 +1,55 @@
+package txm
+
+import (
+	"errors"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+)
+
+type BumpPolicyConfig struct {
+	// EscalationBlockThreshold is the number of blocks after which the number of consecutive bumps applied to a
+	// rebroadcast doubles. Zero disables escalation.
+	EscalationBlockThreshold uint32
+	// MaxBumpsPerAttempt caps the number of consecutive bumps applied to a single rebroadcast. Must be greater than 0.
+	MaxBumpsPerAttempt uint32
+}
+
+type bumpPolicy struct {
+	config BumpPolicyConfig
+}
+
+// NewBumpPolicy returns a policy that bumps the fee of the latest attempt on every rebroadcast. The bump percentage,
+// the minimum bump and the max price of each key are enforced by the fee estimator. If the transaction remains
+// unconfirmed for more than EscalationBlockThreshold blocks, bumps escalate exponentially.
+func NewBumpPolicy(config BumpPolicyConfig) (BumpPolicy, error) {
+	if config.MaxBumpsPerAttempt == 0 {
+		return nil, errors.New("MaxBumpsPerAttempt must be greater than 0")
+	}
+	return &bumpPolicy{config: config}, nil
+}
+
+// Bumps returns the number of consecutive fee bumps to apply to the latest attempt of the transaction:
+// 1 for the first EscalationBlockThreshold blocks since the first attempt, 2 for the next EscalationBlockThreshold blocks, then 4, etc.
+func (b *bumpPolicy) Bumps(tx *types.Transaction, latestBlockNumber int64) uint32 {
+	if b.config.EscalationBlockThreshold == 0 || latestBlockNumber <= 0 {
+		return 1
+	}
+
+	var firstBlockNum *int64
+	for _, a := range tx.Attempts {
+		if a.BroadcastBeforeBlockNum != nil && (firstBlockNum == nil || *a.BroadcastBeforeBlockNum < *firstBlockNum) {
+			firstBlockNum = a.BroadcastBeforeBlockNum
+		}
+	}
+	if firstBlockNum == nil || latestBlockNumber < *firstBlockNum {
+		return 1
+	}
+
+	//nolint:gosec // latestBlockNumber >= firstBlockNum
+	level := uint64(latestBlockNumber-*firstBlockNum) / uint64(b.config.EscalationBlockThreshold)
+	if level >= 32 {
+		return b.config.MaxBumpsPerAttempt
+	}
+	//nolint:gosec // the result is capped by MaxBumpsPerAttempt
+	return uint32(min(uint64(1)<<level, uint64(b.config.MaxBumpsPerAttempt)))
+}
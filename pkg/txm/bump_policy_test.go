@@ -0,0 +1,49 @@
+package txm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+)
+
+func TestBumpPolicy(t *testing.T) {
+	t.Parallel()
+
+	blockNum := int64(100)
+	tx := &types.Transaction{ID: 1, Attempts: []*types.Attempt{{}, {BroadcastBeforeBlockNum: &blockNum}}}
+
+	newBumpPolicy := func(t *testing.T, config BumpPolicyConfig) BumpPolicy {
+		b, err := NewBumpPolicy(config)
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("bumps once if escalation is disabled", func(t *testing.T) {
+		b := newBumpPolicy(t, BumpPolicyConfig{MaxBumpsPerAttempt: 8})
+		assert.Equal(t, uint32(1), b.Bumps(tx, 1000))
+	})
+
+	t.Run("bumps once if block numbers are unknown", func(t *testing.T) {
+		b := newBumpPolicy(t, BumpPolicyConfig{EscalationBlockThreshold: 5, MaxBumpsPerAttempt: 8})
+		assert.Equal(t, uint32(1), b.Bumps(tx, 0))
+		assert.Equal(t, uint32(1), b.Bumps(&types.Transaction{ID: 2, Attempts: []*types.Attempt{{}}}, 1000))
+	})
+
+	t.Run("escalates exponentially", func(t *testing.T) {
+		b := newBumpPolicy(t, BumpPolicyConfig{EscalationBlockThreshold: 5, MaxBumpsPerAttempt: 8})
+		assert.Equal(t, uint32(1), b.Bumps(tx, 104))
+		assert.Equal(t, uint32(2), b.Bumps(tx, 105))
+		assert.Equal(t, uint32(4), b.Bumps(tx, 110))
+		assert.Equal(t, uint32(8), b.Bumps(tx, 115))
+		assert.Equal(t, uint32(8), b.Bumps(tx, 120))
+		assert.Equal(t, uint32(8), b.Bumps(tx, 1000))
+	})
+
+	t.Run("requires MaxBumpsPerAttempt", func(t *testing.T) {
+		_, err := NewBumpPolicy(BumpPolicyConfig{EscalationBlockThreshold: 5})
+		require.ErrorContains(t, err, "MaxBumpsPerAttempt must be greater than 0")
+	})
+}
@@ -26,6 +26,11 @@ func (c *ChainClient) PendingNonceAt(ctx context.Context, address common.Address
 	return c.c.PendingNonceAt(ctx, address)
 }
 
+func (c *ChainClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = c.c.CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash)
+	return
+}
+
 func (c *ChainClient) SendTransaction(ctx context.Context, _ *types.Transaction, attempt *types.Attempt) error {
 	return c.c.SendTransaction(ctx, attempt.SignedTransaction)
 }
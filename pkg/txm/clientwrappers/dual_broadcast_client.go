@@ -52,6 +52,11 @@ func (d *DualBroadcastClient) PendingNonceAt(ctx context.Context, address common
 	return nonce, nil
 }
 
+func (d *DualBroadcastClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = d.c.CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash)
+	return
+}
+
 func (d *DualBroadcastClient) SendTransaction(ctx context.Context, tx *types.Transaction, attempt *types.Attempt) error {
 	meta, err := tx.GetMeta()
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -46,6 +47,11 @@ func (g *GethClient) HeadByNumber(ctx context.Context, number *big.Int) (*evmtyp
 	return head, err
 }
 
+func (g *GethClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = g.CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash)
+	return
+}
+
 func (g *GethClient) SendTransaction(ctx context.Context, _ *types.Transaction, attempt *types.Attempt) error {
 	return g.Client.SendTransaction(ctx, attempt.SignedTransaction)
 }
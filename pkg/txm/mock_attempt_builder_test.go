@@ -85,9 +85,9 @@ func (_c *mockAttemptBuilder_NewAttempt_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
-// NewBumpAttempt provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *mockAttemptBuilder) NewBumpAttempt(_a0 context.Context, _a1 logger.Logger, _a2 *types.Transaction, _a3 types.Attempt) (*types.Attempt, error) {
-	ret := _m.Called(_a0, _a1, _a2, _a3)
+// NewBumpAttempt provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *mockAttemptBuilder) NewBumpAttempt(_a0 context.Context, _a1 logger.Logger, _a2 *types.Transaction, _a3 types.Attempt, _a4 uint32) (*types.Attempt, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NewBumpAttempt")
@@ -95,19 +95,19 @@ func (_m *mockAttemptBuilder) NewBumpAttempt(_a0 context.Context, _a1 logger.Log
 
 	var r0 *types.Attempt
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, logger.Logger, *types.Transaction, types.Attempt) (*types.Attempt, error)); ok {
-		return rf(_a0, _a1, _a2, _a3)
+	if rf, ok := ret.Get(0).(func(context.Context, logger.Logger, *types.Transaction, types.Attempt, uint32) (*types.Attempt, error)); ok {
+		return rf(_a0, _a1, _a2, _a3, _a4)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, logger.Logger, *types.Transaction, types.Attempt) *types.Attempt); ok {
-		r0 = rf(_a0, _a1, _a2, _a3)
+	if rf, ok := ret.Get(0).(func(context.Context, logger.Logger, *types.Transaction, types.Attempt, uint32) *types.Attempt); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.Attempt)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, logger.Logger, *types.Transaction, types.Attempt) error); ok {
-		r1 = rf(_a0, _a1, _a2, _a3)
+	if rf, ok := ret.Get(1).(func(context.Context, logger.Logger, *types.Transaction, types.Attempt, uint32) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -125,13 +125,14 @@ type mockAttemptBuilder_NewBumpAttempt_Call struct {
 //   - _a1 logger.Logger
 //   - _a2 *types.Transaction
 //   - _a3 types.Attempt
-func (_e *mockAttemptBuilder_Expecter) NewBumpAttempt(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *mockAttemptBuilder_NewBumpAttempt_Call {
-	return &mockAttemptBuilder_NewBumpAttempt_Call{Call: _e.mock.On("NewBumpAttempt", _a0, _a1, _a2, _a3)}
+//   - _a4 uint32
+func (_e *mockAttemptBuilder_Expecter) NewBumpAttempt(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}, _a4 interface{}) *mockAttemptBuilder_NewBumpAttempt_Call {
+	return &mockAttemptBuilder_NewBumpAttempt_Call{Call: _e.mock.On("NewBumpAttempt", _a0, _a1, _a2, _a3, _a4)}
 }
 
-func (_c *mockAttemptBuilder_NewBumpAttempt_Call) Run(run func(_a0 context.Context, _a1 logger.Logger, _a2 *types.Transaction, _a3 types.Attempt)) *mockAttemptBuilder_NewBumpAttempt_Call {
+func (_c *mockAttemptBuilder_NewBumpAttempt_Call) Run(run func(_a0 context.Context, _a1 logger.Logger, _a2 *types.Transaction, _a3 types.Attempt, _a4 uint32)) *mockAttemptBuilder_NewBumpAttempt_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(logger.Logger), args[2].(*types.Transaction), args[3].(types.Attempt))
+		run(args[0].(context.Context), args[1].(logger.Logger), args[2].(*types.Transaction), args[3].(types.Attempt), args[4].(uint32))
 	})
 	return _c
 }
@@ -141,7 +142,7 @@ func (_c *mockAttemptBuilder_NewBumpAttempt_Call) Return(_a0 *types.Attempt, _a1
 	return _c
 }
 
-func (_c *mockAttemptBuilder_NewBumpAttempt_Call) RunAndReturn(run func(context.Context, logger.Logger, *types.Transaction, types.Attempt) (*types.Attempt, error)) *mockAttemptBuilder_NewBumpAttempt_Call {
+func (_c *mockAttemptBuilder_NewBumpAttempt_Call) RunAndReturn(run func(context.Context, logger.Logger, *types.Transaction, types.Attempt, uint32) (*types.Attempt, error)) *mockAttemptBuilder_NewBumpAttempt_Call {
 	_c.Call.Return(run)
 	return _c
 }
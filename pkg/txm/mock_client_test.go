@@ -189,6 +189,65 @@ func (_c *mockClient_SendTransaction_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// TransactionReceipt provides a mock function with given fields: _a0, _a1
+func (_m *mockClient) TransactionReceipt(_a0 context.Context, _a1 common.Hash) (*types.Receipt, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransactionReceipt")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.Receipt, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.Receipt); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockClient_TransactionReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransactionReceipt'
+type mockClient_TransactionReceipt_Call struct {
+	*mock.Call
+}
+
+// TransactionReceipt is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 common.Hash
+func (_e *mockClient_Expecter) TransactionReceipt(_a0 interface{}, _a1 interface{}) *mockClient_TransactionReceipt_Call {
+	return &mockClient_TransactionReceipt_Call{Call: _e.mock.On("TransactionReceipt", _a0, _a1)}
+}
+
+func (_c *mockClient_TransactionReceipt_Call) Run(run func(_a0 context.Context, _a1 common.Hash)) *mockClient_TransactionReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *mockClient_TransactionReceipt_Call) Return(_a0 *types.Receipt, _a1 error) *mockClient_TransactionReceipt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockClient_TransactionReceipt_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.Receipt, error)) *mockClient_TransactionReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockClient creates a new instance of mockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockClient(t interface {
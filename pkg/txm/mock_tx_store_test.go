@@ -72,6 +72,67 @@ func (_c *mockTxStore_AbandonPendingTransactions_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// Add provides a mock function with given fields: _a0, _a1
+func (_m *mockTxStore) Add(_a0 context.Context, _a1 ...common.Address) error {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...common.Address) error); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockTxStore_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type mockTxStore_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 ...common.Address
+func (_e *mockTxStore_Expecter) Add(_a0 interface{}, _a1 ...interface{}) *mockTxStore_Add_Call {
+	return &mockTxStore_Add_Call{Call: _e.mock.On("Add",
+		append([]interface{}{_a0}, _a1...)...)}
+}
+
+func (_c *mockTxStore_Add_Call) Run(run func(_a0 context.Context, _a1 ...common.Address)) *mockTxStore_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]common.Address, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(common.Address)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockTxStore_Add_Call) Return(_a0 error) *mockTxStore_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockTxStore_Add_Call) RunAndReturn(run func(context.Context, ...common.Address) error) *mockTxStore_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AppendAttemptToTransaction provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *mockTxStore) AppendAttemptToTransaction(_a0 context.Context, _a1 uint64, _a2 common.Address, _a3 *types.Attempt) error {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -290,6 +351,65 @@ func (_c *mockTxStore_DeleteAttemptForUnconfirmedTx_Call) RunAndReturn(run func(
 	return _c
 }
 
+// FetchHighestUnconfirmedNonce provides a mock function with given fields: _a0, _a1
+func (_m *mockTxStore) FetchHighestUnconfirmedNonce(_a0 context.Context, _a1 common.Address) (*uint64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchHighestUnconfirmedNonce")
+	}
+
+	var r0 *uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (*uint64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) *uint64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockTxStore_FetchHighestUnconfirmedNonce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchHighestUnconfirmedNonce'
+type mockTxStore_FetchHighestUnconfirmedNonce_Call struct {
+	*mock.Call
+}
+
+// FetchHighestUnconfirmedNonce is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 common.Address
+func (_e *mockTxStore_Expecter) FetchHighestUnconfirmedNonce(_a0 interface{}, _a1 interface{}) *mockTxStore_FetchHighestUnconfirmedNonce_Call {
+	return &mockTxStore_FetchHighestUnconfirmedNonce_Call{Call: _e.mock.On("FetchHighestUnconfirmedNonce", _a0, _a1)}
+}
+
+func (_c *mockTxStore_FetchHighestUnconfirmedNonce_Call) Run(run func(_a0 context.Context, _a1 common.Address)) *mockTxStore_FetchHighestUnconfirmedNonce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address))
+	})
+	return _c
+}
+
+func (_c *mockTxStore_FetchHighestUnconfirmedNonce_Call) Return(_a0 *uint64, _a1 error) *mockTxStore_FetchHighestUnconfirmedNonce_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockTxStore_FetchHighestUnconfirmedNonce_Call) RunAndReturn(run func(context.Context, common.Address) (*uint64, error)) *mockTxStore_FetchHighestUnconfirmedNonce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchUnconfirmedTransactionAtNonceWithCount provides a mock function with given fields: _a0, _a1, _a2
 func (_m *mockTxStore) FetchUnconfirmedTransactionAtNonceWithCount(_a0 context.Context, _a1 uint64, _a2 common.Address) (*types.Transaction, int, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -357,6 +477,54 @@ func (_c *mockTxStore_FetchUnconfirmedTransactionAtNonceWithCount_Call) RunAndRe
 	return _c
 }
 
+// IncrementAttemptCount provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockTxStore) IncrementAttemptCount(_a0 context.Context, _a1 uint64, _a2 common.Address) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementAttemptCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Address) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockTxStore_IncrementAttemptCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementAttemptCount'
+type mockTxStore_IncrementAttemptCount_Call struct {
+	*mock.Call
+}
+
+// IncrementAttemptCount is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint64
+//   - _a2 common.Address
+func (_e *mockTxStore_Expecter) IncrementAttemptCount(_a0 interface{}, _a1 interface{}, _a2 interface{}) *mockTxStore_IncrementAttemptCount_Call {
+	return &mockTxStore_IncrementAttemptCount_Call{Call: _e.mock.On("IncrementAttemptCount", _a0, _a1, _a2)}
+}
+
+func (_c *mockTxStore_IncrementAttemptCount_Call) Run(run func(_a0 context.Context, _a1 uint64, _a2 common.Address)) *mockTxStore_IncrementAttemptCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(common.Address))
+	})
+	return _c
+}
+
+func (_c *mockTxStore_IncrementAttemptCount_Call) Return(_a0 error) *mockTxStore_IncrementAttemptCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockTxStore_IncrementAttemptCount_Call) RunAndReturn(run func(context.Context, uint64, common.Address) error) *mockTxStore_IncrementAttemptCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MarkConfirmedAndReorgedTransactions provides a mock function with given fields: _a0, _a1, _a2
 func (_m *mockTxStore) MarkConfirmedAndReorgedTransactions(_a0 context.Context, _a1 uint64, _a2 common.Address) ([]*types.Transaction, []uint64, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -572,6 +740,54 @@ func (_c *mockTxStore_UpdateTransactionBroadcast_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// UpdateTransactionReceipt provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockTxStore) UpdateTransactionReceipt(_a0 context.Context, _a1 *types.Receipt, _a2 common.Address) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransactionReceipt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Receipt, common.Address) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockTxStore_UpdateTransactionReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransactionReceipt'
+type mockTxStore_UpdateTransactionReceipt_Call struct {
+	*mock.Call
+}
+
+// UpdateTransactionReceipt is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *types.Receipt
+//   - _a2 common.Address
+func (_e *mockTxStore_Expecter) UpdateTransactionReceipt(_a0 interface{}, _a1 interface{}, _a2 interface{}) *mockTxStore_UpdateTransactionReceipt_Call {
+	return &mockTxStore_UpdateTransactionReceipt_Call{Call: _e.mock.On("UpdateTransactionReceipt", _a0, _a1, _a2)}
+}
+
+func (_c *mockTxStore_UpdateTransactionReceipt_Call) Run(run func(_a0 context.Context, _a1 *types.Receipt, _a2 common.Address)) *mockTxStore_UpdateTransactionReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.Receipt), args[2].(common.Address))
+	})
+	return _c
+}
+
+func (_c *mockTxStore_UpdateTransactionReceipt_Call) Return(_a0 error) *mockTxStore_UpdateTransactionReceipt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockTxStore_UpdateTransactionReceipt_Call) RunAndReturn(run func(context.Context, *types.Receipt, common.Address) error) *mockTxStore_UpdateTransactionReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUnstartedTransactionWithNonce provides a mock function with given fields: _a0, _a1, _a2
 func (_m *mockTxStore) UpdateUnstartedTransactionWithNonce(_a0 context.Context, _a1 common.Address, _a2 uint64) (*types.Transaction, error) {
 	ret := _m.Called(_a0, _a1, _a2)
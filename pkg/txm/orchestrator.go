@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
@@ -29,10 +30,19 @@ import (
 	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
+// ErrTransactionNotFound is returned when no transaction has the requested IdempotencyKey.
+var ErrTransactionNotFound = errors.New("transaction not found")
+
 type OrchestratorTxStore interface {
-	Add(addresses ...common.Address) error
+	Add(ctx context.Context, addresses ...common.Address) error
 	FetchUnconfirmedTransactionAtNonceWithCount(context.Context, uint64, common.Address) (*txmtypes.Transaction, int, error)
 	FindTxWithIdempotencyKey(context.Context, string) (*txmtypes.Transaction, error)
+	FindTxesByMetaFieldAndStates(context.Context, string, string, []txmgrtypes.TxState) ([]*txmtypes.Transaction, error)
+	FindTxesWithMetaFieldByStates(context.Context, string, []txmgrtypes.TxState) ([]*txmtypes.Transaction, error)
+	FindTxesWithMetaFieldByReceiptBlockNum(context.Context, string, int64) ([]*txmtypes.Transaction, error)
+	FindTxesByIDsAndStates(context.Context, []uint64, []txmgrtypes.TxState) ([]*txmtypes.Transaction, error)
+	FindEarliestUnconfirmedBroadcastTime(context.Context) (*time.Time, error)
+	FindEarliestUnconfirmedTxAttemptBlock(context.Context) (*int64, error)
 }
 
 type OrchestratorAttemptBuilder[
@@ -93,7 +103,7 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) Start(ctx context.Context) error {
 			return err
 		}
 		for _, address := range addresses {
-			err := o.txStore.Add(address)
+			err := o.txStore.Add(ctx, address)
 			if err != nil {
 				return err
 			}
@@ -148,7 +158,7 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) RegisterResumeCallback(fn txmgr.ResumeC
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) Reset(addr common.Address, abandon bool) error {
 	ok := o.IfStarted(func() {
-		if err := o.txm.Abandon(addr); err != nil {
+		if err := o.txm.Reset(addr, abandon); err != nil {
 			o.lggr.Error(err)
 		}
 	})
@@ -158,8 +168,21 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) Reset(addr common.Address, abandon bool
 	return nil
 }
 
+// ReconcileAddresses starts and stops sending addresses to match the enabled keys of the keystore without waiting
+// for the next periodic reconciliation.
+func (o *Orchestrator[BLOCK_HASH, HEAD]) ReconcileAddresses(ctx context.Context) (err error) {
+	ok := o.IfStarted(func() {
+		err = o.txm.ReconcileAddresses(ctx)
+	})
+	if !ok {
+		return errors.New("Orchestrator not started yet")
+	}
+	return
+}
+
 func (o *Orchestrator[BLOCK_HASH, HEAD]) OnNewLongestChain(ctx context.Context, head HEAD) {
 	ok := o.IfStarted(func() {
+		o.txm.OnNewBlock(head.BlockNumber())
 		o.attemptBuilder.OnNewLongestChain(ctx, head)
 	})
 	if !ok {
@@ -243,28 +266,103 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) CreateTransaction(ctx context.Context,
 		o.txm.Trigger(request.FromAddress)
 	}
 
+	convertedTx, err := convertTx(wrappedTx)
+	if err != nil {
+		return
+	}
+	return *convertedTx, nil
+}
+
+// convertTx converts a TXMv2 transaction, along with its attempts and receipt, to the legacy Tx type for backwards compatibility.
+func convertTx(wrappedTx *txmtypes.Transaction) (*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], error) {
 	if wrappedTx.ID > math.MaxInt64 {
-		return tx, fmt.Errorf("overflow for int64: %d", wrappedTx.ID)
-	}
-
-	tx = txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee]{
-		ID:             int64(wrappedTx.ID),
-		IdempotencyKey: wrappedTx.IdempotencyKey,
-		FromAddress:    wrappedTx.FromAddress,
-		ToAddress:      wrappedTx.ToAddress,
-		EncodedPayload: wrappedTx.Data,
-		Value:          *wrappedTx.Value,
-		FeeLimit:       wrappedTx.SpecifiedGasLimit,
-		CreatedAt:      wrappedTx.CreatedAt,
-		Meta:           wrappedTx.Meta,
-		Subject:        wrappedTx.Subject,
-		ChainID:        wrappedTx.ChainID,
+		return nil, fmt.Errorf("overflow for int64: %d", wrappedTx.ID)
+	}
+
+	var value big.Int
+	if wrappedTx.Value != nil {
+		value = *wrappedTx.Value
+	}
+	tx := &txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee]{
+		ID:                 int64(wrappedTx.ID),
+		IdempotencyKey:     wrappedTx.IdempotencyKey,
+		FromAddress:        wrappedTx.FromAddress,
+		ToAddress:          wrappedTx.ToAddress,
+		EncodedPayload:     wrappedTx.Data,
+		Value:              value,
+		FeeLimit:           wrappedTx.SpecifiedGasLimit,
+		BroadcastAt:        wrappedTx.LastBroadcastAt,
+		InitialBroadcastAt: wrappedTx.InitialBroadcastAt,
+		CreatedAt:          wrappedTx.CreatedAt,
+		State:              wrappedTx.State,
+		Meta:               wrappedTx.Meta,
+		Subject:            wrappedTx.Subject,
+		ChainID:            wrappedTx.ChainID,
 
 		PipelineTaskRunID: wrappedTx.PipelineTaskRunID,
 		MinConfirmations:  wrappedTx.MinConfirmations,
 		SignalCallback:    wrappedTx.SignalCallback,
 		CallbackCompleted: wrappedTx.CallbackCompleted,
 	}
+	if wrappedTx.Nonce != nil {
+		if *wrappedTx.Nonce > math.MaxInt64 {
+			return nil, fmt.Errorf("overflow for int64: %d", *wrappedTx.Nonce)
+		}
+		nonce := evmtypes.Nonce(*wrappedTx.Nonce)
+		tx.Sequence = &nonce
+	}
+
+	for _, a := range wrappedTx.Attempts {
+		if a.ID > math.MaxInt64 {
+			return nil, fmt.Errorf("overflow for int64: %d", a.ID)
+		}
+		attempt := txmgrtypes.TxAttempt[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee]{
+			ID:                      int64(a.ID),
+			TxID:                    tx.ID,
+			Tx:                      *tx,
+			TxFee:                   a.Fee,
+			ChainSpecificFeeLimit:   a.GasLimit,
+			Hash:                    a.Hash,
+			CreatedAt:               a.CreatedAt,
+			BroadcastBeforeBlockNum: a.BroadcastBeforeBlockNum,
+			State:                   txmgrtypes.TxAttemptInProgress,
+			TxType:                  int(a.Type),
+			IsPurgeAttempt:          wrappedTx.IsPurgeable,
+		}
+		if a.BroadcastAt != nil {
+			attempt.State = txmgrtypes.TxAttemptBroadcast
+		}
+		if a.SignedTransaction != nil {
+			signedRawTx, err := a.SignedTransaction.MarshalBinary()
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode signed transaction for attempt: %v: %w", a.Hash, err)
+			}
+			attempt.SignedRawTx = signedRawTx
+		}
+		if r := wrappedTx.Receipt; r != nil && r.TxHash == a.Hash {
+			attempt.Receipts = []txmgrtypes.ChainReceipt[common.Hash, common.Hash]{&evmtypes.Receipt{
+				TxHash:            r.TxHash,
+				BlockHash:         r.BlockHash,
+				BlockNumber:       r.BlockNumber,
+				TransactionIndex:  r.TransactionIndex,
+				Status:            r.Status,
+				GasUsed:           r.GasUsed,
+				EffectiveGasPrice: r.EffectiveGasPrice,
+			}}
+		}
+		tx.TxAttempts = append(tx.TxAttempts, attempt)
+	}
+	return tx, nil
+}
+
+func (o *Orchestrator[BLOCK_HASH, HEAD]) convertTxs(wrappedTxs []*txmtypes.Transaction) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
+	for _, wrappedTx := range wrappedTxs {
+		tx, err := convertTx(wrappedTx)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
 	return
 }
 
@@ -288,28 +386,71 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) CountTransactionsByState(ctx context.Co
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindEarliestUnconfirmedBroadcastTime(ctx context.Context) (time nullv4.Time, err error) {
-	return
+	earliest, err := o.txStore.FindEarliestUnconfirmedBroadcastTime(ctx)
+	if err != nil || earliest == nil {
+		return
+	}
+	return nullv4.TimeFrom(*earliest), nil
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindEarliestUnconfirmedTxAttemptBlock(ctx context.Context) (time nullv4.Int, err error) {
-	return
+	earliest, err := o.txStore.FindEarliestUnconfirmedTxAttemptBlock(ctx)
+	if err != nil || earliest == nil {
+		return
+	}
+	return nullv4.IntFrom(*earliest), nil
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindTxesByMetaFieldAndStates(ctx context.Context, metaField string, metaValue string, states []txmgrtypes.TxState, chainID *big.Int) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
-	return
+	if chainID.Cmp(o.chainID) != 0 {
+		return
+	}
+	wrappedTxs, err := o.txStore.FindTxesByMetaFieldAndStates(ctx, metaField, metaValue, states)
+	if err != nil {
+		return
+	}
+	return o.convertTxs(wrappedTxs)
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindTxesWithMetaFieldByStates(ctx context.Context, metaField string, states []txmgrtypes.TxState, chainID *big.Int) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
-	return
+	if chainID.Cmp(o.chainID) != 0 {
+		return
+	}
+	wrappedTxs, err := o.txStore.FindTxesWithMetaFieldByStates(ctx, metaField, states)
+	if err != nil {
+		return
+	}
+	return o.convertTxs(wrappedTxs)
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindTxesWithMetaFieldByReceiptBlockNum(ctx context.Context, metaField string, blockNum int64, chainID *big.Int) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
-	return
+	if chainID.Cmp(o.chainID) != 0 {
+		return
+	}
+	wrappedTxs, err := o.txStore.FindTxesWithMetaFieldByReceiptBlockNum(ctx, metaField, blockNum)
+	if err != nil {
+		return
+	}
+	return o.convertTxs(wrappedTxs)
 }
 
 //nolint:revive // keep API backwards compatible
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindTxesWithAttemptsAndReceiptsByIdsAndState(ctx context.Context, ids []int64, states []txmgrtypes.TxState, chainID *big.Int) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
-	return
+	if chainID.Cmp(o.chainID) != 0 {
+		return
+	}
+	txIDs := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id < 0 {
+			return nil, fmt.Errorf("invalid txID: %d", id)
+		}
+		txIDs = append(txIDs, uint64(id))
+	}
+	wrappedTxs, err := o.txStore.FindTxesByIDsAndStates(ctx, txIDs, states)
+	if err != nil {
+		return
+	}
+	return o.convertTxs(wrappedTxs)
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) GetForwarderForEOA(ctx context.Context, eoa common.Address) (forwarder common.Address, err error) {
@@ -349,7 +490,23 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) GetTransactionStatus(ctx context.Contex
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) GetTransactionFee(ctx context.Context, transactionID string) (fee *evm.TransactionFee, err error) {
-	return nil, errors.New("unimplemented")
+	tx, err := o.txStore.FindTxWithIdempotencyKey(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction with IdempotencyKey %s: %w", transactionID, err)
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("%w: IdempotencyKey %s", ErrTransactionNotFound, transactionID)
+	}
+	if tx.State != txmgr.TxConfirmed && tx.State != txmgr.TxFinalized {
+		return nil, fmt.Errorf("transaction with IdempotencyKey %s is not confirmed. State: %v", transactionID, tx.State)
+	}
+
+	// The receipt is re-validated so the fee reflects the block the transaction was included in after a re-org.
+	receipt, err := o.txm.updateReceipt(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt for transaction with IdempotencyKey %s: %w", transactionID, err)
+	}
+	return &evm.TransactionFee{TransactionFee: receipt.Fee()}, nil
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) SendNativeToken(ctx context.Context, chainID *big.Int, from, to common.Address, value big.Int, gasLimit uint64) (tx txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
@@ -0,0 +1,93 @@
+package txm
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	evmtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/storage"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	pkgtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+)
+
+func TestConvertTx(t *testing.T) {
+	t.Parallel()
+
+	nonce := uint64(3)
+	now := time.Now()
+	blockNum := int64(10)
+	signedTx := evmtypes.NewTx(&evmtypes.LegacyTx{Nonce: nonce, Gas: 22000, GasPrice: big.NewInt(1)})
+	broadcastAttempt := &types.Attempt{
+		ID:                      2,
+		TxID:                    1,
+		Hash:                    signedTx.Hash(),
+		Fee:                     gas.EvmFee{GasPrice: assets.NewWeiI(1)},
+		GasLimit:                22000,
+		Type:                    evmtypes.LegacyTxType,
+		SignedTransaction:       signedTx,
+		BroadcastAt:             &now,
+		BroadcastBeforeBlockNum: &blockNum,
+	}
+	tx := &types.Transaction{
+		ID:          1,
+		ChainID:     testutils.FixtureChainID,
+		Nonce:       &nonce,
+		FromAddress: testutils.NewAddress(),
+		ToAddress:   testutils.NewAddress(),
+		Value:       big.NewInt(5),
+		State:       txmgr.TxConfirmed,
+		Attempts:    []*types.Attempt{{ID: 1, TxID: 1, Hash: testutils.NewHash()}, broadcastAttempt},
+		Receipt: &types.Receipt{
+			TxID:        1,
+			TxHash:      signedTx.Hash(),
+			BlockNumber: big.NewInt(blockNum),
+			GasUsed:     21000,
+		},
+		LastBroadcastAt: &now,
+	}
+
+	convertedTx, err := convertTx(tx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), convertedTx.ID)
+	assert.Equal(t, txmgr.TxConfirmed, convertedTx.State)
+	require.NotNil(t, convertedTx.Sequence)
+	assert.Equal(t, int64(nonce), convertedTx.Sequence.Int64())
+	assert.Equal(t, big.NewInt(5), &convertedTx.Value)
+	assert.Equal(t, &now, convertedTx.BroadcastAt)
+
+	require.Len(t, convertedTx.TxAttempts, 2)
+	assert.Equal(t, txmgrtypes.TxAttemptInProgress, convertedTx.TxAttempts[0].State)
+	assert.Empty(t, convertedTx.TxAttempts[0].Receipts)
+	attempt := convertedTx.TxAttempts[1]
+	assert.Equal(t, txmgrtypes.TxAttemptBroadcast, attempt.State)
+	assert.Equal(t, &blockNum, attempt.BroadcastBeforeBlockNum)
+	rawTx, err := signedTx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, rawTx, attempt.SignedRawTx)
+	require.Len(t, attempt.Receipts, 1)
+	assert.Equal(t, signedTx.Hash(), attempt.Receipts[0].GetTxHash())
+	assert.Equal(t, big.NewInt(blockNum), attempt.Receipts[0].GetBlockNumber())
+}
+
+func TestOrchestrator_GetTransactionFee(t *testing.T) {
+	t.Parallel()
+
+	txStore := storage.NewInMemoryStoreManager(logger.Test(t), testutils.FixtureChainID, nil)
+	o := &Orchestrator[common.Hash, *pkgtypes.Head]{txStore: txStore}
+	_, err := o.GetTransactionFee(tests.Context(t), "unknown")
+	require.ErrorIs(t, err, ErrTransactionNotFound)
+	assert.NotContains(t, err.Error(), "%!w")
+}
@@ -0,0 +1,758 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	evmtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/google/uuid"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	clnull "github.com/smartcontractkit/chainlink-common/pkg/utils/null"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+)
+
+// DBStore is a persistent implementation of the TXMv2 TxStore and OrchestratorTxStore backed by Postgres.
+// It can be used as a drop-in replacement of the InMemoryStoreManager. Unlike the in-memory store, transactions,
+// attempts and idempotency keys survive restarts. All queries are scoped to chainID.
+type DBStore struct {
+	lggr         logger.SugaredLogger
+	chainID      *big.Int
+	maxQueuedKey func(common.Address) uint32
+	ds           sqlutil.DataSource
+}
+
+// NewDBStore creates a new DBStore. maxQueuedKey returns the capacity of the unstarted transactions queue of each address.
+// If it's nil, the default capacity is used.
+func NewDBStore(lggr logger.Logger, chainID *big.Int, maxQueuedKey func(common.Address) uint32, ds sqlutil.DataSource) *DBStore {
+	return &DBStore{
+		lggr:         logger.Sugared(logger.Named(lggr, "DBStore")),
+		chainID:      chainID,
+		maxQueuedKey: maxQueuedKey,
+		ds:           ds,
+	}
+}
+
+func (s *DBStore) Transact(ctx context.Context, fn func(*DBStore) error) error {
+	return sqlutil.Transact(ctx, s.new, s.ds, nil, fn)
+}
+
+// new returns a DBStore like s, but backed by ds.
+func (s *DBStore) new(ds sqlutil.DataSource) *DBStore {
+	return &DBStore{lggr: s.lggr, chainID: s.chainID, maxQueuedKey: s.maxQueuedKey, ds: ds}
+}
+
+const transactionColumns = `id, idempotency_key, evm_chain_id, nonce, from_address, to_address, value, data, specified_gas_limit,
+	blob_sidecar, authorization_list, created_at, initial_broadcast_at, last_broadcast_at, state, is_purgeable, attempt_count, meta, subject,
+	pipeline_task_run_id, min_confirmations, signal_callback, callback_completed`
+
+const attemptColumns = `id, tx_id, hash, gas_price, gas_fee_cap, gas_tip_cap, blob_fee_cap, gas_limit, type, signed_transaction, created_at, broadcast_at,
+	broadcast_before_block_num`
+
+const receiptColumns = `tx_id, tx_hash, block_hash, block_number, transaction_index, status, gas_used, effective_gas_price, l1_fee`
+
+type dbTransaction struct {
+	ID                 uint64             `db:"id"`
+	IdempotencyKey     *string            `db:"idempotency_key"`
+	ChainID            ubig.Big           `db:"evm_chain_id"`
+	Nonce              *uint64            `db:"nonce"`
+	FromAddress        common.Address     `db:"from_address"`
+	ToAddress          common.Address     `db:"to_address"`
+	Value              ubig.Big           `db:"value"`
+	Data               []byte             `db:"data"`
+	SpecifiedGasLimit  uint64             `db:"specified_gas_limit"`
+	BlobSidecar        []byte             `db:"blob_sidecar"`
+	AuthorizationList  sqlutil.JSON       `db:"authorization_list"`
+	CreatedAt          time.Time          `db:"created_at"`
+	InitialBroadcastAt *time.Time         `db:"initial_broadcast_at"`
+	LastBroadcastAt    *time.Time         `db:"last_broadcast_at"`
+	State              txmgrtypes.TxState `db:"state"`
+	IsPurgeable        bool               `db:"is_purgeable"`
+	AttemptCount       uint16             `db:"attempt_count"`
+	Meta               *sqlutil.JSON      `db:"meta"`
+	Subject            uuid.NullUUID      `db:"subject"`
+	PipelineTaskRunID  uuid.NullUUID      `db:"pipeline_task_run_id"`
+	MinConfirmations   clnull.Uint32      `db:"min_confirmations"`
+	SignalCallback     bool               `db:"signal_callback"`
+	CallbackCompleted  bool               `db:"callback_completed"`
+}
+
+func (d *dbTransaction) toTransaction() (*types.Transaction, error) {
+	tx := &types.Transaction{
+		ID:                 d.ID,
+		IdempotencyKey:     d.IdempotencyKey,
+		ChainID:            d.ChainID.ToInt(),
+		Nonce:              d.Nonce,
+		FromAddress:        d.FromAddress,
+		ToAddress:          d.ToAddress,
+		Value:              d.Value.ToInt(),
+		Data:               d.Data,
+		SpecifiedGasLimit:  d.SpecifiedGasLimit,
+		CreatedAt:          d.CreatedAt,
+		InitialBroadcastAt: d.InitialBroadcastAt,
+		LastBroadcastAt:    d.LastBroadcastAt,
+		State:              d.State,
+		IsPurgeable:        d.IsPurgeable,
+		AttemptCount:       d.AttemptCount,
+		Meta:               d.Meta,
+		Subject:            d.Subject,
+		PipelineTaskRunID:  d.PipelineTaskRunID,
+		MinConfirmations:   d.MinConfirmations,
+		SignalCallback:     d.SignalCallback,
+		CallbackCompleted:  d.CallbackCompleted,
+	}
+	if len(d.BlobSidecar) > 0 {
+		tx.BlobSidecar = new(evmtypes.BlobTxSidecar)
+		if err := rlp.DecodeBytes(d.BlobSidecar, tx.BlobSidecar); err != nil {
+			return nil, fmt.Errorf("failed to decode blob sidecar for txID: %v: %w", d.ID, err)
+		}
+	}
+	if len(d.AuthorizationList) > 0 {
+		if err := json.Unmarshal(d.AuthorizationList, &tx.AuthorizationList); err != nil {
+			return nil, fmt.Errorf("failed to decode authorization list for txID: %v: %w", d.ID, err)
+		}
+	}
+	return tx, nil
+}
+
+type dbAttempt struct {
+	ID                      uint64      `db:"id"`
+	TxID                    uint64      `db:"tx_id"`
+	Hash                    common.Hash `db:"hash"`
+	GasPrice                *assets.Wei `db:"gas_price"`
+	GasFeeCap               *assets.Wei `db:"gas_fee_cap"`
+	GasTipCap               *assets.Wei `db:"gas_tip_cap"`
+	BlobFeeCap              *assets.Wei `db:"blob_fee_cap"`
+	GasLimit                uint64      `db:"gas_limit"`
+	Type                    byte        `db:"type"`
+	SignedTransaction       []byte      `db:"signed_transaction"`
+	CreatedAt               time.Time   `db:"created_at"`
+	BroadcastAt             *time.Time  `db:"broadcast_at"`
+	BroadcastBeforeBlockNum *int64      `db:"broadcast_before_block_num"`
+}
+
+func newDBAttempt(attempt *types.Attempt) (*dbAttempt, error) {
+	a := &dbAttempt{
+		TxID:       attempt.TxID,
+		Hash:       attempt.Hash,
+		GasPrice:   attempt.Fee.GasPrice,
+		GasFeeCap:  attempt.Fee.GasFeeCap,
+		GasTipCap:  attempt.Fee.GasTipCap,
+		BlobFeeCap: attempt.Fee.BlobFeeCap,
+		GasLimit:   attempt.GasLimit,
+		Type:       attempt.Type,
+
+		BroadcastBeforeBlockNum: attempt.BroadcastBeforeBlockNum,
+	}
+	if attempt.SignedTransaction != nil {
+		// The blob sidecar is stored once with the transaction and reattached when attempts are loaded.
+		raw, err := attempt.SignedTransaction.WithoutBlobTxSidecar().MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode signed transaction for attempt: %v: %w", attempt.Hash, err)
+		}
+		a.SignedTransaction = raw
+	}
+	return a, nil
+}
+
+func (d *dbAttempt) toAttempt() (*types.Attempt, error) {
+	a := &types.Attempt{
+		ID:   d.ID,
+		TxID: d.TxID,
+		Hash: d.Hash,
+		Fee: gas.EvmFee{
+			GasPrice:   d.GasPrice,
+			DynamicFee: gas.DynamicFee{GasFeeCap: d.GasFeeCap, GasTipCap: d.GasTipCap},
+			BlobFeeCap: d.BlobFeeCap,
+		},
+		GasLimit:    d.GasLimit,
+		Type:        d.Type,
+		CreatedAt:   d.CreatedAt,
+		BroadcastAt: d.BroadcastAt,
+
+		BroadcastBeforeBlockNum: d.BroadcastBeforeBlockNum,
+	}
+	if len(d.SignedTransaction) > 0 {
+		signedTx := new(evmtypes.Transaction)
+		if err := signedTx.UnmarshalBinary(d.SignedTransaction); err != nil {
+			return nil, fmt.Errorf("failed to decode signed transaction for attempt: %v: %w", d.Hash, err)
+		}
+		a.SignedTransaction = signedTx
+	}
+	return a, nil
+}
+
+type dbReceipt struct {
+	TxID              uint64      `db:"tx_id"`
+	TxHash            common.Hash `db:"tx_hash"`
+	BlockHash         common.Hash `db:"block_hash"`
+	BlockNumber       int64       `db:"block_number"`
+	TransactionIndex  uint        `db:"transaction_index"`
+	Status            uint64      `db:"status"`
+	GasUsed           uint64      `db:"gas_used"`
+	EffectiveGasPrice *ubig.Big   `db:"effective_gas_price"`
+	L1Fee             *ubig.Big   `db:"l1_fee"`
+}
+
+func (d *dbReceipt) toReceipt() *types.Receipt {
+	r := &types.Receipt{
+		TxID:             d.TxID,
+		TxHash:           d.TxHash,
+		BlockHash:        d.BlockHash,
+		BlockNumber:      big.NewInt(d.BlockNumber),
+		TransactionIndex: d.TransactionIndex,
+		Status:           d.Status,
+		GasUsed:          d.GasUsed,
+	}
+	if d.EffectiveGasPrice != nil {
+		r.EffectiveGasPrice = d.EffectiveGasPrice.ToInt()
+	}
+	if d.L1Fee != nil {
+		r.L1Fee = d.L1Fee.ToInt()
+	}
+	return r
+}
+
+// Add resets the in-flight attempt counter of pending transactions for the given addresses. The counter is
+// intentionally not preserved between restarts so the TXM can retry transactions that reached the max allowed attempts.
+func (s *DBStore) Add(ctx context.Context, addresses ...common.Address) error {
+	for _, address := range addresses {
+		_, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET attempt_count = 0
+			WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3`, ubig.New(s.chainID), address, txmgr.TxUnconfirmed)
+		if err != nil {
+			return fmt.Errorf("failed to add address: %v to DBStore: %w", address, err)
+		}
+	}
+	return nil
+}
+
+func (s *DBStore) AbandonPendingTransactions(ctx context.Context, fromAddress common.Address) error {
+	_, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET state = $1
+		WHERE evm_chain_id = $2 AND from_address = $3 AND state IN ($4, $5)`,
+		txmgr.TxFatalError, ubig.New(s.chainID), fromAddress, txmgr.TxUnstarted, txmgr.TxUnconfirmed)
+	return err
+}
+
+func (s *DBStore) AppendAttemptToTransaction(ctx context.Context, txNonce uint64, fromAddress common.Address, attempt *types.Attempt) error {
+	return s.Transact(ctx, func(orm *DBStore) error {
+		tx, err := orm.selectTransactionAtNonce(ctx, txNonce, fromAddress, txmgr.TxUnconfirmed, true)
+		if err != nil {
+			return err
+		}
+		if tx == nil {
+			return fmt.Errorf("unconfirmed tx was not found for nonce: %d - txID: %v", txNonce, attempt.TxID)
+		}
+		if tx.ID != attempt.TxID {
+			return fmt.Errorf("unconfirmed tx with nonce exists but attempt points to a different txID. Found Tx: %v - txID: %v", tx, attempt.TxID)
+		}
+
+		a, err := newDBAttempt(attempt)
+		if err != nil {
+			return err
+		}
+		err = orm.ds.QueryRowxContext(ctx, `INSERT INTO evm.txm_attempts
+			(tx_id, hash, gas_price, gas_fee_cap, gas_tip_cap, blob_fee_cap, gas_limit, type, signed_transaction, broadcast_before_block_num, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW()) RETURNING id, created_at`,
+			a.TxID, a.Hash, a.GasPrice, a.GasFeeCap, a.GasTipCap, a.BlobFeeCap, a.GasLimit, a.Type, a.SignedTransaction, a.BroadcastBeforeBlockNum).Scan(&attempt.ID, &attempt.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert attempt: %v for txID: %v: %w", attempt.Hash, attempt.TxID, err)
+		}
+		_, err = orm.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET attempt_count = attempt_count + 1 WHERE id = $1`, tx.ID)
+		return err
+	})
+}
+
+func (s *DBStore) CountUnstartedTransactions(ctx context.Context, fromAddress common.Address) (count int, err error) {
+	err = s.ds.GetContext(ctx, &count, `SELECT COUNT(*) FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3`, ubig.New(s.chainID), fromAddress, txmgr.TxUnstarted)
+	return
+}
+
+func (s *DBStore) CreateEmptyUnconfirmedTransaction(ctx context.Context, fromAddress common.Address, nonce uint64, gasLimit uint64) (tx *types.Transaction, err error) {
+	err = s.Transact(ctx, func(orm *DBStore) error {
+		existing, err := orm.selectTransactionAtNonce(ctx, nonce, fromAddress, txmgr.TxUnconfirmed, false)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("an unconfirmed tx with the same nonce already exists: %v", existing)
+		}
+		existing, err = orm.selectTransactionAtNonce(ctx, nonce, fromAddress, txmgr.TxConfirmed, false)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("a confirmed tx with the same nonce already exists: %v", existing)
+		}
+
+		tx, err = orm.insertTransaction(ctx, &types.Transaction{
+			ChainID:           orm.chainID,
+			Nonce:             &nonce,
+			FromAddress:       fromAddress,
+			ToAddress:         common.Address{},
+			Value:             big.NewInt(0),
+			SpecifiedGasLimit: gasLimit,
+			State:             txmgr.TxUnconfirmed,
+		})
+		return err
+	})
+	return
+}
+
+// CreateTransaction adds a new unstarted transaction to the queue. If the queue has reached its capacity, a *types.QueueFullError
+// is returned.
+func (s *DBStore) CreateTransaction(ctx context.Context, txRequest *types.TxRequest) (tx *types.Transaction, err error) {
+	err = s.Transact(ctx, func(orm *DBStore) error {
+		var uLen int
+		if uLen, err = orm.CountUnstartedTransactions(ctx, txRequest.FromAddress); err != nil {
+			return err
+		}
+		if maxQueued := orm.maxQueued(txRequest.FromAddress); uLen >= maxQueued {
+			return &types.QueueFullError{FromAddress: txRequest.FromAddress, MaxQueued: maxQueued}
+		}
+
+		tx, err = orm.insertTransaction(ctx, &types.Transaction{
+			IdempotencyKey:    txRequest.IdempotencyKey,
+			ChainID:           orm.chainID,
+			FromAddress:       txRequest.FromAddress,
+			ToAddress:         txRequest.ToAddress,
+			Value:             txRequest.Value,
+			Data:              txRequest.Data,
+			SpecifiedGasLimit: txRequest.SpecifiedGasLimit,
+			BlobSidecar:       txRequest.BlobSidecar,
+			AuthorizationList: txRequest.AuthorizationList,
+			State:             txmgr.TxUnstarted,
+			Meta:              txRequest.Meta,
+			MinConfirmations:  txRequest.MinConfirmations,
+			PipelineTaskRunID: txRequest.PipelineTaskRunID,
+			SignalCallback:    txRequest.SignalCallback,
+		})
+		return err
+	})
+	return
+}
+
+func (s *DBStore) FetchHighestUnconfirmedNonce(ctx context.Context, fromAddress common.Address) (nonce *uint64, err error) {
+	err = s.ds.GetContext(ctx, &nonce, `SELECT MAX(nonce) FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3`, ubig.New(s.chainID), fromAddress, txmgr.TxUnconfirmed)
+	return
+}
+
+func (s *DBStore) FetchUnconfirmedTransactionAtNonceWithCount(ctx context.Context, nonce uint64, fromAddress common.Address) (tx *types.Transaction, count int, err error) {
+	err = s.Transact(ctx, func(orm *DBStore) error {
+		if tx, err = orm.selectTransactionAtNonce(ctx, nonce, fromAddress, txmgr.TxUnconfirmed, false); err != nil {
+			return err
+		}
+		return orm.ds.GetContext(ctx, &count, `SELECT COUNT(*) FROM evm.txm_transactions
+			WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3`, ubig.New(orm.chainID), fromAddress, txmgr.TxUnconfirmed)
+	})
+	return
+}
+
+func (s *DBStore) MarkConfirmedAndReorgedTransactions(ctx context.Context, latestNonce uint64, fromAddress common.Address) (confirmedTxs []*types.Transaction, unconfirmedTxIDs []uint64, err error) {
+	err = s.Transact(ctx, func(orm *DBStore) error {
+		var confirmed []dbTransaction
+		err = orm.ds.SelectContext(ctx, &confirmed, `UPDATE evm.txm_transactions SET state = $1
+			WHERE evm_chain_id = $2 AND from_address = $3 AND state = $4 AND nonce < $5
+			RETURNING `+transactionColumns, txmgr.TxConfirmed, ubig.New(orm.chainID), fromAddress, txmgr.TxUnconfirmed, latestNonce)
+		if err != nil {
+			return fmt.Errorf("failed to mark transactions confirmed: %w", err)
+		}
+		if confirmedTxs, err = orm.loadTransactions(ctx, confirmed); err != nil {
+			return err
+		}
+
+		// An unconfirmed transaction can't coexist with a re-orged transaction that holds the same nonce.
+		var overwrittenTxIDs []uint64
+		err = orm.ds.SelectContext(ctx, &overwrittenTxIDs, `UPDATE evm.txm_transactions AS u SET state = $1
+			FROM evm.txm_transactions AS c
+			WHERE u.evm_chain_id = $2 AND u.from_address = $3 AND u.state = $4
+			AND c.evm_chain_id = $2 AND c.from_address = $3 AND c.state = $5 AND c.nonce >= $6 AND c.nonce = u.nonce
+			RETURNING u.id`, txmgr.TxFatalError, ubig.New(orm.chainID), fromAddress, txmgr.TxUnconfirmed, txmgr.TxConfirmed, latestNonce)
+		if err != nil {
+			return fmt.Errorf("failed to mark overwritten transactions fatal: %w", err)
+		}
+		if len(overwrittenTxIDs) > 0 {
+			orm.lggr.Errorw("Another unconfirmed transaction with the same nonce exists. Transaction will be overwritten.", "txIDs", overwrittenTxIDs)
+		}
+
+		// Mark reorged transactions as if they weren't broadcasted before
+		err = orm.ds.SelectContext(ctx, &unconfirmedTxIDs, `UPDATE evm.txm_transactions SET state = $1, last_broadcast_at = NULL
+			WHERE evm_chain_id = $2 AND from_address = $3 AND state = $4 AND nonce >= $5
+			RETURNING id`, txmgr.TxUnconfirmed, ubig.New(orm.chainID), fromAddress, txmgr.TxConfirmed, latestNonce)
+		if err != nil {
+			return fmt.Errorf("failed to mark reorged transactions unconfirmed: %w", err)
+		}
+		if len(unconfirmedTxIDs) > 0 {
+			if _, err = orm.ds.ExecContext(ctx, `DELETE FROM evm.txm_receipts WHERE tx_id = ANY($1)`, unconfirmedTxIDs); err != nil {
+				return fmt.Errorf("failed to delete receipts of reorged transactions: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	slices.SortFunc(confirmedTxs, func(a, b *types.Transaction) int { return cmp.Compare(a.ID, b.ID) })
+	slices.Sort(unconfirmedTxIDs)
+	return
+}
+
+// IncrementAttemptCount counts a rebroadcast of an existing attempt towards the max allowed attempts.
+func (s *DBStore) IncrementAttemptCount(ctx context.Context, nonce uint64, fromAddress common.Address) error {
+	res, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET attempt_count = attempt_count + 1
+		WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3 AND nonce = $4`, ubig.New(s.chainID), fromAddress, txmgr.TxUnconfirmed, nonce)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("unconfirmed tx with nonce: %d was not found", nonce)
+	}
+	return nil
+}
+
+func (s *DBStore) MarkUnconfirmedTransactionPurgeable(ctx context.Context, nonce uint64, fromAddress common.Address) error {
+	res, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET is_purgeable = true
+		WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3 AND nonce = $4`, ubig.New(s.chainID), fromAddress, txmgr.TxUnconfirmed, nonce)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("unconfirmed tx with nonce: %d was not found", nonce)
+	}
+	return nil
+}
+
+func (s *DBStore) UpdateTransactionBroadcast(ctx context.Context, txID uint64, txNonce uint64, attemptHash common.Hash, fromAddress common.Address) error {
+	return s.Transact(ctx, func(orm *DBStore) error {
+		// Set the same time for both the tx and its attempt
+		now := time.Now()
+		res, err := orm.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET last_broadcast_at = $1, initial_broadcast_at = COALESCE(initial_broadcast_at, $1)
+			WHERE evm_chain_id = $2 AND from_address = $3 AND state = $4 AND nonce = $5 AND id = $6`,
+			now, ubig.New(orm.chainID), fromAddress, txmgr.TxUnconfirmed, txNonce, txID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return fmt.Errorf("unconfirmed tx was not found for nonce: %d - txID: %v", txNonce, txID)
+		}
+		res, err = orm.ds.ExecContext(ctx, `UPDATE evm.txm_attempts SET broadcast_at = $1 WHERE tx_id = $2 AND hash = $3`, now, txID, attemptHash)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return fmt.Errorf("UpdateTransactionBroadcast failed to find attempt. attempt with hash: %v was not found", attemptHash)
+		}
+		return nil
+	})
+}
+
+func (s *DBStore) UpdateTransactionReceipt(ctx context.Context, receipt *types.Receipt, fromAddress common.Address) error {
+	if receipt.BlockNumber == nil || !receipt.BlockNumber.IsInt64() {
+		return fmt.Errorf("invalid block number: %v for receipt of txID: %v", receipt.BlockNumber, receipt.TxID)
+	}
+	var effectiveGasPrice, l1Fee *ubig.Big
+	if receipt.EffectiveGasPrice != nil {
+		effectiveGasPrice = ubig.New(receipt.EffectiveGasPrice)
+	}
+	if receipt.L1Fee != nil {
+		l1Fee = ubig.New(receipt.L1Fee)
+	}
+	res, err := s.ds.ExecContext(ctx, `INSERT INTO evm.txm_receipts
+		(tx_id, tx_hash, block_hash, block_number, transaction_index, status, gas_used, effective_gas_price, l1_fee, created_at)
+		SELECT id, $1, $2, $3, $4, $5, $6, $7, $8, NOW() FROM evm.txm_transactions
+		WHERE evm_chain_id = $9 AND from_address = $10 AND id = $11 AND state = $12
+		ON CONFLICT (tx_id) DO UPDATE SET tx_hash = EXCLUDED.tx_hash, block_hash = EXCLUDED.block_hash, block_number = EXCLUDED.block_number,
+		transaction_index = EXCLUDED.transaction_index, status = EXCLUDED.status, gas_used = EXCLUDED.gas_used,
+		effective_gas_price = EXCLUDED.effective_gas_price, l1_fee = EXCLUDED.l1_fee, created_at = EXCLUDED.created_at`,
+		receipt.TxHash, receipt.BlockHash, receipt.BlockNumber.Int64(), receipt.TransactionIndex, receipt.Status, receipt.GasUsed, effectiveGasPrice, l1Fee,
+		ubig.New(s.chainID), fromAddress, receipt.TxID, txmgr.TxConfirmed)
+	if err != nil {
+		return fmt.Errorf("failed to insert receipt for txID: %v: %w", receipt.TxID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("confirmed tx was not found for txID: %v", receipt.TxID)
+	}
+	return nil
+}
+
+func (s *DBStore) UpdateUnstartedTransactionWithNonce(ctx context.Context, fromAddress common.Address, nonce uint64) (tx *types.Transaction, err error) {
+	err = s.Transact(ctx, func(orm *DBStore) error {
+		var unstarted dbTransaction
+		err = orm.ds.GetContext(ctx, &unstarted, `SELECT `+transactionColumns+` FROM evm.txm_transactions
+			WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3
+			ORDER BY id ASC LIMIT 1 FOR UPDATE SKIP LOCKED`, ubig.New(orm.chainID), fromAddress, txmgr.TxUnstarted)
+		if errors.Is(err, sql.ErrNoRows) {
+			orm.lggr.Debugf("Unstarted transactions queue is empty for address: %v", fromAddress)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		existing, err := orm.selectTransactionAtNonce(ctx, nonce, fromAddress, txmgr.TxUnconfirmed, false)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("an unconfirmed tx with the same nonce already exists: %v", existing)
+		}
+
+		_, err = orm.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET nonce = $1, state = $2 WHERE id = $3`, nonce, txmgr.TxUnconfirmed, unstarted.ID)
+		if err != nil {
+			return err
+		}
+		if tx, err = unstarted.toTransaction(); err != nil {
+			return err
+		}
+		tx.Nonce = &nonce
+		tx.State = txmgr.TxUnconfirmed
+		return nil
+	})
+	return
+}
+
+// Error Handler
+func (s *DBStore) DeleteAttemptForUnconfirmedTx(ctx context.Context, transactionNonce uint64, attempt *types.Attempt, fromAddress common.Address) error {
+	return s.Transact(ctx, func(orm *DBStore) error {
+		tx, err := orm.selectTransactionAtNonce(ctx, transactionNonce, fromAddress, txmgr.TxUnconfirmed, true)
+		if err != nil {
+			return err
+		}
+		if tx == nil {
+			return fmt.Errorf("unconfirmed tx was not found for nonce: %d - txID: %v", transactionNonce, attempt.TxID)
+		}
+		res, err := orm.ds.ExecContext(ctx, `DELETE FROM evm.txm_attempts WHERE tx_id = $1 AND hash = $2`, tx.ID, attempt.Hash)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return fmt.Errorf("attempt with hash: %v for txID: %v was not found", attempt.Hash, attempt.TxID)
+		}
+		return nil
+	})
+}
+
+func (s *DBStore) MarkTxFatal(ctx context.Context, tx *types.Transaction, fromAddress common.Address) error {
+	res, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET state = $1
+		WHERE evm_chain_id = $2 AND from_address = $3 AND id = $4`, txmgr.TxFatalError, ubig.New(s.chainID), fromAddress, tx.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("tx with ID: %v was not found", tx.ID)
+	}
+	return nil
+}
+
+// Orchestrator
+func (s *DBStore) FindTxWithIdempotencyKey(ctx context.Context, idempotencyKey string) (*types.Transaction, error) {
+	var dbTxs []dbTransaction
+	err := s.ds.SelectContext(ctx, &dbTxs, `SELECT `+transactionColumns+` FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND idempotency_key = $2`, ubig.New(s.chainID), idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := s.loadTransactions(ctx, dbTxs)
+	if err != nil || len(txs) == 0 {
+		return nil, err
+	}
+	return txs[0], nil
+}
+
+func (s *DBStore) FindTxesByMetaFieldAndStates(ctx context.Context, metaField string, metaValue string, states []txmgrtypes.TxState) ([]*types.Transaction, error) {
+	return s.selectTransactions(ctx, `SELECT `+transactionColumns+` FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND meta ? $2 AND meta->>$2 = $3 AND state = ANY($4) ORDER BY id ASC`,
+		ubig.New(s.chainID), metaField, metaValue, statesToStrings(states))
+}
+
+func (s *DBStore) FindTxesWithMetaFieldByStates(ctx context.Context, metaField string, states []txmgrtypes.TxState) ([]*types.Transaction, error) {
+	return s.selectTransactions(ctx, `SELECT `+transactionColumns+` FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND meta ? $2 AND state = ANY($3) ORDER BY id ASC`,
+		ubig.New(s.chainID), metaField, statesToStrings(states))
+}
+
+func (s *DBStore) FindTxesWithMetaFieldByReceiptBlockNum(ctx context.Context, metaField string, blockNum int64) ([]*types.Transaction, error) {
+	return s.selectTransactions(ctx, `SELECT `+prefixColumns("t", transactionColumns)+` FROM evm.txm_transactions AS t
+		JOIN evm.txm_receipts AS r ON r.tx_id = t.id
+		WHERE t.evm_chain_id = $1 AND t.meta ? $2 AND r.block_number >= $3 ORDER BY t.id ASC`,
+		ubig.New(s.chainID), metaField, blockNum)
+}
+
+func (s *DBStore) FindTxesByIDsAndStates(ctx context.Context, ids []uint64, states []txmgrtypes.TxState) ([]*types.Transaction, error) {
+	return s.selectTransactions(ctx, `SELECT `+transactionColumns+` FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND id = ANY($2) AND state = ANY($3) ORDER BY id ASC`,
+		ubig.New(s.chainID), ids, statesToStrings(states))
+}
+
+func (s *DBStore) FindEarliestUnconfirmedBroadcastTime(ctx context.Context) (earliest *time.Time, err error) {
+	err = s.ds.GetContext(ctx, &earliest, `SELECT MIN(initial_broadcast_at) FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND state = $2`, ubig.New(s.chainID), txmgr.TxUnconfirmed)
+	return
+}
+
+func (s *DBStore) FindEarliestUnconfirmedTxAttemptBlock(ctx context.Context) (earliest *int64, err error) {
+	err = s.ds.GetContext(ctx, &earliest, `SELECT MIN(a.broadcast_before_block_num) FROM evm.txm_attempts AS a
+		JOIN evm.txm_transactions AS t ON t.id = a.tx_id
+		WHERE t.evm_chain_id = $1 AND t.state = $2`, ubig.New(s.chainID), txmgr.TxUnconfirmed)
+	return
+}
+
+func (s *DBStore) selectTransactions(ctx context.Context, query string, args ...any) ([]*types.Transaction, error) {
+	var dbTxs []dbTransaction
+	if err := s.ds.SelectContext(ctx, &dbTxs, query, args...); err != nil {
+		return nil, err
+	}
+	return s.loadTransactions(ctx, dbTxs)
+}
+
+func statesToStrings(states []txmgrtypes.TxState) []string {
+	strs := make([]string, 0, len(states))
+	for _, state := range states {
+		strs = append(strs, string(state))
+	}
+	return strs
+}
+
+// prefixColumns qualifies a comma separated list of columns with the given table alias.
+func prefixColumns(alias string, columns string) string {
+	fields := strings.Split(columns, ",")
+	for i, f := range fields {
+		fields[i] = alias + "." + strings.TrimSpace(f)
+	}
+	return strings.Join(fields, ", ")
+}
+
+func (s *DBStore) insertTransaction(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	value := tx.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	data := tx.Data
+	if data == nil {
+		data = []byte{}
+	}
+	var blobSidecar []byte
+	if tx.BlobSidecar != nil {
+		var err error
+		if blobSidecar, err = rlp.EncodeToBytes(tx.BlobSidecar); err != nil {
+			return nil, fmt.Errorf("failed to encode blob sidecar: %w", err)
+		}
+	}
+	var authorizationList sqlutil.JSON
+	if len(tx.AuthorizationList) > 0 {
+		var err error
+		if authorizationList, err = json.Marshal(tx.AuthorizationList); err != nil {
+			return nil, fmt.Errorf("failed to encode authorization list: %w", err)
+		}
+	}
+	err := s.ds.QueryRowxContext(ctx, `INSERT INTO evm.txm_transactions
+		(idempotency_key, evm_chain_id, nonce, from_address, to_address, value, data, specified_gas_limit, blob_sidecar, authorization_list,
+		created_at, state, meta, pipeline_task_run_id, min_confirmations, signal_callback)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), $11, $12, $13, $14, $15) RETURNING id, created_at`,
+		tx.IdempotencyKey, ubig.New(s.chainID), tx.Nonce, tx.FromAddress, tx.ToAddress, ubig.New(value), data, tx.SpecifiedGasLimit, blobSidecar, authorizationList, tx.State,
+		tx.Meta, tx.PipelineTaskRunID, tx.MinConfirmations, tx.SignalCallback).Scan(&tx.ID, &tx.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert transaction: %w", err)
+	}
+	return tx, nil
+}
+
+func (s *DBStore) selectTransactionAtNonce(ctx context.Context, nonce uint64, fromAddress common.Address, state txmgrtypes.TxState, forUpdate bool) (*types.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM evm.txm_transactions
+		WHERE evm_chain_id = $1 AND from_address = $2 AND state = $3 AND nonce = $4 ORDER BY id DESC`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	var dbTxs []dbTransaction
+	if err := s.ds.SelectContext(ctx, &dbTxs, query, ubig.New(s.chainID), fromAddress, state, nonce); err != nil {
+		return nil, err
+	}
+	txs, err := s.loadTransactions(ctx, dbTxs[:min(len(dbTxs), 1)])
+	if err != nil || len(txs) == 0 {
+		return nil, err
+	}
+	return txs[0], nil
+}
+
+// loadTransactions converts dbTxs and populates their attempts ordered by creation and their receipts.
+func (s *DBStore) loadTransactions(ctx context.Context, dbTxs []dbTransaction) ([]*types.Transaction, error) {
+	if len(dbTxs) == 0 {
+		return nil, nil
+	}
+	txs := make([]*types.Transaction, 0, len(dbTxs))
+	txMap := make(map[uint64]*types.Transaction, len(dbTxs))
+	txIDs := make([]uint64, 0, len(dbTxs))
+	for i := range dbTxs {
+		tx, err := dbTxs[i].toTransaction()
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+		txMap[tx.ID] = tx
+		txIDs = append(txIDs, tx.ID)
+	}
+
+	var dbAttempts []dbAttempt
+	if err := s.ds.SelectContext(ctx, &dbAttempts, `SELECT `+attemptColumns+` FROM evm.txm_attempts WHERE tx_id = ANY($1) ORDER BY id ASC`, txIDs); err != nil {
+		return nil, fmt.Errorf("failed to load attempts: %w", err)
+	}
+	for i := range dbAttempts {
+		attempt, err := dbAttempts[i].toAttempt()
+		if err != nil {
+			return nil, err
+		}
+		tx := txMap[attempt.TxID]
+		if tx.BlobSidecar != nil && attempt.SignedTransaction != nil && attempt.SignedTransaction.Type() == evmtypes.BlobTxType {
+			attempt.SignedTransaction = attempt.SignedTransaction.WithBlobTxSidecar(tx.BlobSidecar)
+		}
+		tx.Attempts = append(tx.Attempts, attempt)
+	}
+
+	var dbReceipts []dbReceipt
+	if err := s.ds.SelectContext(ctx, &dbReceipts, `SELECT `+receiptColumns+` FROM evm.txm_receipts WHERE tx_id = ANY($1)`, txIDs); err != nil {
+		return nil, fmt.Errorf("failed to load receipts: %w", err)
+	}
+	for i := range dbReceipts {
+		receipt := dbReceipts[i].toReceipt()
+		txMap[receipt.TxID].Receipt = receipt
+	}
+	return txs, nil
+}
+
+func (s *DBStore) maxQueued(address common.Address) int {
+	if s.maxQueuedKey != nil {
+		return int(s.maxQueuedKey(address))
+	}
+	return maxQueuedTransactions
+}
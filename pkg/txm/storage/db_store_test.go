@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+
+	evmtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+)
+
+func TestDBStore_TransactionLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db := testutils.NewSqlxDB(t)
+	testutils.MigrateUp(t, db, Migrations)
+	s := NewDBStore(logger.Test(t), testutils.FixtureChainID, nil, db)
+	fromAddress := testutils.NewAddress()
+	require.NoError(t, s.Add(t.Context(), fromAddress))
+
+	IDK := "IDK"
+	tx, err := s.CreateTransaction(ctx, &types.TxRequest{
+		IdempotencyKey:    &IDK,
+		FromAddress:       fromAddress,
+		ToAddress:         testutils.NewAddress(),
+		Value:             big.NewInt(10),
+		Data:              []byte{1, 2, 3},
+		SpecifiedGasLimit: 22000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, txmgr.TxUnstarted, tx.State)
+
+	count, err := s.CountUnstartedTransactions(ctx, fromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	tx, err = s.UpdateUnstartedTransactionWithNonce(ctx, fromAddress, 0)
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	assert.Equal(t, uint64(0), *tx.Nonce)
+
+	tx, err = s.UpdateUnstartedTransactionWithNonce(ctx, fromAddress, 1)
+	require.NoError(t, err)
+	assert.Nil(t, tx)
+
+	signedTx := evmtypes.NewTx(&evmtypes.LegacyTx{Nonce: 0, Gas: 22000, GasPrice: big.NewInt(1)})
+	attempt := &types.Attempt{
+		TxID:              0,
+		Hash:              signedTx.Hash(),
+		Fee:               gas.EvmFee{GasPrice: assets.NewWeiI(1)},
+		GasLimit:          22000,
+		Type:              evmtypes.LegacyTxType,
+		SignedTransaction: signedTx,
+	}
+	tx, err = s.FindTxWithIdempotencyKey(ctx, IDK)
+	require.NoError(t, err)
+	attempt.TxID = tx.ID
+	require.NoError(t, s.AppendAttemptToTransaction(ctx, 0, fromAddress, attempt))
+	require.NoError(t, s.UpdateTransactionBroadcast(ctx, tx.ID, 0, attempt.Hash, fromAddress))
+
+	// A new store instance simulates a restart
+	s = NewDBStore(logger.Test(t), testutils.FixtureChainID, nil, db)
+	require.NoError(t, s.Add(t.Context(), fromAddress))
+	tx, count, err = s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 0, fromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.NotNil(t, tx)
+	assert.Equal(t, uint16(0), tx.AttemptCount)
+	assert.NotNil(t, tx.LastBroadcastAt)
+	assert.NotNil(t, tx.InitialBroadcastAt)
+	require.Len(t, tx.Attempts, 1)
+	assert.Equal(t, attempt.Hash, tx.Attempts[0].Hash)
+	assert.Equal(t, attempt.Hash, tx.Attempts[0].SignedTransaction.Hash())
+	assert.NotNil(t, tx.Attempts[0].BroadcastAt)
+
+	require.NoError(t, s.IncrementAttemptCount(ctx, 0, fromAddress))
+	tx, _, err = s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 0, fromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1), tx.AttemptCount)
+
+	nonce, err := s.FetchHighestUnconfirmedNonce(ctx, fromAddress)
+	require.NoError(t, err)
+	require.NotNil(t, nonce)
+	assert.Equal(t, uint64(0), *nonce)
+
+	confirmedTxs, unconfirmedTxIDs, err := s.MarkConfirmedAndReorgedTransactions(ctx, 1, fromAddress)
+	require.NoError(t, err)
+	require.Len(t, confirmedTxs, 1)
+	assert.Equal(t, tx.ID, confirmedTxs[0].ID)
+	assert.Empty(t, unconfirmedTxIDs)
+
+	_, err = s.CreateEmptyUnconfirmedTransaction(ctx, fromAddress, 0, 22000)
+	require.ErrorContains(t, err, "a confirmed tx with the same nonce already exists")
+
+	// Re-org
+	confirmedTxs, unconfirmedTxIDs, err = s.MarkConfirmedAndReorgedTransactions(ctx, 0, fromAddress)
+	require.NoError(t, err)
+	assert.Empty(t, confirmedTxs)
+	assert.Equal(t, []uint64{tx.ID}, unconfirmedTxIDs)
+	tx, _, err = s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 0, fromAddress)
+	require.NoError(t, err)
+	assert.Nil(t, tx.LastBroadcastAt)
+
+	require.NoError(t, s.MarkUnconfirmedTransactionPurgeable(ctx, 0, fromAddress))
+	require.NoError(t, s.DeleteAttemptForUnconfirmedTx(ctx, 0, attempt, fromAddress))
+	require.NoError(t, s.AbandonPendingTransactions(ctx, fromAddress))
+	tx, err = s.FindTxWithIdempotencyKey(ctx, IDK)
+	require.NoError(t, err)
+	assert.Equal(t, txmgr.TxFatalError, tx.State)
+	assert.True(t, tx.IsPurgeable)
+	assert.Empty(t, tx.Attempts)
+}
+
+func TestDBStore_CreateEmptyUnconfirmedTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db := testutils.NewSqlxDB(t)
+	testutils.MigrateUp(t, db, Migrations)
+	s := NewDBStore(logger.Test(t), testutils.FixtureChainID, nil, db)
+	fromAddress := testutils.NewAddress()
+
+	tx, err := s.CreateEmptyUnconfirmedTransaction(ctx, fromAddress, 5, 22000)
+	require.NoError(t, err)
+	assert.Equal(t, txmgr.TxUnconfirmed, tx.State)
+	assert.Equal(t, uint64(5), *tx.Nonce)
+
+	_, err = s.CreateEmptyUnconfirmedTransaction(ctx, fromAddress, 5, 22000)
+	require.ErrorContains(t, err, "an unconfirmed tx with the same nonce already exists")
+}
@@ -1,11 +1,14 @@
 package storage
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -13,10 +16,11 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
 const (
-	// maxQueuedTransactions is the max limit of UnstartedTransactions and ConfirmedTransactions structures.
+	// maxQueuedTransactions is the default max limit of UnstartedTransactions and the max limit of ConfirmedTransactions structures.
 	maxQueuedTransactions = 250
 	// pruneSubset controls the subset of confirmed transactions to prune when the structure reaches its max limit.
 	// i.e. if the value is 3 and the limit is 90, 30 transactions will be pruned.
@@ -27,8 +31,12 @@ type InMemoryStore struct {
 	sync.RWMutex
 	lggr      logger.Logger
 	txIDCount uint64
+	// txIDs, if set, hands out the transaction IDs instead of txIDCount. It's shared by the stores of a manager so that
+	// IDs are unique across addresses.
+	txIDs     *atomic.Uint64
 	address   common.Address
 	chainID   *big.Int
+	maxQueued int
 
 	UnstartedTransactions   []*types.Transaction
 	UnconfirmedTransactions map[uint64]*types.Transaction
@@ -36,6 +44,8 @@ type InMemoryStore struct {
 	FatalTransactions       []*types.Transaction
 
 	Transactions map[uint64]*types.Transaction
+	// metaIndex maps top-level TxMeta fields to the IDs of the transactions that contain them
+	metaIndex map[string]map[uint64]struct{}
 }
 
 func NewInMemoryStore(lggr logger.Logger, address common.Address, chainID *big.Int) *InMemoryStore {
@@ -43,10 +53,12 @@ func NewInMemoryStore(lggr logger.Logger, address common.Address, chainID *big.I
 		lggr:                    logger.Named(lggr, "InMemoryStore"),
 		address:                 address,
 		chainID:                 chainID,
+		maxQueued:               maxQueuedTransactions,
 		UnstartedTransactions:   make([]*types.Transaction, 0, maxQueuedTransactions),
 		UnconfirmedTransactions: make(map[uint64]*types.Transaction),
 		ConfirmedTransactions:   make(map[uint64]*types.Transaction, maxQueuedTransactions),
 		Transactions:            make(map[uint64]*types.Transaction),
+		metaIndex:               make(map[string]map[uint64]struct{}),
 	}
 }
 
@@ -59,6 +71,7 @@ func (m *InMemoryStore) AbandonPendingTransactions() {
 		tx.State = txmgr.TxFatalError
 	}
 	for _, tx := range m.FatalTransactions {
+		m.unindexMeta(tx)
 		delete(m.Transactions, tx.ID)
 	}
 	m.FatalTransactions = m.UnstartedTransactions
@@ -104,7 +117,6 @@ func (m *InMemoryStore) CreateEmptyUnconfirmedTransaction(nonce uint64, gasLimit
 	defer m.Unlock()
 
 	emptyTx := &types.Transaction{
-		ID:                m.txIDCount,
 		ChainID:           m.chainID,
 		Nonce:             &nonce,
 		FromAddress:       m.address,
@@ -123,19 +135,25 @@ func (m *InMemoryStore) CreateEmptyUnconfirmedTransaction(nonce uint64, gasLimit
 		return nil, fmt.Errorf("a confirmed tx with the same nonce already exists: %v", m.ConfirmedTransactions[nonce])
 	}
 
-	m.txIDCount++
+	emptyTx.ID = m.nextTxID()
 	m.UnconfirmedTransactions[nonce] = emptyTx
 	m.Transactions[emptyTx.ID] = emptyTx
 
 	return emptyTx.DeepCopy(), nil
 }
 
-func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) *types.Transaction {
+// CreateTransaction adds a new unstarted transaction to the queue. If the queue has reached its capacity, a *types.QueueFullError
+// is returned.
+func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) (*types.Transaction, error) {
 	m.Lock()
 	defer m.Unlock()
 
+	if len(m.UnstartedTransactions) >= m.maxQueued {
+		return nil, &types.QueueFullError{FromAddress: m.address, MaxQueued: m.maxQueued}
+	}
+
 	tx := &types.Transaction{
-		ID:                m.txIDCount,
+		ID:                m.nextTxID(),
 		IdempotencyKey:    txRequest.IdempotencyKey,
 		ChainID:           m.chainID,
 		FromAddress:       m.address,
@@ -143,6 +161,8 @@ func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) *types.Tra
 		Value:             txRequest.Value,
 		Data:              txRequest.Data,
 		SpecifiedGasLimit: txRequest.SpecifiedGasLimit,
+		BlobSidecar:       txRequest.BlobSidecar,
+		AuthorizationList: slices.Clone(txRequest.AuthorizationList),
 		CreatedAt:         time.Now(),
 		State:             txmgr.TxUnstarted,
 		Meta:              txRequest.Meta,
@@ -151,21 +171,33 @@ func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) *types.Tra
 		SignalCallback:    txRequest.SignalCallback,
 	}
 
-	uLen := len(m.UnstartedTransactions)
-	if uLen >= maxQueuedTransactions {
-		m.lggr.Warnw(fmt.Sprintf("Unstarted transactions queue for address: %v reached max limit of: %d. Dropping oldest transactions", m.address, maxQueuedTransactions),
-			"txs", m.UnstartedTransactions[0:uLen-maxQueuedTransactions+1]) // need to make room for the new tx
-		for _, tx := range m.UnstartedTransactions[0 : uLen-maxQueuedTransactions+1] {
-			delete(m.Transactions, tx.ID)
-		}
-		m.UnstartedTransactions = m.UnstartedTransactions[uLen-maxQueuedTransactions+1:]
-	}
-
-	m.txIDCount++
 	txCopy := tx.DeepCopy()
 	m.Transactions[txCopy.ID] = txCopy
+	m.indexMeta(txCopy)
 	m.UnstartedTransactions = append(m.UnstartedTransactions, txCopy)
-	return tx
+	return tx, nil
+}
+
+func (m *InMemoryStore) nextTxID() uint64 {
+	if m.txIDs != nil {
+		return m.txIDs.Add(1) - 1
+	}
+	id := m.txIDCount
+	m.txIDCount++
+	return id
+}
+
+func (m *InMemoryStore) FetchHighestUnconfirmedNonce() *uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	var highestNonce *uint64
+	for nonce := range m.UnconfirmedTransactions {
+		if highestNonce == nil || nonce > *highestNonce {
+			highestNonce = &nonce
+		}
+	}
+	return highestNonce
 }
 
 func (m *InMemoryStore) FetchUnconfirmedTransactionAtNonceWithCount(latestNonce uint64) (txCopy *types.Transaction, unconfirmedCount int) {
@@ -215,6 +247,7 @@ func (m *InMemoryStore) MarkConfirmedAndReorgedTransactions(latestNonce uint64)
 		if *tx.Nonce >= latestNonce {
 			tx.State = txmgr.TxUnconfirmed
 			tx.LastBroadcastAt = nil // Mark reorged transaction as if it wasn't broadcasted before
+			tx.Receipt = nil
 			unconfirmedTransactionIDs = append(unconfirmedTransactionIDs, tx.ID)
 			m.UnconfirmedTransactions[*tx.Nonce] = tx
 			delete(m.ConfirmedTransactions, *tx.Nonce)
@@ -231,6 +264,21 @@ func (m *InMemoryStore) MarkConfirmedAndReorgedTransactions(latestNonce uint64)
 	return confirmedTransactions, unconfirmedTransactionIDs, nil
 }
 
+// IncrementAttemptCount counts a rebroadcast of an existing attempt towards the max allowed attempts.
+func (m *InMemoryStore) IncrementAttemptCount(nonce uint64) error {
+	m.Lock()
+	defer m.Unlock()
+
+	tx, exists := m.UnconfirmedTransactions[nonce]
+	if !exists {
+		return fmt.Errorf("unconfirmed tx with nonce: %d was not found", nonce)
+	}
+
+	tx.AttemptCount++
+
+	return nil
+}
+
 func (m *InMemoryStore) MarkUnconfirmedTransactionPurgeable(nonce uint64) error {
 	m.Lock()
 	defer m.Unlock()
@@ -269,6 +317,19 @@ func (m *InMemoryStore) UpdateTransactionBroadcast(txID uint64, txNonce uint64,
 	return nil
 }
 
+func (m *InMemoryStore) UpdateTransactionReceipt(receipt *types.Receipt) error {
+	m.Lock()
+	defer m.Unlock()
+
+	tx, exists := m.Transactions[receipt.TxID]
+	if !exists || tx.State != txmgr.TxConfirmed {
+		return fmt.Errorf("confirmed tx was not found for txID: %v", receipt.TxID)
+	}
+	tx.Receipt = receipt.DeepCopy()
+
+	return nil
+}
+
 func (m *InMemoryStore) UpdateUnstartedTransactionWithNonce(nonce uint64) (*types.Transaction, error) {
 	m.Lock()
 	defer m.Unlock()
@@ -308,6 +369,7 @@ func (m *InMemoryStore) pruneConfirmedTransactions() []uint64 {
 	for nonce, tx := range m.ConfirmedTransactions {
 		if nonce < minNonce {
 			txIDsToPrune = append(txIDsToPrune, tx.ID)
+			m.unindexMeta(tx)
 			delete(m.Transactions, tx.ID)
 			delete(m.ConfirmedTransactions, nonce)
 		}
@@ -354,3 +416,144 @@ func (m *InMemoryStore) FindTxWithIdempotencyKey(idempotencyKey string) *types.T
 
 	return nil
 }
+
+func (m *InMemoryStore) FindTxesByMetaFieldAndStates(metaField string, metaValue string, states []txmgrtypes.TxState) []*types.Transaction {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.findTxesWithMetaField(metaField, func(tx *types.Transaction, value *string) bool {
+		return value != nil && *value == metaValue && slices.Contains(states, tx.State)
+	})
+}
+
+func (m *InMemoryStore) FindTxesWithMetaFieldByStates(metaField string, states []txmgrtypes.TxState) []*types.Transaction {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.findTxesWithMetaField(metaField, func(tx *types.Transaction, _ *string) bool {
+		return slices.Contains(states, tx.State)
+	})
+}
+
+func (m *InMemoryStore) FindTxesWithMetaFieldByReceiptBlockNum(metaField string, blockNum int64) []*types.Transaction {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.findTxesWithMetaField(metaField, func(tx *types.Transaction, _ *string) bool {
+		return tx.Receipt != nil && tx.Receipt.BlockNumber != nil && tx.Receipt.BlockNumber.Cmp(big.NewInt(blockNum)) >= 0
+	})
+}
+
+func (m *InMemoryStore) FindTxesByIDsAndStates(ids []uint64, states []txmgrtypes.TxState) []*types.Transaction {
+	m.RLock()
+	defer m.RUnlock()
+
+	var txs []*types.Transaction
+	for _, id := range ids {
+		if tx, exists := m.Transactions[id]; exists && slices.Contains(states, tx.State) {
+			txs = append(txs, tx.DeepCopy())
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].ID < txs[j].ID })
+	return txs
+}
+
+func (m *InMemoryStore) FindEarliestUnconfirmedBroadcastTime() (earliest *time.Time) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, tx := range m.UnconfirmedTransactions {
+		if tx.InitialBroadcastAt != nil && (earliest == nil || tx.InitialBroadcastAt.Before(*earliest)) {
+			t := *tx.InitialBroadcastAt
+			earliest = &t
+		}
+	}
+	return
+}
+
+func (m *InMemoryStore) FindEarliestUnconfirmedTxAttemptBlock() (earliest *int64) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, tx := range m.UnconfirmedTransactions {
+		for _, a := range tx.Attempts {
+			if a.BroadcastBeforeBlockNum != nil && (earliest == nil || *a.BroadcastBeforeBlockNum < *earliest) {
+				n := *a.BroadcastBeforeBlockNum
+				earliest = &n
+			}
+		}
+	}
+	return
+}
+
+// Shouldn't call lock because it's being called by a method that already has the lock
+func (m *InMemoryStore) findTxesWithMetaField(metaField string, match func(tx *types.Transaction, value *string) bool) []*types.Transaction {
+	var txs []*types.Transaction
+	for txID := range m.metaIndex[metaField] {
+		tx, exists := m.Transactions[txID]
+		if !exists {
+			continue
+		}
+		fields, err := metaFields(tx)
+		if err != nil {
+			m.lggr.Errorw("Failed to parse meta", "txID", tx.ID, "err", err)
+			continue
+		}
+		if match(tx, fields[metaField]) {
+			txs = append(txs, tx.DeepCopy())
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].ID < txs[j].ID })
+	return txs
+}
+
+// Shouldn't call lock because it's being called by a method that already has the lock
+func (m *InMemoryStore) indexMeta(tx *types.Transaction) {
+	fields, err := metaFields(tx)
+	if err != nil {
+		m.lggr.Errorw("Failed to index meta", "txID", tx.ID, "err", err)
+		return
+	}
+	for field := range fields {
+		if _, exists := m.metaIndex[field]; !exists {
+			m.metaIndex[field] = make(map[uint64]struct{})
+		}
+		m.metaIndex[field][tx.ID] = struct{}{}
+	}
+}
+
+// Shouldn't call lock because it's being called by a method that already has the lock
+func (m *InMemoryStore) unindexMeta(tx *types.Transaction) {
+	fields, err := metaFields(tx)
+	if err != nil {
+		return
+	}
+	for field := range fields {
+		delete(m.metaIndex[field], tx.ID)
+		if len(m.metaIndex[field]) == 0 {
+			delete(m.metaIndex, field)
+		}
+	}
+}
+
+// metaFields returns the top-level fields of the transaction's meta along with their text values, similar to
+// the ->> operator of Postgres. JSON strings are unquoted and null values are returned as nil.
+func metaFields(tx *types.Transaction) (map[string]*string, error) {
+	if tx.Meta == nil {
+		return nil, nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(*tx.Meta, &raw); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]*string, len(raw))
+	for field, rawValue := range raw {
+		var value *string
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			v := string(rawValue)
+			value = &v
+		}
+		fields[field] = value
+	}
+	return fields, nil
+}
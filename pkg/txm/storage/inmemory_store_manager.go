@@ -5,11 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
 const StoreNotFoundForAddress string = "InMemoryStore for address: %v not found"
@@ -17,65 +21,87 @@ const StoreNotFoundForAddress string = "InMemoryStore for address: %v not found"
 type InMemoryStoreManager struct {
 	lggr             logger.Logger
 	chainID          *big.Int
+	maxQueuedKey     func(common.Address) uint32
+	txIDs            atomic.Uint64 // shared by the stores, so that transaction IDs are unique across addresses
+	storeMapMu       sync.RWMutex
 	InMemoryStoreMap map[common.Address]*InMemoryStore
 }
 
-func NewInMemoryStoreManager(lggr logger.Logger, chainID *big.Int) *InMemoryStoreManager {
+// NewInMemoryStoreManager creates a new store manager. maxQueuedKey returns the capacity of the unstarted transactions queue
+// of each address. If it's nil, the default capacity is used.
+func NewInMemoryStoreManager(lggr logger.Logger, chainID *big.Int, maxQueuedKey func(common.Address) uint32) *InMemoryStoreManager {
 	inMemoryStoreMap := make(map[common.Address]*InMemoryStore)
 	return &InMemoryStoreManager{
 		lggr:             lggr,
 		chainID:          chainID,
+		maxQueuedKey:     maxQueuedKey,
 		InMemoryStoreMap: inMemoryStoreMap}
 }
 
 func (m *InMemoryStoreManager) AbandonPendingTransactions(_ context.Context, fromAddress common.Address) error {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		store.AbandonPendingTransactions()
 		return nil
 	}
 	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
-func (m *InMemoryStoreManager) Add(addresses ...common.Address) (err error) {
+// Add creates a store for each address. Addresses can be added while the Txm is running. Existing stores are kept.
+func (m *InMemoryStoreManager) Add(_ context.Context, addresses ...common.Address) (err error) {
+	m.storeMapMu.Lock()
+	defer m.storeMapMu.Unlock()
 	for _, address := range addresses {
 		if _, exists := m.InMemoryStoreMap[address]; exists {
 			err = errors.Join(err, fmt.Errorf("address %v already exists in store manager", address))
+			continue
 		}
-		m.InMemoryStoreMap[address] = NewInMemoryStore(m.lggr, address, m.chainID)
+		store := NewInMemoryStore(m.lggr, address, m.chainID)
+		store.txIDs = &m.txIDs
+		if m.maxQueuedKey != nil {
+			store.maxQueued = int(m.maxQueuedKey(address))
+		}
+		m.InMemoryStoreMap[address] = store
 	}
 	return
 }
 
 func (m *InMemoryStoreManager) AppendAttemptToTransaction(_ context.Context, txNonce uint64, fromAddress common.Address, attempt *types.Attempt) error {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.AppendAttemptToTransaction(txNonce, attempt)
 	}
 	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
 func (m *InMemoryStoreManager) CountUnstartedTransactions(fromAddress common.Address) (int, error) {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.CountUnstartedTransactions(), nil
 	}
 	return 0, fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
 func (m *InMemoryStoreManager) CreateEmptyUnconfirmedTransaction(_ context.Context, fromAddress common.Address, nonce uint64, gasLimit uint64) (*types.Transaction, error) {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.CreateEmptyUnconfirmedTransaction(nonce, gasLimit)
 	}
 	return nil, fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
 func (m *InMemoryStoreManager) CreateTransaction(_ context.Context, txRequest *types.TxRequest) (*types.Transaction, error) {
-	if store, exists := m.InMemoryStoreMap[txRequest.FromAddress]; exists {
-		return store.CreateTransaction(txRequest), nil
+	if store, exists := m.store(txRequest.FromAddress); exists {
+		return store.CreateTransaction(txRequest)
 	}
 	return nil, fmt.Errorf(StoreNotFoundForAddress, txRequest.FromAddress)
 }
 
+func (m *InMemoryStoreManager) FetchHighestUnconfirmedNonce(_ context.Context, fromAddress common.Address) (*uint64, error) {
+	if store, exists := m.store(fromAddress); exists {
+		return store.FetchHighestUnconfirmedNonce(), nil
+	}
+	return nil, fmt.Errorf(StoreNotFoundForAddress, fromAddress)
+}
+
 func (m *InMemoryStoreManager) FetchUnconfirmedTransactionAtNonceWithCount(_ context.Context, nonce uint64, fromAddress common.Address) (tx *types.Transaction, count int, err error) {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		tx, count = store.FetchUnconfirmedTransactionAtNonceWithCount(nonce)
 		return
 	}
@@ -83,50 +109,64 @@ func (m *InMemoryStoreManager) FetchUnconfirmedTransactionAtNonceWithCount(_ con
 }
 
 func (m *InMemoryStoreManager) MarkConfirmedAndReorgedTransactions(_ context.Context, nonce uint64, fromAddress common.Address) (confirmedTxs []*types.Transaction, unconfirmedTxIDs []uint64, err error) {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		confirmedTxs, unconfirmedTxIDs, err = store.MarkConfirmedAndReorgedTransactions(nonce)
 		return
 	}
 	return nil, nil, fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
+func (m *InMemoryStoreManager) IncrementAttemptCount(_ context.Context, nonce uint64, fromAddress common.Address) error {
+	if store, exists := m.store(fromAddress); exists {
+		return store.IncrementAttemptCount(nonce)
+	}
+	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
+}
+
 func (m *InMemoryStoreManager) MarkUnconfirmedTransactionPurgeable(_ context.Context, nonce uint64, fromAddress common.Address) error {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.MarkUnconfirmedTransactionPurgeable(nonce)
 	}
 	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
 func (m *InMemoryStoreManager) UpdateTransactionBroadcast(_ context.Context, txID uint64, nonce uint64, attemptHash common.Hash, fromAddress common.Address) error {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.UpdateTransactionBroadcast(txID, nonce, attemptHash)
 	}
 	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
+func (m *InMemoryStoreManager) UpdateTransactionReceipt(_ context.Context, receipt *types.Receipt, fromAddress common.Address) error {
+	if store, exists := m.store(fromAddress); exists {
+		return store.UpdateTransactionReceipt(receipt)
+	}
+	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
+}
+
 func (m *InMemoryStoreManager) UpdateUnstartedTransactionWithNonce(_ context.Context, fromAddress common.Address, nonce uint64) (*types.Transaction, error) {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.UpdateUnstartedTransactionWithNonce(nonce)
 	}
 	return nil, fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
 func (m *InMemoryStoreManager) DeleteAttemptForUnconfirmedTx(_ context.Context, nonce uint64, attempt *types.Attempt, fromAddress common.Address) error {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.DeleteAttemptForUnconfirmedTx(nonce, attempt)
 	}
 	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
 func (m *InMemoryStoreManager) MarkTxFatal(_ context.Context, tx *types.Transaction, fromAddress common.Address) error {
-	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+	if store, exists := m.store(fromAddress); exists {
 		return store.MarkTxFatal(tx)
 	}
 	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
 func (m *InMemoryStoreManager) FindTxWithIdempotencyKey(_ context.Context, idempotencyKey string) (*types.Transaction, error) {
-	for _, store := range m.InMemoryStoreMap {
+	for _, store := range m.stores() {
 		tx := store.FindTxWithIdempotencyKey(idempotencyKey)
 		if tx != nil {
 			return tx, nil
@@ -134,3 +174,66 @@ func (m *InMemoryStoreManager) FindTxWithIdempotencyKey(_ context.Context, idemp
 	}
 	return nil, nil
 }
+
+func (m *InMemoryStoreManager) FindTxesByMetaFieldAndStates(_ context.Context, metaField string, metaValue string, states []txmgrtypes.TxState) (txs []*types.Transaction, err error) {
+	for _, store := range m.stores() {
+		txs = append(txs, store.FindTxesByMetaFieldAndStates(metaField, metaValue, states)...)
+	}
+	return
+}
+
+func (m *InMemoryStoreManager) FindTxesWithMetaFieldByStates(_ context.Context, metaField string, states []txmgrtypes.TxState) (txs []*types.Transaction, err error) {
+	for _, store := range m.stores() {
+		txs = append(txs, store.FindTxesWithMetaFieldByStates(metaField, states)...)
+	}
+	return
+}
+
+func (m *InMemoryStoreManager) FindTxesWithMetaFieldByReceiptBlockNum(_ context.Context, metaField string, blockNum int64) (txs []*types.Transaction, err error) {
+	for _, store := range m.stores() {
+		txs = append(txs, store.FindTxesWithMetaFieldByReceiptBlockNum(metaField, blockNum)...)
+	}
+	return
+}
+
+func (m *InMemoryStoreManager) FindTxesByIDsAndStates(_ context.Context, ids []uint64, states []txmgrtypes.TxState) (txs []*types.Transaction, err error) {
+	for _, store := range m.stores() {
+		txs = append(txs, store.FindTxesByIDsAndStates(ids, states)...)
+	}
+	return
+}
+
+func (m *InMemoryStoreManager) FindEarliestUnconfirmedBroadcastTime(_ context.Context) (earliest *time.Time, err error) {
+	for _, store := range m.stores() {
+		if t := store.FindEarliestUnconfirmedBroadcastTime(); t != nil && (earliest == nil || t.Before(*earliest)) {
+			earliest = t
+		}
+	}
+	return
+}
+
+func (m *InMemoryStoreManager) FindEarliestUnconfirmedTxAttemptBlock(_ context.Context) (earliest *int64, err error) {
+	for _, store := range m.stores() {
+		if n := store.FindEarliestUnconfirmedTxAttemptBlock(); n != nil && (earliest == nil || *n < *earliest) {
+			earliest = n
+		}
+	}
+	return
+}
+
+func (m *InMemoryStoreManager) store(address common.Address) (*InMemoryStore, bool) {
+	m.storeMapMu.RLock()
+	defer m.storeMapMu.RUnlock()
+	store, exists := m.InMemoryStoreMap[address]
+	return store, exists
+}
+
+func (m *InMemoryStoreManager) stores() []*InMemoryStore {
+	m.storeMapMu.RLock()
+	defer m.storeMapMu.RUnlock()
+	stores := make([]*InMemoryStore, 0, len(m.InMemoryStoreMap))
+	for _, store := range m.InMemoryStoreMap {
+		stores = append(stores, store)
+	}
+	return stores
+}
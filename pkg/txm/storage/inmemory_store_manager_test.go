@@ -9,27 +9,54 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
 func TestAdd(t *testing.T) {
 	t.Parallel()
 
 	fromAddress := testutils.NewAddress()
-	m := NewInMemoryStoreManager(logger.Test(t), testutils.FixtureChainID)
+	m := NewInMemoryStoreManager(logger.Test(t), testutils.FixtureChainID, nil)
 	// Adds a new address
-	err := m.Add(fromAddress)
+	err := m.Add(t.Context(), fromAddress)
 	require.NoError(t, err)
 	assert.Len(t, m.InMemoryStoreMap, 1)
 
 	// Fails if address exists
-	err = m.Add(fromAddress)
+	err = m.Add(t.Context(), fromAddress)
 	require.Error(t, err)
 
 	// Adds multiple addresses
 	fromAddress1 := testutils.NewAddress()
 	fromAddress2 := testutils.NewAddress()
 	addresses := []common.Address{fromAddress1, fromAddress2}
-	err = m.Add(addresses...)
+	err = m.Add(t.Context(), addresses...)
 	require.NoError(t, err)
 	assert.Len(t, m.InMemoryStoreMap, 3)
 }
+
+func TestFindTxesByIDsAndStates_AcrossAddresses(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	fromAddress1 := testutils.NewAddress()
+	fromAddress2 := testutils.NewAddress()
+	m := NewInMemoryStoreManager(logger.Test(t), testutils.FixtureChainID, nil)
+	require.NoError(t, m.Add(t.Context(), fromAddress1, fromAddress2))
+
+	// The first transaction of each address would both get ID 0 if the stores counted IDs separately.
+	tx1, err := m.CreateTransaction(ctx, &types.TxRequest{FromAddress: fromAddress1})
+	require.NoError(t, err)
+	tx2, err := m.CreateTransaction(ctx, &types.TxRequest{FromAddress: fromAddress2})
+	require.NoError(t, err)
+	assert.NotEqual(t, tx1.ID, tx2.ID)
+
+	for _, tx := range []*types.Transaction{tx1, tx2} {
+		txs, err := m.FindTxesByIDsAndStates(ctx, []uint64{tx.ID}, []txmgrtypes.TxState{txmgr.TxUnstarted})
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.Equal(t, tx.FromAddress, txs[0].FromAddress)
+	}
+}
@@ -6,16 +6,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
 func TestAbandonPendingTransactions(t *testing.T) {
@@ -159,36 +162,76 @@ func TestCreateTransaction(t *testing.T) {
 		now := time.Now()
 		txR1 := &types.TxRequest{}
 		txR2 := &types.TxRequest{}
-		tx1 := m.CreateTransaction(txR1)
+		tx1, err := m.CreateTransaction(txR1)
+		require.NoError(t, err)
 		assert.Equal(t, uint64(0), tx1.ID)
 		assert.LessOrEqual(t, now, tx1.CreatedAt)
 
-		tx2 := m.CreateTransaction(txR2)
+		tx2, err := m.CreateTransaction(txR2)
+		require.NoError(t, err)
 		assert.Equal(t, uint64(1), tx2.ID)
 		assert.LessOrEqual(t, now, tx2.CreatedAt)
 
 		assert.Equal(t, 2, m.CountUnstartedTransactions())
 	})
 
-	t.Run("prunes oldest unstarted transactions if limit is reached", func(t *testing.T) {
+	t.Run("returns queue full error if limit is reached", func(t *testing.T) {
 		m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
-		overshot := 5
-		for i := 0; i < maxQueuedTransactions+overshot; i++ {
+		for i := 0; i < maxQueuedTransactions; i++ {
 			r := &types.TxRequest{}
-			tx := m.CreateTransaction(r)
+			tx, err := m.CreateTransaction(r)
+			require.NoError(t, err)
 			//nolint:gosec // this won't overflow
 			assert.Equal(t, uint64(i), tx.ID)
 		}
-		// total shouldn't exceed maxQueuedTransactions
+		_, err := m.CreateTransaction(&types.TxRequest{})
+		var queueFullErr *types.QueueFullError
+		require.ErrorAs(t, err, &queueFullErr)
+		assert.Equal(t, maxQueuedTransactions, queueFullErr.MaxQueued)
+		// existing transactions shouldn't be dropped
 		assert.Equal(t, maxQueuedTransactions, m.CountUnstartedTransactions())
-		// earliest tx ID should be the same amount of the number of transactions that we dropped
 		tx, err := m.UpdateUnstartedTransactionWithNonce(0)
 		require.NoError(t, err)
-		//nolint:gosec // this won't overflow
-		assert.Equal(t, uint64(overshot), tx.ID)
+		assert.Equal(t, uint64(0), tx.ID)
+
+		// new transactions are accepted once there is space in the queue
+		_, err = m.CreateTransaction(&types.TxRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("uses per address queue limit", func(t *testing.T) {
+		sm := NewInMemoryStoreManager(logger.Test(t), testutils.FixtureChainID, func(common.Address) uint32 { return 1 })
+		require.NoError(t, sm.Add(t.Context(), fromAddress))
+		_, err := sm.CreateTransaction(t.Context(), &types.TxRequest{FromAddress: fromAddress})
+		require.NoError(t, err)
+		_, err = sm.CreateTransaction(t.Context(), &types.TxRequest{FromAddress: fromAddress})
+		var queueFullErr *types.QueueFullError
+		require.ErrorAs(t, err, &queueFullErr)
+		assert.Equal(t, fromAddress, queueFullErr.FromAddress)
+		assert.Equal(t, 1, queueFullErr.MaxQueued)
 	})
 }
 
+func TestFetchHighestUnconfirmedNonce(t *testing.T) {
+	t.Parallel()
+
+	fromAddress := testutils.NewAddress()
+	m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+	assert.Nil(t, m.FetchHighestUnconfirmedNonce())
+
+	_, err := insertConfirmedTransaction(m, 10)
+	require.NoError(t, err)
+	assert.Nil(t, m.FetchHighestUnconfirmedNonce())
+
+	_, err = insertUnconfirmedTransaction(m, 3)
+	require.NoError(t, err)
+	_, err = insertUnconfirmedTransaction(m, 5)
+	require.NoError(t, err)
+	nonce := m.FetchHighestUnconfirmedNonce()
+	require.NotNil(t, nonce)
+	assert.Equal(t, uint64(5), *nonce)
+}
+
 func TestFetchUnconfirmedTransactionAtNonceWithCount(t *testing.T) {
 	t.Parallel()
 
@@ -352,6 +395,31 @@ func TestUpdateTransactionBroadcast(t *testing.T) {
 	})
 }
 
+func TestUpdateTransactionReceipt(t *testing.T) {
+	t.Parallel()
+
+	fromAddress := testutils.NewAddress()
+	m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+	unconfirmedTx, err := insertUnconfirmedTransaction(m, 1)
+	require.NoError(t, err)
+	confirmedTx, err := insertConfirmedTransaction(m, 0)
+	require.NoError(t, err)
+
+	t.Run("fails if transaction is not confirmed", func(t *testing.T) {
+		require.Error(t, m.UpdateTransactionReceipt(&types.Receipt{TxID: unconfirmedTx.ID, BlockNumber: big.NewInt(1)}))
+	})
+
+	t.Run("stores receipt and clears it on re-org", func(t *testing.T) {
+		receipt := &types.Receipt{TxID: confirmedTx.ID, BlockNumber: big.NewInt(1), GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)}
+		require.NoError(t, m.UpdateTransactionReceipt(receipt))
+		assert.Equal(t, receipt, confirmedTx.Receipt)
+
+		_, _, err := m.MarkConfirmedAndReorgedTransactions(0)
+		require.NoError(t, err)
+		assert.Nil(t, confirmedTx.Receipt)
+	})
+}
+
 func TestUpdateUnstartedTransactionWithNonce(t *testing.T) {
 	t.Parallel()
 
@@ -444,6 +512,70 @@ func TestFindTxWithIdempotencyKey(t *testing.T) {
 	assert.Nil(t, itx)
 }
 
+func TestFindTxesByMeta(t *testing.T) {
+	t.Parallel()
+	fromAddress := testutils.NewAddress()
+	m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+
+	meta := func(s string) *sqlutil.JSON {
+		j := sqlutil.JSON(s)
+		return &j
+	}
+	tx1, err := m.CreateTransaction(&types.TxRequest{Meta: meta(`{"JobID": 1, "RequestID": "0xabc"}`)})
+	require.NoError(t, err)
+	tx2, err := m.CreateTransaction(&types.TxRequest{Meta: meta(`{"JobID": 2}`)})
+	require.NoError(t, err)
+	_, err = m.CreateTransaction(&types.TxRequest{})
+	require.NoError(t, err)
+	_, err = m.UpdateUnstartedTransactionWithNonce(0)
+	require.NoError(t, err)
+
+	txs := m.FindTxesByMetaFieldAndStates("JobID", "1", []txmgrtypes.TxState{txmgr.TxUnconfirmed})
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx1.ID, txs[0].ID)
+	assert.Empty(t, m.FindTxesByMetaFieldAndStates("JobID", "1", []txmgrtypes.TxState{txmgr.TxUnstarted}))
+	assert.Empty(t, m.FindTxesByMetaFieldAndStates("RequestID", "0xdef", []txmgrtypes.TxState{txmgr.TxUnconfirmed}))
+
+	txs = m.FindTxesWithMetaFieldByStates("JobID", []txmgrtypes.TxState{txmgr.TxUnstarted, txmgr.TxUnconfirmed})
+	require.Len(t, txs, 2)
+	assert.Equal(t, tx1.ID, txs[0].ID)
+	assert.Equal(t, tx2.ID, txs[1].ID)
+
+	txs = m.FindTxesByIDsAndStates([]uint64{tx1.ID, tx2.ID, 100}, []txmgrtypes.TxState{txmgr.TxUnstarted})
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx2.ID, txs[0].ID)
+
+	_, _, err = m.MarkConfirmedAndReorgedTransactions(1)
+	require.NoError(t, err)
+	require.NoError(t, m.UpdateTransactionReceipt(&types.Receipt{TxID: tx1.ID, BlockNumber: big.NewInt(10)}))
+	assert.Len(t, m.FindTxesWithMetaFieldByReceiptBlockNum("JobID", 10), 1)
+	assert.Empty(t, m.FindTxesWithMetaFieldByReceiptBlockNum("JobID", 11))
+	assert.Empty(t, m.FindTxesWithMetaFieldByReceiptBlockNum("Unknown", 10))
+}
+
+func TestFindEarliestUnconfirmed(t *testing.T) {
+	t.Parallel()
+	fromAddress := testutils.NewAddress()
+	m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+	assert.Nil(t, m.FindEarliestUnconfirmedBroadcastTime())
+	assert.Nil(t, m.FindEarliestUnconfirmedTxAttemptBlock())
+
+	tx1, err := insertUnconfirmedTransaction(m, 0)
+	require.NoError(t, err)
+	tx2, err := insertUnconfirmedTransaction(m, 1)
+	require.NoError(t, err)
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+	tx1.InitialBroadcastAt = &now
+	tx2.InitialBroadcastAt = &earlier
+	blockNum1, blockNum2 := int64(5), int64(3)
+	tx1.Attempts = []*types.Attempt{{BroadcastBeforeBlockNum: &blockNum1}}
+	tx2.Attempts = []*types.Attempt{{}, {BroadcastBeforeBlockNum: &blockNum2}}
+
+	assert.Equal(t, earlier, *m.FindEarliestUnconfirmedBroadcastTime())
+	assert.Equal(t, blockNum2, *m.FindEarliestUnconfirmedTxAttemptBlock())
+}
+
 func TestPruneConfirmedTransactions(t *testing.T) {
 	t.Parallel()
 	fromAddress := testutils.NewAddress()
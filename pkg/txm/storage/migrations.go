@@ -0,0 +1,8 @@
+package storage
+
+import "embed"
+
+// Migrations are the goose migrations of the DBStore schema. They are run by the node along with its own migrations.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
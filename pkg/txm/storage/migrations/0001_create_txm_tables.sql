@@ -0,0 +1,65 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evm.txm_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    idempotency_key TEXT,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    nonce BIGINT,
+    from_address BYTEA NOT NULL,
+    to_address BYTEA NOT NULL,
+    value NUMERIC(78,0) NOT NULL,
+    data BYTEA NOT NULL,
+    specified_gas_limit BIGINT NOT NULL,
+    blob_sidecar BYTEA,
+    authorization_list JSONB,
+    created_at TIMESTAMPTZ NOT NULL,
+    initial_broadcast_at TIMESTAMPTZ,
+    last_broadcast_at TIMESTAMPTZ,
+    state TEXT NOT NULL,
+    is_purgeable BOOLEAN NOT NULL DEFAULT FALSE,
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    meta JSONB,
+    subject UUID,
+    pipeline_task_run_id UUID,
+    min_confirmations INTEGER,
+    signal_callback BOOLEAN NOT NULL DEFAULT FALSE,
+    callback_completed BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_txm_transactions_idempotency_key ON evm.txm_transactions (evm_chain_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_txm_transactions_state_nonce ON evm.txm_transactions (evm_chain_id, from_address, state, nonce);
+CREATE INDEX IF NOT EXISTS idx_txm_transactions_meta ON evm.txm_transactions USING GIN (meta);
+
+CREATE TABLE IF NOT EXISTS evm.txm_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    tx_id BIGINT NOT NULL REFERENCES evm.txm_transactions (id) ON DELETE CASCADE,
+    hash BYTEA NOT NULL,
+    gas_price NUMERIC(78,0),
+    gas_fee_cap NUMERIC(78,0),
+    gas_tip_cap NUMERIC(78,0),
+    blob_fee_cap NUMERIC(78,0),
+    gas_limit BIGINT NOT NULL,
+    type SMALLINT NOT NULL,
+    signed_transaction BYTEA,
+    created_at TIMESTAMPTZ NOT NULL,
+    broadcast_at TIMESTAMPTZ,
+    broadcast_before_block_num BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_txm_attempts_tx_id ON evm.txm_attempts (tx_id);
+
+CREATE TABLE IF NOT EXISTS evm.txm_receipts (
+    tx_id BIGINT PRIMARY KEY REFERENCES evm.txm_transactions (id) ON DELETE CASCADE,
+    tx_hash BYTEA NOT NULL,
+    block_hash BYTEA NOT NULL,
+    block_number BIGINT NOT NULL,
+    transaction_index BIGINT NOT NULL,
+    status BIGINT NOT NULL,
+    gas_used BIGINT NOT NULL,
+    effective_gas_price NUMERIC(78,0),
+    l1_fee NUMERIC(78,0),
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_txm_receipts_block_number ON evm.txm_receipts (block_number);
+
+-- +goose Down
+DROP TABLE IF EXISTS evm.txm_receipts;
+DROP TABLE IF EXISTS evm.txm_attempts;
+DROP TABLE IF EXISTS evm.txm_transactions;
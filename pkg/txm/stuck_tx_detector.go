@@ -1,6 +1,7 @@
 package txm
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -16,35 +17,66 @@ import (
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 )
 
+// detectionAPITimeout is the timeout of the requests to the detection API.
+const detectionAPITimeout = 10 * time.Second
+
 type StuckTxDetectorConfig struct {
 	BlockTime             time.Duration
 	StuckTxBlockThreshold uint32
 	DetectionURL          string
 	DualBroadcast         bool
+	// MempoolDetection enables detection through the mempool of the RPC for chains with a public mempool. It's ignored
+	// if DualBroadcast is set.
+	MempoolDetection bool
+}
+
+type StuckTxDetectorClient interface {
+	CallContext(ctx context.Context, result any, method string, args ...any) error
 }
 
 type stuckTxDetector struct {
 	lggr         logger.Logger
 	chainType    chaintype.ChainType
 	config       StuckTxDetectorConfig
+	client       StuckTxDetectorClient
+	httpClient   *http.Client
 	lastPurgeMap map[common.Address]time.Time
 }
 
-func NewStuckTxDetector(lggr logger.Logger, chaintype chaintype.ChainType, config StuckTxDetectorConfig) *stuckTxDetector {
+func NewStuckTxDetector(lggr logger.Logger, chaintype chaintype.ChainType, config StuckTxDetectorConfig, client StuckTxDetectorClient) *stuckTxDetector {
 	return &stuckTxDetector{
 		lggr:         lggr,
 		chainType:    chaintype,
 		config:       config,
+		client:       client,
+		httpClient:   &http.Client{Timeout: detectionAPITimeout},
 		lastPurgeMap: make(map[common.Address]time.Time),
 	}
 }
 
+// DetectStuckTransaction uses chain specific detection to purge transactions as soon as they are known to be unincludable.
+// Time based detection is used as a fallback for all chains.
 func (s *stuckTxDetector) DetectStuckTransaction(ctx context.Context, tx *types.Transaction) (bool, error) {
-	//nolint:gocritic //placeholder for upcoming chaintypes
-	switch s.chainType {
-	default:
-		return s.timeBasedDetection(tx), nil
+	var isStuck bool
+	var err error
+	switch {
+	case s.chainType == chaintype.ChainScroll:
+		isStuck, err = s.scrollDetection(ctx, tx)
+	case s.config.DualBroadcast:
+		// Attempts are sent to a private mempool, so the RPC doesn't know about them until they're included.
+	case s.chainType == chaintype.ChainZkEvm, s.chainType == chaintype.ChainXLayer:
+		isStuck, err = s.unknownTxDetection(ctx, tx)
+	case s.config.MempoolDetection:
+		isStuck, err = s.mempoolDetection(ctx, tx)
+	}
+	if err != nil {
+		return false, err
 	}
+	if isStuck {
+		s.lastPurgeMap[tx.FromAddress] = time.Now()
+		return true, nil
+	}
+	return s.timeBasedDetection(tx), nil
 }
 
 // timeBasedDetection marks a transaction if all the following conditions are met:
@@ -66,6 +98,143 @@ func (s *stuckTxDetector) timeBasedDetection(tx *types.Transaction) bool {
 	return false
 }
 
+type scrollRequest struct {
+	Txs []string `json:"txs"`
+}
+
+type scrollResponse struct {
+	Errcode int            `json:"errcode"`
+	Errmsg  string         `json:"errmsg"`
+	Data    map[string]int `json:"data"`
+}
+
+// scrollStatusSkipped is returned by the Scroll detection API for transactions that were skipped by the sequencer
+// i.e. due to proof overflow, and will never be included.
+const scrollStatusSkipped = 1
+
+// scrollDetection uses the detection API of Scroll to find out if any of the attempts has been skipped by the sequencer.
+func (s *stuckTxDetector) scrollDetection(ctx context.Context, tx *types.Transaction) (bool, error) {
+	if len(tx.Attempts) == 0 {
+		return false, nil
+	}
+	if s.config.DetectionURL == "" {
+		return false, fmt.Errorf("detection URL is required for chain type: %s", s.chainType)
+	}
+	request := scrollRequest{Txs: make([]string, 0, len(tx.Attempts))}
+	for _, attempt := range tx.Attempts {
+		request.Txs = append(request.Txs, attempt.Hash.String())
+	}
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal request for txID: %v - %w", tx.ID, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.DetectionURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return false, fmt.Errorf("failed to make request for txID: %v - %w", tx.ID, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transaction status for txID: %v - %w", tx.ID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("request for txID: %v failed with status: %d", tx.ID, resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	var response scrollResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		return false, fmt.Errorf("failed to unmarshal response for txID: %v - %w: %s", tx.ID, err, string(body))
+	}
+	if response.Errcode != 0 || response.Errmsg != "" {
+		return false, fmt.Errorf("detection API returned error for txID: %v - errcode: %d, errmsg: %s", tx.ID, response.Errcode, response.Errmsg)
+	}
+	for _, attempt := range tx.Attempts {
+		if status, exists := response.Data[attempt.Hash.String()]; exists && status == scrollStatusSkipped {
+			s.lggr.Debugf("TxID: %v with attemptHash: %v was skipped by the sequencer. Transaction is now considered stuck and will be purged.",
+				tx.ID, attempt.Hash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// unknownTxDetection marks a transaction as stuck if the RPC doesn't know about any of its attempts. zkEVM sequencers discard
+// transactions that would overflow the prover's counters so they don't remain in the pool.
+func (s *stuckTxDetector) unknownTxDetection(ctx context.Context, tx *types.Transaction) (bool, error) {
+	if !s.broadcastedBeforeLastBlock(tx) {
+		return false, nil
+	}
+	known, err := s.anyAttemptKnown(ctx, tx)
+	if err != nil || known {
+		return false, err
+	}
+	s.lggr.Debugf("None of the attempts of txID: %v were found by the RPC. Transaction is now considered stuck and will be purged.", tx.ID)
+	return true, nil
+}
+
+type txPoolTx struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// txPoolContent is the response of txpool_contentFrom. Transactions are keyed by nonce.
+type txPoolContent struct {
+	Pending map[string]*txPoolTx `json:"pending"`
+	Queued  map[string]*txPoolTx `json:"queued"`
+}
+
+// mempoolDetection marks a transaction as stuck if none of its attempts are in the mempool of the RPC. The content of the mempool
+// for the address is fetched with txpool_contentFrom. If the RPC doesn't support the txpool namespace, the attempts are
+// looked up with eth_getTransactionByHash instead.
+func (s *stuckTxDetector) mempoolDetection(ctx context.Context, tx *types.Transaction) (bool, error) {
+	if !s.broadcastedBeforeLastBlock(tx) {
+		return false, nil
+	}
+	var content txPoolContent
+	if err := s.client.CallContext(ctx, &content, "txpool_contentFrom", tx.FromAddress); err != nil {
+		s.lggr.Debugw("Failed to fetch mempool content. Falling back to eth_getTransactionByHash", "txID", tx.ID, "err", err)
+		return s.unknownTxDetection(ctx, tx)
+	}
+	for _, attempt := range tx.Attempts {
+		for _, pooledTxs := range []map[string]*txPoolTx{content.Pending, content.Queued} {
+			for _, pooledTx := range pooledTxs {
+				if pooledTx != nil && pooledTx.Hash == attempt.Hash {
+					return false, nil
+				}
+			}
+		}
+	}
+	// The attempt might have been included in the meantime so make sure the RPC is not aware of it.
+	known, err := s.anyAttemptKnown(ctx, tx)
+	if err != nil || known {
+		return false, err
+	}
+	s.lggr.Debugf("None of the attempts of txID: %v were found in the mempool. Transaction is now considered stuck and will be purged.", tx.ID)
+	return true, nil
+}
+
+// broadcastedBeforeLastBlock gives the attempts of the transaction at least a block to propagate before checking the RPC.
+func (s *stuckTxDetector) broadcastedBeforeLastBlock(tx *types.Transaction) bool {
+	return len(tx.Attempts) > 0 && tx.LastBroadcastAt != nil && time.Since(*tx.LastBroadcastAt) > s.config.BlockTime
+}
+
+func (s *stuckTxDetector) anyAttemptKnown(ctx context.Context, tx *types.Transaction) (bool, error) {
+	for _, attempt := range tx.Attempts {
+		var result map[string]any
+		if err := s.client.CallContext(ctx, &result, "eth_getTransactionByHash", attempt.Hash); err != nil {
+			return false, fmt.Errorf("failed to fetch transaction for txID: %v, attemptHash: %v - %w", tx.ID, attempt.Hash, err)
+		}
+		if result != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 type APIResponse struct {
 	Status string      `json:"status,omitempty"`
 	Hash   common.Hash `json:"hash,omitempty"`
@@ -87,7 +256,7 @@ func (s *stuckTxDetector) DualBroadcastDetection(ctx context.Context, tx *types.
 		if err != nil {
 			return false, fmt.Errorf("failed to make request for txID: %v, attemptHash: %v - %w", tx.ID, attempt.Hash, err)
 		}
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := s.httpClient.Do(req)
 		if err != nil {
 			return false, fmt.Errorf("failed to get transaction status for txID: %v, attemptHash: %v - %w", tx.ID, attempt.Hash, err)
 		}
@@ -1,13 +1,22 @@
 package txm
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/chaintype"
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 )
@@ -21,7 +30,7 @@ func TestTimeBasedDetection(t *testing.T) {
 			StuckTxBlockThreshold: 5,
 		}
 		fromAddress := testutils.NewAddress()
-		s := NewStuckTxDetector(logger.Test(t), "", config)
+		s := NewStuckTxDetector(logger.Test(t), "", config, nil)
 
 		// No previous broadcast
 		tx := &types.Transaction{
@@ -46,7 +55,7 @@ func TestTimeBasedDetection(t *testing.T) {
 			StuckTxBlockThreshold: 5,
 		}
 		fromAddress := testutils.NewAddress()
-		s := NewStuckTxDetector(logger.Test(t), "", config)
+		s := NewStuckTxDetector(logger.Test(t), "", config, nil)
 
 		tx := &types.Transaction{
 			ID:              1,
@@ -62,7 +71,7 @@ func TestTimeBasedDetection(t *testing.T) {
 			StuckTxBlockThreshold: 10,
 		}
 		fromAddress := testutils.NewAddress()
-		s := NewStuckTxDetector(logger.Test(t), "", config)
+		s := NewStuckTxDetector(logger.Test(t), "", config, nil)
 
 		tx1 := &types.Transaction{
 			ID:              1,
@@ -78,3 +87,172 @@ func TestTimeBasedDetection(t *testing.T) {
 		assert.False(t, s.timeBasedDetection(tx2))
 	})
 }
+
+type fakeStuckTxDetectorClient struct {
+	txPoolErr  error
+	txPool     txPoolContent
+	knownTxs   map[common.Hash]bool
+	txPoolCall int
+}
+
+func (f *fakeStuckTxDetectorClient) CallContext(_ context.Context, result any, method string, args ...any) error {
+	switch method {
+	case "txpool_contentFrom":
+		f.txPoolCall++
+		if f.txPoolErr != nil {
+			return f.txPoolErr
+		}
+		*result.(*txPoolContent) = f.txPool
+	case "eth_getTransactionByHash":
+		if f.knownTxs[args[0].(common.Hash)] {
+			*result.(*map[string]any) = map[string]any{"hash": args[0]}
+		}
+	}
+	return nil
+}
+
+func TestScrollDetection(t *testing.T) {
+	t.Parallel()
+
+	attemptHash := testutils.NewHash()
+	lastBroadcastAt := time.Now()
+	tx := &types.Transaction{ID: 1, FromAddress: testutils.NewAddress(), LastBroadcastAt: &lastBroadcastAt, Attempts: []*types.Attempt{{Hash: attemptHash}}}
+
+	newServer := func(t *testing.T, response string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req scrollRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, []string{attemptHash.String()}, req.Txs)
+			_, err := w.Write([]byte(response))
+			assert.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("returns true if attempt was skipped", func(t *testing.T) {
+		server := newServer(t, fmt.Sprintf(`{"errcode":0,"errmsg":"","data":{"%s":1}}`, attemptHash))
+		s := NewStuckTxDetector(logger.Test(t), chaintype.ChainScroll, StuckTxDetectorConfig{BlockTime: time.Second, StuckTxBlockThreshold: 10, DetectionURL: server.URL}, nil)
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.True(t, isStuck)
+	})
+
+	t.Run("returns false if attempt was not skipped", func(t *testing.T) {
+		server := newServer(t, fmt.Sprintf(`{"errcode":0,"errmsg":"","data":{"%s":0}}`, attemptHash))
+		s := NewStuckTxDetector(logger.Test(t), chaintype.ChainScroll, StuckTxDetectorConfig{BlockTime: time.Second, StuckTxBlockThreshold: 10, DetectionURL: server.URL}, nil)
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.False(t, isStuck)
+	})
+
+	t.Run("fails if API returns an error", func(t *testing.T) {
+		server := newServer(t, `{"errcode":1,"errmsg":"invalid request","data":{}}`)
+		s := NewStuckTxDetector(logger.Test(t), chaintype.ChainScroll, StuckTxDetectorConfig{BlockTime: time.Second, StuckTxBlockThreshold: 10, DetectionURL: server.URL}, nil)
+		_, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.ErrorContains(t, err, "invalid request")
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		unblock := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-unblock
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(unblock) })
+		s := NewStuckTxDetector(logger.Test(t), chaintype.ChainScroll, StuckTxDetectorConfig{BlockTime: time.Second, StuckTxBlockThreshold: 10, DetectionURL: server.URL}, nil)
+		s.httpClient.Timeout = 10 * time.Millisecond
+		_, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.ErrorContains(t, err, "Client.Timeout exceeded")
+	})
+}
+
+func TestUnknownTxDetection(t *testing.T) {
+	t.Parallel()
+
+	attemptHash := testutils.NewHash()
+	config := StuckTxDetectorConfig{BlockTime: 10 * time.Millisecond, StuckTxBlockThreshold: 1000}
+
+	t.Run("returns false if attempt was broadcasted during the last block", func(t *testing.T) {
+		s := NewStuckTxDetector(logger.Test(t), chaintype.ChainZkEvm, StuckTxDetectorConfig{BlockTime: time.Hour, StuckTxBlockThreshold: 10}, &fakeStuckTxDetectorClient{})
+		now := time.Now()
+		tx := &types.Transaction{ID: 1, LastBroadcastAt: &now, Attempts: []*types.Attempt{{Hash: attemptHash}}}
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.False(t, isStuck)
+	})
+
+	t.Run("returns true if attempts are unknown", func(t *testing.T) {
+		s := NewStuckTxDetector(logger.Test(t), chaintype.ChainZkEvm, config, &fakeStuckTxDetectorClient{})
+		lastBroadcastAt := time.Now().Add(-time.Second)
+		tx := &types.Transaction{ID: 1, LastBroadcastAt: &lastBroadcastAt, Attempts: []*types.Attempt{{Hash: attemptHash}}}
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.True(t, isStuck)
+	})
+
+	t.Run("returns false if any attempt is known", func(t *testing.T) {
+		s := NewStuckTxDetector(logger.Test(t), chaintype.ChainXLayer, config, &fakeStuckTxDetectorClient{knownTxs: map[common.Hash]bool{attemptHash: true}})
+		lastBroadcastAt := time.Now().Add(-time.Second)
+		tx := &types.Transaction{ID: 1, LastBroadcastAt: &lastBroadcastAt, Attempts: []*types.Attempt{{Hash: testutils.NewHash()}, {Hash: attemptHash}}}
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.False(t, isStuck)
+	})
+}
+
+func TestMempoolDetection(t *testing.T) {
+	t.Parallel()
+
+	attemptHash := testutils.NewHash()
+	lastBroadcastAt := time.Now().Add(-time.Second)
+	tx := &types.Transaction{ID: 1, LastBroadcastAt: &lastBroadcastAt, Attempts: []*types.Attempt{{Hash: attemptHash}}}
+	config := StuckTxDetectorConfig{BlockTime: 10 * time.Millisecond, StuckTxBlockThreshold: 1000, MempoolDetection: true}
+
+	t.Run("does nothing if mempool detection is disabled", func(t *testing.T) {
+		client := &fakeStuckTxDetectorClient{}
+		s := NewStuckTxDetector(logger.Test(t), "", StuckTxDetectorConfig{BlockTime: 10 * time.Millisecond, StuckTxBlockThreshold: 1000}, client)
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.False(t, isStuck)
+		assert.Equal(t, 0, client.txPoolCall)
+	})
+
+	t.Run("returns false if attempt is in the mempool", func(t *testing.T) {
+		client := &fakeStuckTxDetectorClient{txPool: txPoolContent{Queued: map[string]*txPoolTx{"0": {Hash: attemptHash}}}}
+		s := NewStuckTxDetector(logger.Test(t), "", config, client)
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.False(t, isStuck)
+	})
+
+	t.Run("returns true if attempt is not in the mempool", func(t *testing.T) {
+		client := &fakeStuckTxDetectorClient{txPool: txPoolContent{Pending: map[string]*txPoolTx{"0": {Hash: testutils.NewHash()}}}}
+		s := NewStuckTxDetector(logger.Test(t), "", config, client)
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.True(t, isStuck)
+	})
+
+	t.Run("falls back to eth_getTransactionByHash if txpool is not supported", func(t *testing.T) {
+		client := &fakeStuckTxDetectorClient{txPoolErr: errors.New("method not found"), knownTxs: map[common.Hash]bool{attemptHash: true}}
+		s := NewStuckTxDetector(logger.Test(t), "", config, client)
+		isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+		require.NoError(t, err)
+		assert.False(t, isStuck)
+		assert.Equal(t, 1, client.txPoolCall)
+	})
+
+	t.Run("does nothing with dual broadcast", func(t *testing.T) {
+		for _, chainType := range []chaintype.ChainType{"", chaintype.ChainZkEvm} {
+			client := &fakeStuckTxDetectorClient{}
+			dualBroadcastConfig := config
+			dualBroadcastConfig.DualBroadcast = true
+			s := NewStuckTxDetector(logger.Test(t), chainType, dualBroadcastConfig, client)
+			isStuck, err := s.DetectStuckTransaction(t.Context(), tx)
+			require.NoError(t, err)
+			assert.False(t, isStuck, chainType)
+			assert.Equal(t, 0, client.txPoolCall, chainType)
+		}
+	})
+}
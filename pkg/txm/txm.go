@@ -2,9 +2,11 @@ package txm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -15,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/utils"
 	"github.com/smartcontractkit/chainlink-evm/pkg/keys"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
 )
 
 const (
@@ -24,23 +27,29 @@ const (
 	maxAllowedAttempts          uint16        = 10
 	pendingNonceDefaultTimeout  time.Duration = 30 * time.Second
 	pendingNonceRecheckInterval time.Duration = 1 * time.Second
+	keyReconcileInterval        time.Duration = 1 * time.Minute
 )
 
 type Client interface {
 	PendingNonceAt(context.Context, common.Address) (uint64, error)
 	NonceAt(context.Context, common.Address, *big.Int) (uint64, error)
 	SendTransaction(ctx context.Context, tx *types.Transaction, attempt *types.Attempt) error
+	TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error)
 }
 
 type TxStore interface {
+	Add(context.Context, ...common.Address) error
 	AbandonPendingTransactions(context.Context, common.Address) error
 	AppendAttemptToTransaction(context.Context, uint64, common.Address, *types.Attempt) error
 	CreateEmptyUnconfirmedTransaction(context.Context, common.Address, uint64, uint64) (*types.Transaction, error)
 	CreateTransaction(context.Context, *types.TxRequest) (*types.Transaction, error)
+	FetchHighestUnconfirmedNonce(context.Context, common.Address) (*uint64, error)
 	FetchUnconfirmedTransactionAtNonceWithCount(context.Context, uint64, common.Address) (*types.Transaction, int, error)
+	IncrementAttemptCount(context.Context, uint64, common.Address) error
 	MarkConfirmedAndReorgedTransactions(context.Context, uint64, common.Address) ([]*types.Transaction, []uint64, error)
 	MarkUnconfirmedTransactionPurgeable(context.Context, uint64, common.Address) error
 	UpdateTransactionBroadcast(context.Context, uint64, uint64, common.Hash, common.Address) error
+	UpdateTransactionReceipt(context.Context, *types.Receipt, common.Address) error
 	UpdateUnstartedTransactionWithNonce(context.Context, common.Address, uint64) (*types.Transaction, error)
 
 	// ErrorHandler
@@ -50,7 +59,11 @@ type TxStore interface {
 
 type AttemptBuilder interface {
 	NewAttempt(context.Context, logger.Logger, *types.Transaction, bool) (*types.Attempt, error)
-	NewBumpAttempt(context.Context, logger.Logger, *types.Transaction, types.Attempt) (*types.Attempt, error)
+	NewBumpAttempt(context.Context, logger.Logger, *types.Transaction, types.Attempt, uint32) (*types.Attempt, error)
+}
+
+type BumpPolicy interface {
+	Bumps(tx *types.Transaction, latestBlockNumber int64) uint32
 }
 
 type ErrorHandler interface {
@@ -70,6 +83,56 @@ type Config struct {
 	BlockTime           time.Duration
 	RetryBlockThreshold uint16
 	EmptyTxLimitDefault uint64
+	// MaxInFlightKey and MaxInFlightSubsetKey return the in-flight limits of each address. Defaults are used if they are nil.
+	MaxInFlightKey       func(common.Address) uint32
+	MaxInFlightSubsetKey func(common.Address) uint32
+	// MaxAttempts is the max number of attempts broadcasted for a transaction. The default is used if it's zero.
+	MaxAttempts uint16
+	// KeyReconcileInterval is how often the enabled addresses of the keystore are reconciled with the running loops.
+	// The default is used if it's zero.
+	KeyReconcileInterval time.Duration
+}
+
+func (c *Config) maxInFlight(address common.Address) int {
+	if c.MaxInFlightKey != nil {
+		return int(c.MaxInFlightKey(address))
+	}
+	return maxInFlightTransactions
+}
+
+// maxInFlightSubset can't be higher than maxInFlight.
+func (c *Config) maxInFlightSubset(address common.Address) int {
+	subset := maxInFlightSubset
+	if c.MaxInFlightSubsetKey != nil {
+		subset = int(c.MaxInFlightSubsetKey(address))
+	}
+	return min(subset, c.maxInFlight(address))
+}
+
+func (c *Config) maxAttempts() uint16 {
+	if c.MaxAttempts > 0 {
+		return c.MaxAttempts
+	}
+	return maxAllowedAttempts
+}
+
+func (c *Config) keyReconcileInterval() time.Duration {
+	if c.KeyReconcileInterval > 0 {
+		return c.KeyReconcileInterval
+	}
+	return keyReconcileInterval
+}
+
+// addressLoops holds the broadcast and backfill loops of an address, so they can be stopped without affecting other addresses.
+type addressLoops struct {
+	triggerCh chan struct{}
+	stopCh    services.StopChan
+	wg        sync.WaitGroup
+}
+
+func (l *addressLoops) stop() {
+	close(l.stopCh)
+	l.wg.Wait()
 }
 
 type Txm struct {
@@ -80,6 +143,7 @@ type Txm struct {
 	attemptBuilder  AttemptBuilder
 	errorHandler    ErrorHandler
 	stuckTxDetector StuckTxDetector
+	bumpPolicy      BumpPolicy
 	txStore         TxStore
 	keystore        keys.AddressLister
 	config          Config
@@ -88,12 +152,18 @@ type Txm struct {
 	nonceMapMu sync.RWMutex
 	nonceMap   map[common.Address]uint64
 
-	triggerCh map[common.Address]chan struct{}
-	stopCh    services.StopChan
-	wg        sync.WaitGroup
+	latestBlockNumber atomic.Int64
+
+	addressesMu sync.Mutex
+	addresses   map[common.Address]*addressLoops
+	// knownAddresses are the addresses that have been added to the TxStore.
+	knownAddresses map[common.Address]struct{}
+
+	stopCh services.StopChan
+	wg     sync.WaitGroup
 }
 
-func NewTxm(lggr logger.Logger, chainID *big.Int, client Client, attemptBuilder AttemptBuilder, txStore TxStore, stuckTxDetector StuckTxDetector, config Config, keystore keys.AddressLister) *Txm {
+func NewTxm(lggr logger.Logger, chainID *big.Int, client Client, attemptBuilder AttemptBuilder, txStore TxStore, stuckTxDetector StuckTxDetector, bumpPolicy BumpPolicy, config Config, keystore keys.AddressLister) *Txm {
 	return &Txm{
 		lggr:            logger.Sugared(logger.Named(lggr, "Txm")),
 		keystore:        keystore,
@@ -102,9 +172,11 @@ func NewTxm(lggr logger.Logger, chainID *big.Int, client Client, attemptBuilder
 		attemptBuilder:  attemptBuilder,
 		txStore:         txStore,
 		stuckTxDetector: stuckTxDetector,
+		bumpPolicy:      bumpPolicy,
 		config:          config,
 		nonceMap:        make(map[common.Address]uint64),
-		triggerCh:       make(map[common.Address]chan struct{}),
+		addresses:       make(map[common.Address]*addressLoops),
+		knownAddresses:  make(map[common.Address]struct{}),
 	}
 }
 
@@ -117,24 +189,47 @@ func (t *Txm) Start(ctx context.Context) error {
 		t.metrics = tm
 		t.stopCh = make(chan struct{})
 
+		// Enabled addresses are expected to be added to the TxStore before the Txm starts.
 		addresses, err := t.keystore.EnabledAddresses(ctx)
 		if err != nil {
 			return err
 		}
+		t.addressesMu.Lock()
 		for _, address := range addresses {
+			t.knownAddresses[address] = struct{}{}
 			t.startAddress(address)
 		}
+		t.addressesMu.Unlock()
+
+		t.wg.Add(1)
+		go t.reconcileLoop()
 		return nil
 	})
 }
 
+// startAddress must be called while holding addressesMu.
 func (t *Txm) startAddress(address common.Address) {
-	triggerCh := make(chan struct{}, 1)
-	t.triggerCh[address] = triggerCh
+	loops := &addressLoops{
+		triggerCh: make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+	}
+	t.addresses[address] = loops
+
+	loops.wg.Add(2)
+	go t.broadcastLoop(address, loops)
+	go t.backfillLoop(address, loops)
+}
 
-	t.wg.Add(2)
-	go t.broadcastLoop(address, triggerCh)
-	go t.backfillLoop(address)
+// stopAddress must be called while holding addressesMu. It returns false if the address wasn't running.
+func (t *Txm) stopAddress(address common.Address) bool {
+	loops, exists := t.addresses[address]
+	if !exists {
+		return false
+	}
+	loops.stop()
+	delete(t.addresses, address)
+	t.deleteNonce(address)
+	return true
 }
 
 func (t *Txm) initializeNonce(ctx context.Context, address common.Address) {
@@ -152,6 +247,22 @@ func (t *Txm) initializeNonce(ctx context.Context, address common.Address) {
 			}
 			continue
 		}
+		// Persistent stores may hold unconfirmed transactions that were dropped from the mempool while the node was down.
+		// Start after the highest stored nonce so new transactions don't collide with them.
+		highestNonce, err := t.txStore.FetchHighestUnconfirmedNonce(ctxWithTimeout, address)
+		if err != nil {
+			t.lggr.Errorw("Error when fetching highest unconfirmed nonce", "address", address, "err", err)
+			select {
+			case <-time.After(pendingNonceRecheckInterval):
+			case <-ctx.Done():
+				t.lggr.Errorw("context error", "err", context.Cause(ctx))
+				return
+			}
+			continue
+		}
+		if highestNonce != nil && *highestNonce >= pendingNonce {
+			pendingNonce = *highestNonce + 1
+		}
 		t.setNonce(address, pendingNonce)
 		t.lggr.Debugf("Set initial nonce for address: %v to %d", address, pendingNonce)
 		return
@@ -162,6 +273,11 @@ func (t *Txm) Close() error {
 	return t.StopOnce("Txm", func() error {
 		close(t.stopCh)
 		t.wg.Wait()
+		t.addressesMu.Lock()
+		defer t.addressesMu.Unlock()
+		for address := range t.addresses {
+			t.stopAddress(address)
+		}
 		return nil
 	})
 }
@@ -170,7 +286,15 @@ func (t *Txm) HealthReport() map[string]error {
 	return map[string]error{t.lggr.Name(): t.Healthy()}
 }
 
+// CreateTransaction adds a new transaction to the queue of the address. If the queue is full, a *types.QueueFullError is returned
+// and the caller is expected to retry later.
 func (t *Txm) CreateTransaction(ctx context.Context, txRequest *types.TxRequest) (tx *types.Transaction, err error) {
+	if err = txRequest.ValidateAuthorizationList(); err != nil {
+		return nil, err
+	}
+	if err = types.ValidateValue(txRequest.Value); err != nil {
+		return nil, err
+	}
 	tx, err = t.txStore.CreateTransaction(ctx, txRequest)
 	if err == nil {
 		t.lggr.Infow("Created transaction", "tx", tx)
@@ -180,20 +304,122 @@ func (t *Txm) CreateTransaction(ctx context.Context, txRequest *types.TxRequest)
 
 func (t *Txm) Trigger(address common.Address) {
 	if !t.IfStarted(func() {
-		triggerCh, exists := t.triggerCh[address]
+		t.addressesMu.Lock()
+		defer t.addressesMu.Unlock()
+		loops, exists := t.addresses[address]
 		if !exists {
 			return
 		}
-		triggerCh <- struct{}{}
+		// A pending trigger is enough to wake up the broadcast loop.
+		select {
+		case loops.triggerCh <- struct{}{}:
+		default:
+		}
 	}) {
 		t.lggr.Error("Txm unstarted")
 	}
 }
 
 func (t *Txm) Abandon(address common.Address) error {
-	// TODO: restart txm
-	t.lggr.Infof("Dropping unstarted and unconfirmed transactions for address: %v", address)
-	return t.txStore.AbandonPendingTransactions(context.TODO(), address)
+	return t.Reset(address, true)
+}
+
+// Reset stops the loops of the address and drops its in-memory state. If abandon is true, unstarted and unconfirmed
+// transactions of the address are dropped as well. If the address was running, its loops are restarted and its nonce
+// is fetched again from the RPC. Other addresses are not affected.
+func (t *Txm) Reset(address common.Address, abandon bool) (err error) {
+	t.addressesMu.Lock()
+	defer t.addressesMu.Unlock()
+
+	running := t.stopAddress(address)
+	if abandon {
+		t.lggr.Infof("Dropping unstarted and unconfirmed transactions for address: %v", address)
+		ctx, cancel := t.stopCh.CtxWithTimeout(pendingNonceDefaultTimeout)
+		defer cancel()
+		err = t.txStore.AbandonPendingTransactions(ctx, address)
+	}
+	if running {
+		t.lggr.Infow("Restarting address", "address", address)
+		t.startAddress(address)
+	}
+	return
+}
+
+// ReconcileAddresses starts the loops of addresses that have been enabled in the keystore and stops the loops of the ones
+// that have been disabled or removed. Transactions of stopped addresses are kept, so they will be picked up again if the
+// address gets re-enabled. It runs every KeyReconcileInterval, but it can also be called on demand, i.e. after a key rotation.
+func (t *Txm) ReconcileAddresses(ctx context.Context) (err error) {
+	if !t.IfStarted(func() {
+		err = t.reconcileAddresses(ctx)
+	}) {
+		return errors.New("Txm unstarted")
+	}
+	return
+}
+
+func (t *Txm) reconcileAddresses(ctx context.Context) (err error) {
+	addresses, err := t.keystore.EnabledAddresses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch enabled addresses: %w", err)
+	}
+
+	t.addressesMu.Lock()
+	defer t.addressesMu.Unlock()
+
+	enabled := make(map[common.Address]struct{}, len(addresses))
+	for _, address := range addresses {
+		enabled[address] = struct{}{}
+		if _, exists := t.addresses[address]; exists {
+			continue
+		}
+		if _, known := t.knownAddresses[address]; !known {
+			if addErr := t.txStore.Add(ctx, address); addErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to add address: %v: %w", address, addErr))
+				continue
+			}
+			t.knownAddresses[address] = struct{}{}
+		}
+		t.lggr.Infow("Starting enabled address", "address", address)
+		t.startAddress(address)
+	}
+	for address := range t.addresses {
+		if _, exists := enabled[address]; !exists {
+			t.lggr.Infow("Stopping disabled address", "address", address)
+			t.stopAddress(address)
+		}
+	}
+	return
+}
+
+func (t *Txm) reconcileLoop() {
+	defer t.wg.Done()
+	ctx, cancel := t.stopCh.NewCtx()
+	defer cancel()
+	ticker := services.NewTicker(t.config.keyReconcileInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.reconcileAddresses(ctx); err != nil {
+				t.lggr.Errorw("Error during address reconciliation", "err", err)
+			}
+		}
+	}
+}
+
+// OnNewBlock keeps track of the latest block number so attempts can be associated with the block they were broadcasted before.
+func (t *Txm) OnNewBlock(blockNumber int64) {
+	t.latestBlockNumber.Store(blockNumber)
+}
+
+func (t *Txm) setBroadcastBeforeBlockNum(attempt *types.Attempt) {
+	if latestBlockNumber := t.latestBlockNumber.Load(); latestBlockNumber > 0 {
+		broadcastBeforeBlockNum := latestBlockNumber + 1
+		attempt.BroadcastBeforeBlockNum = &broadcastBeforeBlockNum
+	}
 }
 
 func (t *Txm) getNonce(address common.Address) uint64 {
@@ -208,6 +434,12 @@ func (t *Txm) setNonce(address common.Address, nonce uint64) {
 	defer t.nonceMapMu.Unlock()
 }
 
+func (t *Txm) deleteNonce(address common.Address) {
+	t.nonceMapMu.Lock()
+	defer t.nonceMapMu.Unlock()
+	delete(t.nonceMap, address)
+}
+
 func newBackoff(minDuration time.Duration) backoff.Backoff {
 	return backoff.Backoff{
 		Min:    minDuration,
@@ -216,9 +448,9 @@ func newBackoff(minDuration time.Duration) backoff.Backoff {
 	}
 }
 
-func (t *Txm) broadcastLoop(address common.Address, triggerCh chan struct{}) {
-	defer t.wg.Done()
-	ctx, cancel := t.stopCh.NewCtx()
+func (t *Txm) broadcastLoop(address common.Address, loops *addressLoops) {
+	defer loops.wg.Done()
+	ctx, cancel := loops.stopCh.NewCtx()
 	defer cancel()
 	broadcastWithBackoff := newBackoff(1 * time.Second)
 	var broadcastCh <-chan time.Time
@@ -242,7 +474,7 @@ func (t *Txm) broadcastLoop(address common.Address, triggerCh chan struct{}) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-triggerCh:
+		case <-loops.triggerCh:
 			continue
 		case <-broadcastCh:
 			continue
@@ -250,9 +482,9 @@ func (t *Txm) broadcastLoop(address common.Address, triggerCh chan struct{}) {
 	}
 }
 
-func (t *Txm) backfillLoop(address common.Address) {
-	defer t.wg.Done()
-	ctx, cancel := t.stopCh.NewCtx()
+func (t *Txm) backfillLoop(address common.Address, loops *addressLoops) {
+	defer loops.wg.Done()
+	ctx, cancel := loops.stopCh.NewCtx()
 	defer cancel()
 	backfillWithBackoff := newBackoff(t.config.BlockTime)
 	backfillCh := time.After(utils.WithJitter(t.config.BlockTime))
@@ -286,13 +518,13 @@ func (t *Txm) broadcastTransaction(ctx context.Context, address common.Address)
 			return false, err
 		}
 
-		// Optimistically send up to maxInFlightSubset of the maxInFlightTransactions. After that threshold, broadcast more cautiously
+		// Optimistically send up to maxInFlightSubset of the maxInFlight transactions. After that threshold, broadcast more cautiously
 		// by checking the pending nonce so no more than maxInFlightSubset can get stuck simultaneously i.e. due
 		// to insufficient balance. We're making this trade-off to avoid storing stuck transactions and making unnecessary
-		// RPC calls. The upper limit is always maxInFlightTransactions regardless of the pending nonce.
-		if unconfirmedCount >= maxInFlightSubset {
-			if unconfirmedCount > maxInFlightTransactions {
-				t.lggr.Warnf("Reached transaction limit: %d for unconfirmed transactions", maxInFlightTransactions)
+		// RPC calls. The upper limit is always maxInFlight regardless of the pending nonce.
+		if unconfirmedCount >= t.config.maxInFlightSubset(address) {
+			if maxInFlight := t.config.maxInFlight(address); unconfirmedCount > maxInFlight {
+				t.lggr.Warnf("Reached transaction limit: %d for unconfirmed transactions", maxInFlight)
 				return true, nil
 			}
 			pendingNonce, e := t.client.PendingNonceAt(ctx, address)
@@ -315,7 +547,13 @@ func (t *Txm) broadcastTransaction(ctx context.Context, address common.Address)
 		if tx == nil {
 			return false, nil
 		}
-		t.setNonce(address, nonce+1)
+		nextNonce := nonce + 1
+		if tx.HasSelfAuthorization() {
+			// The authorization of the sender consumes the next nonce. If the authorization turns out to be invalid,
+			// the nonce gap is filled by the backfill loop.
+			nextNonce++
+		}
+		t.setNonce(address, nextNonce)
 
 		if err := t.createAndSendAttempt(ctx, tx, address); err != nil {
 			return false, err
@@ -332,6 +570,7 @@ func (t *Txm) createAndSendAttempt(ctx context.Context, tx *types.Transaction, a
 	if tx.Nonce == nil {
 		return fmt.Errorf("nonce for txID: %v is empty", tx.ID)
 	}
+	t.setBroadcastBeforeBlockNum(attempt)
 	if err = t.txStore.AppendAttemptToTransaction(ctx, *tx.Nonce, address, attempt); err != nil {
 		return err
 	}
@@ -339,6 +578,36 @@ func (t *Txm) createAndSendAttempt(ctx context.Context, tx *types.Transaction, a
 	return t.sendTransactionWithError(ctx, tx, attempt, address)
 }
 
+// createAndSendBumpAttempt bumps the fee of the latest attempt according to the bump policy. If the fee can't be bumped
+// any further because the max price of the key has been reached, the latest attempt is rebroadcasted instead.
+func (t *Txm) createAndSendBumpAttempt(ctx context.Context, tx *types.Transaction, address common.Address) error {
+	if tx.Nonce == nil {
+		return fmt.Errorf("nonce for txID: %v is empty", tx.ID)
+	}
+	latestAttempt := tx.Attempts[len(tx.Attempts)-1]
+	bumps := t.bumpPolicy.Bumps(tx, t.latestBlockNumber.Load())
+	attempt, err := t.attemptBuilder.NewBumpAttempt(ctx, t.lggr, tx, *latestAttempt, bumps)
+	if err != nil {
+		if errors.Is(err, fees.ErrBumpFeeExceedsLimit) {
+			t.lggr.Warnw("Unable to bump fee any further. Rebroadcasting latest attempt", "txID", tx.ID, "attempt", latestAttempt, "err", err)
+			// The rebroadcast counts towards MaxAttempts, even though no attempt is appended.
+			if err = t.txStore.IncrementAttemptCount(ctx, *tx.Nonce, address); err != nil {
+				return err
+			}
+			return t.sendTransactionWithError(ctx, tx, latestAttempt, address)
+		}
+		return err
+	}
+
+	t.setBroadcastBeforeBlockNum(attempt)
+	if err = t.txStore.AppendAttemptToTransaction(ctx, *tx.Nonce, address, attempt); err != nil {
+		return err
+	}
+
+	t.lggr.Infow("Rebroadcasting bumped attempt", "txID", tx.ID, "bumps", bumps, "previousFee", latestAttempt.Fee, "fee", attempt.Fee)
+	return t.sendTransactionWithError(ctx, tx, attempt, address)
+}
+
 func (t *Txm) sendTransactionWithError(ctx context.Context, tx *types.Transaction, attempt *types.Attempt, fromAddress common.Address) (err error) {
 	if tx.Nonce == nil {
 		return fmt.Errorf("nonce for txID: %v is empty", tx.ID)
@@ -383,6 +652,12 @@ func (t *Txm) backfillTransactions(ctx context.Context, address common.Address)
 		t.metrics.IncrementNumConfirmedTxs(ctx, len(confirmedTransactions))
 		confirmedTransactionIDs := t.extractMetrics(ctx, confirmedTransactions)
 		t.lggr.Infof("Confirmed transaction IDs: %v . Re-orged transaction IDs: %v", confirmedTransactionIDs, unconfirmedTransactionIDs)
+		for _, tx := range confirmedTransactions {
+			// Receipts are fetched on a best-effort basis. Missing receipts will be fetched again once they are requested.
+			if _, err := t.updateReceipt(ctx, tx); err != nil {
+				t.lggr.Warnw("Unable to fetch receipt for confirmed transaction", "txID", tx.ID, "err", err)
+			}
+		}
 	}
 
 	tx, unconfirmedCount, err := t.txStore.FetchUnconfirmedTransactionAtNonceWithCount(ctx, latestNonce, address)
@@ -415,7 +690,7 @@ func (t *Txm) backfillTransactions(ctx context.Context, address common.Address)
 			}
 		}
 
-		if tx.AttemptCount >= maxAllowedAttempts {
+		if tx.AttemptCount >= t.config.maxAttempts() {
 			return true, fmt.Errorf("reached max allowed attempts for txID: %d. TXM won't broadcast any more attempts."+
 				"If this error persists, it means the transaction won't be confirmed and the TXM needs to be restarted."+
 				"Look for any error messages from previous broadcasted attempts that may indicate why this happened, i.e. wallet is out of funds. Tx: %v", tx.ID,
@@ -423,7 +698,9 @@ func (t *Txm) backfillTransactions(ctx context.Context, address common.Address)
 		}
 
 		if tx.LastBroadcastAt == nil || time.Since(*tx.LastBroadcastAt) > (t.config.BlockTime*time.Duration(t.config.RetryBlockThreshold)) {
-			// TODO: add optional graceful bumping strategy
+			if t.bumpPolicy != nil && tx.LastBroadcastAt != nil && len(tx.Attempts) > 0 {
+				return false, t.createAndSendBumpAttempt(ctx, tx, address)
+			}
 			t.lggr.Info("Rebroadcasting attempt for txID: ", tx.ID)
 			return false, t.createAndSendAttempt(ctx, tx, address)
 		}
@@ -431,6 +708,41 @@ func (t *Txm) backfillTransactions(ctx context.Context, address common.Address)
 	return false, nil
 }
 
+// updateReceipt fetches the receipt of the confirmed transaction and stores it if it's new or has changed due to a re-org.
+// The attempt of the stored receipt is checked first, followed by the rest of the attempts, latest first. If the RPC
+// doesn't return a receipt for any of the attempts, the stored receipt is returned instead.
+func (t *Txm) updateReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	hashes := make([]common.Hash, 0, len(tx.Attempts)+1)
+	if tx.Receipt != nil {
+		hashes = append(hashes, tx.Receipt.TxHash)
+	}
+	for i := len(tx.Attempts) - 1; i >= 0; i-- {
+		hashes = append(hashes, tx.Attempts[i].Hash)
+	}
+
+	for _, hash := range hashes {
+		receipt, err := t.client.TransactionReceipt(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch receipt for txID: %v, attemptHash: %v: %w", tx.ID, hash, err)
+		}
+		if receipt == nil || receipt.BlockNumber == nil {
+			continue
+		}
+		receipt.TxID = tx.ID
+		if tx.Receipt == nil || tx.Receipt.BlockHash != receipt.BlockHash || tx.Receipt.TxHash != receipt.TxHash {
+			if err = t.txStore.UpdateTransactionReceipt(ctx, receipt, tx.FromAddress); err != nil {
+				return nil, err
+			}
+			t.lggr.Debugw("Stored receipt", "txID", tx.ID, "receipt", receipt)
+		}
+		return receipt, nil
+	}
+	if tx.Receipt != nil {
+		return tx.Receipt, nil
+	}
+	return nil, fmt.Errorf("receipt for txID: %v was not found", tx.ID)
+}
+
 func (t *Txm) createAndSendEmptyTx(ctx context.Context, latestNonce uint64, address common.Address) error {
 	tx, err := t.txStore.CreateEmptyUnconfirmedTransaction(ctx, address, latestNonce, t.config.EmptyTxLimitDefault)
 	if err != nil {
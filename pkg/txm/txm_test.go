@@ -1,8 +1,11 @@
 package txm
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,6 +24,8 @@ import (
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/storage"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/fees"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
 )
 
 func TestLifecycle(t *testing.T) {
@@ -36,10 +41,10 @@ func TestLifecycle(t *testing.T) {
 	t.Run("retries if initial pending nonce call fails", func(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
 		config := Config{BlockTime: 1 * time.Minute}
-		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address1))
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address1))
 		keystore := keystest.Addresses{address1}
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, nil, txStore, nil, config, keystore)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, nil, txStore, nil, nil, config, keystore)
 		client.On("PendingNonceAt", mock.Anything, address1).Return(uint64(0), errors.New("error")).Once()
 		client.On("PendingNonceAt", mock.Anything, address1).Return(uint64(100), nil).Once()
 		servicetest.Run(t, txm)
@@ -47,13 +52,30 @@ func TestLifecycle(t *testing.T) {
 		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Set initial nonce for address: %v to %d", address1, 100))
 	})
 
+	t.Run("starts after the highest stored unconfirmed nonce", func(t *testing.T) {
+		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
+		config := Config{BlockTime: 1 * time.Minute}
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address1))
+		_, err := txStore.CreateTransaction(t.Context(), &types.TxRequest{FromAddress: address1})
+		require.NoError(t, err)
+		_, err = txStore.UpdateUnstartedTransactionWithNonce(t.Context(), address1, 7)
+		require.NoError(t, err)
+		keystore := keystest.Addresses{address1}
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, nil, txStore, nil, nil, config, keystore)
+		client.On("PendingNonceAt", mock.Anything, address1).Return(uint64(5), nil).Once()
+		client.On("NonceAt", mock.Anything, address1, mock.Anything).Return(uint64(5), nil).Maybe()
+		servicetest.Run(t, txm)
+		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Set initial nonce for address: %v to %d", address1, 8))
+	})
+
 	t.Run("tests lifecycle successfully without any transactions", func(t *testing.T) {
 		config := Config{BlockTime: 200 * time.Millisecond}
 		keystore := keystest.Addresses(addresses)
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
-		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(addresses...))
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), addresses...))
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, config, keystore)
 		var nonce uint64
 		// Start
 		client.On("PendingNonceAt", mock.Anything, address1).Return(nonce, nil).Once()
@@ -74,20 +96,20 @@ func TestTrigger(t *testing.T) {
 
 	t.Run("Trigger fails if Txm is unstarted", func(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.ErrorLevel)
-		txm := NewTxm(lggr, nil, nil, nil, nil, nil, Config{}, keystest.Addresses{})
+		txm := NewTxm(lggr, nil, nil, nil, nil, nil, nil, Config{}, keystest.Addresses{})
 		txm.Trigger(address)
 		tests.AssertLogEventually(t, observedLogs, "Txm unstarted")
 	})
 
 	t.Run("executes Trigger", func(t *testing.T) {
 		lggr := logger.Test(t)
-		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
 		client := newMockClient(t)
 		ab := newMockAttemptBuilder(t)
 		config := Config{BlockTime: 1 * time.Minute, RetryBlockThreshold: 10}
 		keystore := keystest.Addresses{address}
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, config, keystore)
 		var nonce uint64
 		// Start
 		client.On("PendingNonceAt", mock.Anything, address).Return(nonce, nil).Maybe()
@@ -96,6 +118,107 @@ func TestTrigger(t *testing.T) {
 	})
 }
 
+type fakeAddressLister struct {
+	mu        sync.Mutex
+	addresses []common.Address
+}
+
+func (f *fakeAddressLister) EnabledAddresses(_ context.Context) ([]common.Address, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addresses, nil
+}
+
+func (f *fakeAddressLister) set(addresses ...common.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addresses = addresses
+}
+
+func (t *Txm) runningAddresses() (addresses []common.Address) {
+	t.addressesMu.Lock()
+	defer t.addressesMu.Unlock()
+	for address := range t.addresses {
+		addresses = append(addresses, address)
+	}
+	return
+}
+
+func TestReconcileAddresses(t *testing.T) {
+	t.Parallel()
+
+	address1 := testutils.NewAddress()
+	address2 := testutils.NewAddress()
+	config := Config{BlockTime: 1 * time.Minute, KeyReconcileInterval: 1 * time.Hour}
+
+	t.Run("fails if Txm is unstarted", func(t *testing.T) {
+		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, nil, nil, nil, nil, nil, config, &fakeAddressLister{})
+		require.ErrorContains(t, txm.ReconcileAddresses(t.Context()), "Txm unstarted")
+	})
+
+	t.Run("starts enabled addresses and stops disabled ones", func(t *testing.T) {
+		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
+		client := newMockClient(t)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address1))
+		keystore := &fakeAddressLister{addresses: []common.Address{address1}}
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, nil, txStore, nil, nil, config, keystore)
+		client.On("PendingNonceAt", mock.Anything, address1).Return(uint64(0), nil)
+		client.On("PendingNonceAt", mock.Anything, address2).Return(uint64(3), nil).Once()
+		servicetest.Run(t, txm)
+		assert.ElementsMatch(t, []common.Address{address1}, txm.runningAddresses())
+
+		// Rotate keys
+		keystore.set(address2)
+		require.NoError(t, txm.ReconcileAddresses(t.Context()))
+		assert.ElementsMatch(t, []common.Address{address2}, txm.runningAddresses())
+		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Set initial nonce for address: %v to %d", address2, 3))
+		_, exists := txStore.InMemoryStoreMap[address2]
+		assert.True(t, exists)
+
+		// Re-enable the first address. Its store is already known, so it's only restarted.
+		keystore.set(address1, address2)
+		require.NoError(t, txm.ReconcileAddresses(t.Context()))
+		assert.ElementsMatch(t, []common.Address{address1, address2}, txm.runningAddresses())
+	})
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	address := testutils.NewAddress()
+	config := Config{BlockTime: 1 * time.Minute}
+
+	t.Run("resets the address and abandons its transactions", func(t *testing.T) {
+		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
+		client := newMockClient(t)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, nil, txStore, nil, nil, config, keystest.Addresses{address})
+		client.On("PendingNonceAt", mock.Anything, address).Return(uint64(5), nil).Once()
+		client.On("PendingNonceAt", mock.Anything, address).Return(uint64(9), nil).Once()
+		client.On("NonceAt", mock.Anything, address, mock.Anything).Return(uint64(5), nil).Maybe()
+		servicetest.Run(t, txm)
+		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Set initial nonce for address: %v to %d", address, 5))
+
+		tx, err := txStore.CreateTransaction(t.Context(), &types.TxRequest{FromAddress: address})
+		require.NoError(t, err)
+		require.NoError(t, txm.Reset(address, true))
+		assert.Equal(t, txmgr.TxFatalError, txStore.InMemoryStoreMap[address].Transactions[tx.ID].State)
+		assert.ElementsMatch(t, []common.Address{address}, txm.runningAddresses())
+		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Set initial nonce for address: %v to %d", address, 9))
+	})
+
+	t.Run("doesn't start an address that isn't running", func(t *testing.T) {
+		lggr := logger.Test(t)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		txm := NewTxm(lggr, testutils.FixtureChainID, nil, nil, txStore, nil, nil, config, keystest.Addresses{})
+		require.NoError(t, txm.Reset(address, true))
+		assert.Empty(t, txm.runningAddresses())
+	})
+}
+
 func TestBroadcastTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -109,7 +232,7 @@ func TestBroadcastTransaction(t *testing.T) {
 	t.Run("fails if FetchUnconfirmedTransactionAtNonceWithCount for unconfirmed transactions fails", func(t *testing.T) {
 		mTxStore := newMockTxStore(t)
 		mTxStore.On("FetchUnconfirmedTransactionAtNonceWithCount", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, errors.New("call failed")).Once()
-		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, mTxStore, nil, config, keystore)
+		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, mTxStore, nil, nil, config, keystore)
 		bo, err := txm.broadcastTransaction(ctx, address)
 		require.Error(t, err)
 		assert.False(t, bo)
@@ -120,17 +243,32 @@ func TestBroadcastTransaction(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
 		mTxStore := newMockTxStore(t)
 		mTxStore.On("FetchUnconfirmedTransactionAtNonceWithCount", mock.Anything, mock.Anything, mock.Anything).Return(nil, maxInFlightTransactions+1, nil).Once()
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, mTxStore, nil, config, keystore)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, mTxStore, nil, nil, config, keystore)
 		bo, err := txm.broadcastTransaction(ctx, address)
 		assert.True(t, bo)
 		require.NoError(t, err)
 		tests.AssertLogEventually(t, observedLogs, "Reached transaction limit")
 	})
 
+	t.Run("uses configured in-flight limits of the address", func(t *testing.T) {
+		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
+		mTxStore := newMockTxStore(t)
+		c := Config{
+			MaxInFlightKey:       func(common.Address) uint32 { return 2 },
+			MaxInFlightSubsetKey: func(common.Address) uint32 { return 5 },
+		}
+		mTxStore.On("FetchUnconfirmedTransactionAtNonceWithCount", mock.Anything, mock.Anything, mock.Anything).Return(nil, 3, nil).Once()
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, mTxStore, nil, nil, c, keystore)
+		bo, err := txm.broadcastTransaction(ctx, address)
+		assert.True(t, bo)
+		require.NoError(t, err)
+		tests.AssertLogEventually(t, observedLogs, "Reached transaction limit: 2 for unconfirmed transactions")
+	})
+
 	t.Run("checks pending nonce if unconfirmed transactions are equal or more than maxInFlightSubset", func(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
 		mTxStore := newMockTxStore(t)
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, mTxStore, nil, config, keystore)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, mTxStore, nil, nil, config, keystore)
 		txm.setNonce(address, 1)
 		mTxStore.On("FetchUnconfirmedTransactionAtNonceWithCount", mock.Anything, mock.Anything, mock.Anything).Return(nil, maxInFlightSubset, nil).Twice()
 
@@ -150,7 +288,7 @@ func TestBroadcastTransaction(t *testing.T) {
 	t.Run("fails if UpdateUnstartedTransactionWithNonce fails", func(t *testing.T) {
 		mTxStore := newMockTxStore(t)
 		mTxStore.On("FetchUnconfirmedTransactionAtNonceWithCount", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil).Once()
-		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, mTxStore, nil, config, keystore)
+		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, mTxStore, nil, nil, config, keystore)
 		mTxStore.On("UpdateUnstartedTransactionWithNonce", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("call failed")).Once()
 		bo, err := txm.broadcastTransaction(ctx, address)
 		assert.False(t, bo)
@@ -160,9 +298,9 @@ func TestBroadcastTransaction(t *testing.T) {
 
 	t.Run("returns if there are no unstarted transactions", func(t *testing.T) {
 		lggr := logger.Test(t)
-		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, config, keystore)
 		bo, err := txm.broadcastTransaction(ctx, address)
 		require.NoError(t, err)
 		assert.False(t, bo)
@@ -171,9 +309,9 @@ func TestBroadcastTransaction(t *testing.T) {
 
 	t.Run("picks a new tx and creates a new attempt then sends it and updates the broadcast time", func(t *testing.T) {
 		lggr := logger.Test(t)
-		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, config, keystore)
 		txm.setNonce(address, 8)
 		metrics, err := NewTxmMetrics(testutils.FixtureChainID)
 		require.NoError(t, err)
@@ -209,6 +347,30 @@ func TestBroadcastTransaction(t *testing.T) {
 		assert.Greater(t, *tx.Attempts[0].BroadcastAt, zeroTime)
 		assert.Greater(t, *tx.InitialBroadcastAt, zeroTime)
 	})
+
+	t.Run("skips the nonce consumed by the authorization of the sender", func(t *testing.T) {
+		lggr := logger.Test(t)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, config, keystore)
+		txm.setNonce(address, 8)
+		metrics, err := NewTxmMetrics(testutils.FixtureChainID)
+		require.NoError(t, err)
+		txm.metrics = metrics
+		_, err = txm.CreateTransaction(t.Context(), &types.TxRequest{
+			FromAddress:       address,
+			ToAddress:         address,
+			SpecifiedGasLimit: 22000,
+			AuthorizationList: []types.SetCodeAuthorization{{Authority: address, Address: testutils.NewAddress()}},
+		})
+		require.NoError(t, err)
+		ab.On("NewAttempt", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.Attempt{GasLimit: 22000}, nil).Once()
+		client.On("SendTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err = txm.broadcastTransaction(ctx, address)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), txm.getNonce(address))
+	})
 }
 
 func TestBackfillTransactions(t *testing.T) {
@@ -222,7 +384,7 @@ func TestBackfillTransactions(t *testing.T) {
 	keystore := keystest.Addresses{}
 
 	t.Run("fails if latest nonce fetching fails", func(t *testing.T) {
-		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
+		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, txStore, nil, nil, config, keystore)
 		client.On("NonceAt", mock.Anything, address, mock.Anything).Return(uint64(0), errors.New("latest nonce fail")).Once()
 		bo, err := txm.backfillTransactions(t.Context(), address)
 		require.Error(t, err)
@@ -231,7 +393,7 @@ func TestBackfillTransactions(t *testing.T) {
 	})
 
 	t.Run("fails if MarkConfirmedAndReorgedTransactions fails", func(t *testing.T) {
-		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
+		txm := NewTxm(logger.Test(t), testutils.FixtureChainID, client, ab, txStore, nil, nil, config, keystore)
 		client.On("NonceAt", mock.Anything, address, mock.Anything).Return(uint64(0), nil).Once()
 		txStore.On("MarkConfirmedAndReorgedTransactions", mock.Anything, mock.Anything, address).
 			Return([]*types.Transaction{}, []uint64{}, errors.New("marking transactions confirmed failed")).Once()
@@ -243,11 +405,11 @@ func TestBackfillTransactions(t *testing.T) {
 
 	t.Run("fills nonce gap", func(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
-		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
 		ab := newMockAttemptBuilder(t)
 		c := Config{EIP1559: false, BlockTime: 10 * time.Minute, RetryBlockThreshold: 10, EmptyTxLimitDefault: 22000}
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, c, keystore)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, c, keystore)
 		emptyMetrics, err := NewTxmMetrics(testutils.FixtureChainID)
 		require.NoError(t, err)
 		txm.metrics = emptyMetrics
@@ -285,11 +447,11 @@ func TestBackfillTransactions(t *testing.T) {
 
 	t.Run("retries attempt after threshold", func(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
-		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
 		ab := newMockAttemptBuilder(t)
 		c := Config{EIP1559: false, BlockTime: 1 * time.Second, RetryBlockThreshold: 1, EmptyTxLimitDefault: 22000}
-		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, c, keystore)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, c, keystore)
 		emptyMetrics, err := NewTxmMetrics(testutils.FixtureChainID)
 		require.NoError(t, err)
 		txm.metrics = emptyMetrics
@@ -321,4 +483,111 @@ func TestBackfillTransactions(t *testing.T) {
 		require.NoError(t, err)
 		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Rebroadcasting attempt for txID: %d", attempt.TxID))
 	})
+
+	t.Run("bumps attempt after threshold if bump policy is set", func(t *testing.T) {
+		lggr := logger.Test(t)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		ab := newMockAttemptBuilder(t)
+		c := Config{EIP1559: false, BlockTime: 1 * time.Millisecond, RetryBlockThreshold: 1, EmptyTxLimitDefault: 22000}
+		bumpPolicy, err := NewBumpPolicy(BumpPolicyConfig{MaxBumpsPerAttempt: 1})
+		require.NoError(t, err)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, bumpPolicy, c, keystore)
+		emptyMetrics, err := NewTxmMetrics(testutils.FixtureChainID)
+		require.NoError(t, err)
+		txm.metrics = emptyMetrics
+
+		tx, err := txm.CreateTransaction(t.Context(), &types.TxRequest{FromAddress: address, ToAddress: testutils.NewAddress(), SpecifiedGasLimit: 22000})
+		require.NoError(t, err)
+		_, err = txStore.UpdateUnstartedTransactionWithNonce(t.Context(), address, 0)
+		require.NoError(t, err)
+		attempt := &types.Attempt{TxID: tx.ID, Hash: testutils.NewHash(), Fee: gas.EvmFee{GasPrice: assets.NewWeiI(1)}, GasLimit: 22000}
+		require.NoError(t, txStore.AppendAttemptToTransaction(t.Context(), 0, address, attempt))
+		require.NoError(t, txStore.UpdateTransactionBroadcast(t.Context(), tx.ID, 0, attempt.Hash, address))
+		time.Sleep(2 * c.BlockTime)
+
+		bumpedAttempt := &types.Attempt{TxID: tx.ID, Hash: testutils.NewHash(), Fee: gas.EvmFee{GasPrice: assets.NewWeiI(2)}, GasLimit: 22000}
+		ab.On("NewBumpAttempt", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(a types.Attempt) bool { return a.Hash == attempt.Hash }), uint32(1)).
+			Return(bumpedAttempt, nil).Once()
+		client.On("NonceAt", mock.Anything, address, mock.Anything).Return(uint64(0), nil).Once()
+		client.On("SendTransaction", mock.Anything, mock.Anything, bumpedAttempt).Return(nil).Once()
+		_, err = txm.backfillTransactions(t.Context(), address)
+		require.NoError(t, err)
+
+		// If the fee can't be bumped any further, the latest attempt is rebroadcasted
+		time.Sleep(2 * c.BlockTime)
+		ab.On("NewBumpAttempt", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fees.ErrBumpFeeExceedsLimit).Once()
+		client.On("NonceAt", mock.Anything, address, mock.Anything).Return(uint64(0), nil).Once()
+		client.On("SendTransaction", mock.Anything, mock.Anything, mock.MatchedBy(func(a *types.Attempt) bool { return a.Hash == bumpedAttempt.Hash })).Return(nil).Once()
+		_, err = txm.backfillTransactions(t.Context(), address)
+		require.NoError(t, err)
+
+		tx, count, err := txStore.FetchUnconfirmedTransactionAtNonceWithCount(t.Context(), 0, address)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Len(t, tx.Attempts, 2)
+		assert.Equal(t, uint16(3), tx.AttemptCount, "rebroadcasts count towards MaxAttempts")
+	})
+}
+
+func TestUpdateReceipt(t *testing.T) {
+	t.Parallel()
+
+	address := testutils.NewAddress()
+	lggr := logger.Test(t)
+	ab := newMockAttemptBuilder(t)
+	keystore := keystest.Addresses{}
+	hash1 := testutils.NewHash()
+	hash2 := testutils.NewHash()
+
+	newConfirmedTx := func(t *testing.T, txStore *storage.InMemoryStoreManager) *types.Transaction {
+		tx, err := txStore.CreateTransaction(t.Context(), &types.TxRequest{FromAddress: address})
+		require.NoError(t, err)
+		_, err = txStore.UpdateUnstartedTransactionWithNonce(t.Context(), address, 0)
+		require.NoError(t, err)
+		require.NoError(t, txStore.AppendAttemptToTransaction(t.Context(), 0, address, &types.Attempt{TxID: tx.ID, Hash: hash1}))
+		require.NoError(t, txStore.AppendAttemptToTransaction(t.Context(), 0, address, &types.Attempt{TxID: tx.ID, Hash: hash2}))
+		confirmed, _, err := txStore.MarkConfirmedAndReorgedTransactions(t.Context(), 1, address)
+		require.NoError(t, err)
+		require.Len(t, confirmed, 1)
+		return confirmed[0]
+	}
+
+	t.Run("fails if receipt was not found for any attempt", func(t *testing.T) {
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		client := newMockClient(t)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, Config{}, keystore)
+		tx := newConfirmedTx(t, txStore)
+		client.On("TransactionReceipt", mock.Anything, hash2).Return(nil, nil).Once()
+		client.On("TransactionReceipt", mock.Anything, hash1).Return(nil, nil).Once()
+		_, err := txm.updateReceipt(t.Context(), tx)
+		require.ErrorContains(t, err, "was not found")
+	})
+
+	t.Run("stores receipt of the included attempt and updates it after a re-org", func(t *testing.T) {
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID, nil)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		client := newMockClient(t)
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, nil, Config{}, keystore)
+		tx := newConfirmedTx(t, txStore)
+		receipt := &types.Receipt{TxHash: hash1, BlockHash: testutils.NewHash(), BlockNumber: big.NewInt(1), GasUsed: 21000, EffectiveGasPrice: big.NewInt(2)}
+		client.On("TransactionReceipt", mock.Anything, hash2).Return(nil, nil).Once()
+		client.On("TransactionReceipt", mock.Anything, hash1).Return(receipt, nil).Once()
+		r, err := txm.updateReceipt(t.Context(), tx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42000), r.Fee().Int64())
+
+		tx = txStore.InMemoryStoreMap[address].Transactions[tx.ID].DeepCopy()
+		require.NotNil(t, tx.Receipt)
+		assert.Equal(t, receipt.BlockHash, tx.Receipt.BlockHash)
+
+		// Same attempt was included in a different block with a different effective gas price
+		reorgedReceipt := &types.Receipt{TxHash: hash1, BlockHash: testutils.NewHash(), BlockNumber: big.NewInt(2), GasUsed: 21000, EffectiveGasPrice: big.NewInt(3)}
+		client.On("TransactionReceipt", mock.Anything, hash1).Return(reorgedReceipt, nil).Once()
+		r, err = txm.updateReceipt(t.Context(), tx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(63000), r.Fee().Int64())
+		assert.Equal(t, reorgedReceipt.BlockHash, txStore.InMemoryStoreMap[address].Transactions[tx.ID].Receipt.BlockHash)
+	})
 }
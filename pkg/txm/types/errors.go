@@ -0,0 +1,18 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// QueueFullError is returned when a transaction is created but the queue of unstarted transactions for the address
+// has reached its capacity. Callers are expected to retry once the queue has been drained.
+type QueueFullError struct {
+	FromAddress common.Address
+	MaxQueued   int
+}
+
+func (e *QueueFullError) Error() string {
+	return fmt.Sprintf("unstarted transactions queue for address: %v reached max limit of: %d", e.FromAddress, e.MaxQueued)
+}
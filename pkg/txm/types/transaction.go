@@ -3,14 +3,17 @@ package types
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
@@ -30,6 +33,8 @@ type Transaction struct {
 	Value             *big.Int
 	Data              []byte
 	SpecifiedGasLimit uint64
+	BlobSidecar       *types.BlobTxSidecar   // Only set for EIP-4844 blob transactions
+	AuthorizationList []SetCodeAuthorization // Only set for EIP-7702 set code transactions
 
 	CreatedAt          time.Time
 	InitialBroadcastAt *time.Time
@@ -41,6 +46,7 @@ type Transaction struct {
 	AttemptCount uint16 // AttempCount is strictly kept in memory and prevents indefinite retrying
 	Meta         *sqlutil.JSON
 	Subject      uuid.NullUUID
+	Receipt      *Receipt // Receipt of the included attempt. It's only available for confirmed transactions.
 
 	// Pipeline variables - if you aren't calling this from chain tx task within
 	// the pipeline, you don't need these variables
@@ -92,9 +98,24 @@ func (t *Transaction) DeepCopy() *Transaction {
 		attemptsCopy = append(attemptsCopy, attempt.DeepCopy())
 	}
 	txCopy.Attempts = attemptsCopy
+	txCopy.AuthorizationList = slices.Clone(t.AuthorizationList)
+	if t.Receipt != nil {
+		txCopy.Receipt = t.Receipt.DeepCopy()
+	}
 	return &txCopy
 }
 
+// HasSelfAuthorization returns true if the authorization list contains an authorization of FromAddress. Such an
+// authorization consumes the nonce that follows the nonce of the transaction.
+func (t *Transaction) HasSelfAuthorization() bool {
+	for _, auth := range t.AuthorizationList {
+		if auth.Authority == t.FromAddress {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *Transaction) GetMeta() (*TxMeta, error) {
 	if t.Meta == nil {
 		return nil, nil
@@ -115,21 +136,97 @@ type Attempt struct {
 	Type              byte
 	SignedTransaction *types.Transaction
 
-	CreatedAt   time.Time
-	BroadcastAt *time.Time
+	CreatedAt               time.Time
+	BroadcastAt             *time.Time
+	BroadcastBeforeBlockNum *int64 // The block following the latest known block at the time the attempt was created.
 }
 
 func (a *Attempt) DeepCopy() *Attempt {
 	txCopy := *a
 	if a.SignedTransaction != nil {
+		// WithoutBlobTxSidecar returns a copy of the transaction. The sidecar is immutable so it can be shared.
 		txCopy.SignedTransaction = a.SignedTransaction.WithoutBlobTxSidecar()
+		if sidecar := a.SignedTransaction.BlobTxSidecar(); sidecar != nil {
+			txCopy.SignedTransaction = txCopy.SignedTransaction.WithBlobTxSidecar(sidecar)
+		}
 	}
 	return &txCopy
 }
 
 func (a *Attempt) String() string {
-	return fmt.Sprintf(`{ID:%d, TxID:%d, Hash:%v, Fee:%v, GasLimit:%d, Type:%v, CreatedAt:%v, BroadcastAt:%v}`,
-		a.ID, a.TxID, a.Hash, a.Fee, a.GasLimit, a.Type, a.CreatedAt, stringOrNull(a.BroadcastAt))
+	return fmt.Sprintf(`{ID:%d, TxID:%d, Hash:%v, Fee:%v, GasLimit:%d, Type:%v, CreatedAt:%v, BroadcastAt:%v, BroadcastBeforeBlockNum:%v}`,
+		a.ID, a.TxID, a.Hash, a.Fee, a.GasLimit, a.Type, a.CreatedAt, stringOrNull(a.BroadcastAt), stringOrNull(a.BroadcastBeforeBlockNum))
+}
+
+type Receipt struct {
+	TxID              uint64
+	TxHash            common.Hash
+	BlockHash         common.Hash
+	BlockNumber       *big.Int
+	TransactionIndex  uint
+	Status            uint64
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+	L1Fee             *big.Int // Only provided by rollups that charge a separate L1/DA fee, i.e. OP stack and Scroll
+}
+
+// Fee returns the total cost of the transaction in wei, including the L1/DA fee for rollups.
+func (r *Receipt) Fee() *big.Int {
+	fee := new(big.Int).SetUint64(r.GasUsed)
+	if r.EffectiveGasPrice != nil {
+		fee.Mul(fee, r.EffectiveGasPrice)
+	} else {
+		fee.SetUint64(0)
+	}
+	if r.L1Fee != nil {
+		fee.Add(fee, r.L1Fee)
+	}
+	return fee
+}
+
+func (r *Receipt) DeepCopy() *Receipt {
+	receiptCopy := *r
+	if r.BlockNumber != nil {
+		receiptCopy.BlockNumber = new(big.Int).Set(r.BlockNumber)
+	}
+	if r.EffectiveGasPrice != nil {
+		receiptCopy.EffectiveGasPrice = new(big.Int).Set(r.EffectiveGasPrice)
+	}
+	if r.L1Fee != nil {
+		receiptCopy.L1Fee = new(big.Int).Set(r.L1Fee)
+	}
+	return &receiptCopy
+}
+
+func (r *Receipt) String() string {
+	return fmt.Sprintf(`{TxID:%d, TxHash:%v, BlockHash:%v, BlockNumber:%v, TransactionIndex:%d, Status:%d, GasUsed:%d, EffectiveGasPrice:%v, L1Fee:%v}`,
+		r.TxID, r.TxHash, r.BlockHash, r.BlockNumber, r.TransactionIndex, r.Status, r.GasUsed, r.EffectiveGasPrice, r.L1Fee)
+}
+
+// UnmarshalJSON decodes an eth_getTransactionReceipt response.
+func (r *Receipt) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		TxHash            common.Hash    `json:"transactionHash"`
+		BlockHash         common.Hash    `json:"blockHash"`
+		BlockNumber       *hexutil.Big   `json:"blockNumber"`
+		TransactionIndex  hexutil.Uint   `json:"transactionIndex"`
+		Status            hexutil.Uint64 `json:"status"`
+		GasUsed           hexutil.Uint64 `json:"gasUsed"`
+		EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
+		L1Fee             *hexutil.Big   `json:"l1Fee"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return fmt.Errorf("could not unmarshal receipt: %w", err)
+	}
+	r.TxHash = dec.TxHash
+	r.BlockHash = dec.BlockHash
+	r.BlockNumber = (*big.Int)(dec.BlockNumber)
+	r.TransactionIndex = uint(dec.TransactionIndex)
+	r.Status = uint64(dec.Status)
+	r.GasUsed = uint64(dec.GasUsed)
+	r.EffectiveGasPrice = (*big.Int)(dec.EffectiveGasPrice)
+	r.L1Fee = (*big.Int)(dec.L1Fee)
+	return nil
 }
 
 type TxRequest struct {
@@ -140,6 +237,12 @@ type TxRequest struct {
 	Value             *big.Int
 	Data              []byte
 	SpecifiedGasLimit uint64
+	// BlobSidecar turns the request into an EIP-4844 blob transaction. The blobs, commitments and proofs must already be
+	// computed, i.e. with kzg4844.
+	BlobSidecar *types.BlobTxSidecar
+	// AuthorizationList turns the request into an EIP-7702 set code transaction. The authorizations are signed with
+	// the keystore when the attempts are created.
+	AuthorizationList []SetCodeAuthorization
 
 	Meta             *sqlutil.JSON // TODO: *TxMeta after migration
 	ForwarderAddress common.Address
@@ -151,6 +254,50 @@ type TxRequest struct {
 	SignalCallback    bool
 }
 
+// SetCodeAuthorization requests an EIP-7702 authorization that delegates the code of Authority to Address. Delegating
+// to the zero address clears the delegation. Authority must be an address of the keystore.
+type SetCodeAuthorization struct {
+	Authority common.Address `json:"authority"`
+	Address   common.Address `json:"address"`
+	// Nonce is the nonce of Authority. It must be set if Authority is not the FromAddress of the transaction. Otherwise,
+	// it's ignored, since the authorization nonce of FromAddress is always the transaction nonce + 1.
+	Nonce *uint64 `json:"nonce,omitempty"`
+}
+
+// ValidateAuthorizationList checks that each authority appears at most once in the authorization list and that the
+// nonces of authorities other than FromAddress are set.
+func (t *TxRequest) ValidateAuthorizationList() error {
+	authorities := make(map[common.Address]struct{}, len(t.AuthorizationList))
+	for _, auth := range t.AuthorizationList {
+		if _, ok := authorities[auth.Authority]; ok {
+			return fmt.Errorf("duplicate authorization for authority: %v", auth.Authority)
+		}
+		authorities[auth.Authority] = struct{}{}
+		if auth.Authority != t.FromAddress && auth.Nonce == nil {
+			return fmt.Errorf("nonce is required for authorization of authority: %v", auth.Authority)
+		}
+	}
+	if len(t.AuthorizationList) > 0 && t.BlobSidecar != nil {
+		return errors.New("a transaction can't have both a blob sidecar and an authorization list")
+	}
+	return nil
+}
+
+// ValidateValue returns an error if the value of a transaction can't be encoded, i.e. it's negative or doesn't fit in
+// 256 bits.
+func ValidateValue(value *big.Int) error {
+	if value == nil {
+		return nil
+	}
+	if value.Sign() < 0 {
+		return fmt.Errorf("value can't be negative: %v", value)
+	}
+	if value.BitLen() > 256 {
+		return fmt.Errorf("value doesn't fit in 256 bits: %v", value)
+	}
+	return nil
+}
+
 type TxMeta struct {
 	// Pipeline
 	JobID        *int32    `json:"JobID,omitempty"`
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -109,3 +110,61 @@ func TestTransaction_GetMeta(t *testing.T) {
 }
 
 func ptr[T any](t T) *T { return &t }
+
+func TestReceipt_UnmarshalJSONAndFee(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without L1 fee", func(t *testing.T) {
+		var r Receipt
+		require.NoError(t, json.Unmarshal([]byte(`{"transactionHash":"0x0000000000000000000000000000000000000000000000000000000000000001","blockNumber":"0xa","status":"0x1","gasUsed":"0x5208","effectiveGasPrice":"0x2"}`), &r))
+		assert.Equal(t, common.Hash{31: 1}, r.TxHash)
+		assert.Equal(t, int64(10), r.BlockNumber.Int64())
+		assert.Equal(t, uint64(1), r.Status)
+		assert.Equal(t, int64(42000), r.Fee().Int64())
+	})
+
+	t.Run("with L1 fee", func(t *testing.T) {
+		var r Receipt
+		require.NoError(t, json.Unmarshal([]byte(`{"blockNumber":"0xa","gasUsed":"0x5208","effectiveGasPrice":"0x2","l1Fee":"0x64"}`), &r))
+		assert.Equal(t, int64(42100), r.Fee().Int64())
+	})
+}
+
+func TestValidateValue(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateValue(nil))
+	require.NoError(t, ValidateValue(big.NewInt(0)))
+	require.NoError(t, ValidateValue(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))))
+	require.ErrorContains(t, ValidateValue(big.NewInt(-1)), "value can't be negative")
+	require.ErrorContains(t, ValidateValue(new(big.Int).Lsh(big.NewInt(1), 256)), "value doesn't fit in 256 bits")
+}
+
+func TestTxRequest_ValidateAuthorizationList(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	delegate := common.HexToAddress("0x3")
+	tests := []struct {
+		name    string
+		request TxRequest
+		wantErr string
+	}{
+		{"empty", TxRequest{FromAddress: from}, ""},
+		{"sender without nonce", TxRequest{FromAddress: from, AuthorizationList: []SetCodeAuthorization{{Authority: from, Address: delegate}}}, ""},
+		{"other authority with nonce", TxRequest{FromAddress: from, AuthorizationList: []SetCodeAuthorization{{Authority: other, Address: delegate, Nonce: ptr(uint64(3))}}}, ""},
+		{"other authority without nonce", TxRequest{FromAddress: from, AuthorizationList: []SetCodeAuthorization{{Authority: other, Address: delegate}}}, "nonce is required"},
+		{"duplicate authority", TxRequest{FromAddress: from, AuthorizationList: []SetCodeAuthorization{{Authority: from, Address: delegate}, {Authority: from}}}, "duplicate authorization"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.ValidateAuthorizationList()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -43,6 +43,7 @@ type Head struct {
 	Timestamp        time.Time
 	CreatedAt        time.Time
 	BaseFeePerGas    *assets.Wei
+	ExcessBlobGas    *uint64 // Only available after EIP-4844
 	ReceiptsRoot     common.Hash
 	TransactionsRoot common.Hash
 	StateRoot        common.Hash
@@ -72,6 +73,7 @@ func (h *Head) SetFromHeader(header *types.Header) {
 	//nolint:gosec // G115
 	h.Timestamp = time.Unix(int64(header.Time), 0)
 	h.Difficulty = header.Difficulty
+	h.ExcessBlobGas = header.ExcessBlobGas
 }
 
 func (h *Head) BlockNumber() int64 {
@@ -290,17 +292,18 @@ func (h Hash) Bytes() []byte { return h[:] }
 
 func (h *Head) UnmarshalJSON(bs []byte) error {
 	type head struct {
-		Hash             Hash           `json:"hash"`
-		Number           *hexutil.Big   `json:"number"`
-		ParentHash       Hash           `json:"parentHash"`
-		Timestamp        hexutil.Uint64 `json:"timestamp"`
-		L1BlockNumber    *hexutil.Big   `json:"l1BlockNumber"`
-		BaseFeePerGas    *hexutil.Big   `json:"baseFeePerGas"`
-		ReceiptsRoot     Hash           `json:"receiptsRoot"`
-		TransactionsRoot Hash           `json:"transactionsRoot"`
-		StateRoot        Hash           `json:"stateRoot"`
-		Difficulty       *hexutil.Big   `json:"difficulty"`
-		TotalDifficulty  *hexutil.Big   `json:"totalDifficulty"`
+		Hash             Hash            `json:"hash"`
+		Number           *hexutil.Big    `json:"number"`
+		ParentHash       Hash            `json:"parentHash"`
+		Timestamp        hexutil.Uint64  `json:"timestamp"`
+		L1BlockNumber    *hexutil.Big    `json:"l1BlockNumber"`
+		BaseFeePerGas    *hexutil.Big    `json:"baseFeePerGas"`
+		ExcessBlobGas    *hexutil.Uint64 `json:"excessBlobGas"`
+		ReceiptsRoot     Hash            `json:"receiptsRoot"`
+		TransactionsRoot Hash            `json:"transactionsRoot"`
+		StateRoot        Hash            `json:"stateRoot"`
+		Difficulty       *hexutil.Big    `json:"difficulty"`
+		TotalDifficulty  *hexutil.Big    `json:"totalDifficulty"`
 	}
 
 	var jsonHead head
@@ -319,6 +322,7 @@ func (h *Head) UnmarshalJSON(bs []byte) error {
 	h.ParentHash = common.Hash(jsonHead.ParentHash)
 	h.Timestamp = time.Unix(int64(jsonHead.Timestamp), 0).UTC()
 	h.BaseFeePerGas = assets.NewWei((*big.Int)(jsonHead.BaseFeePerGas))
+	h.ExcessBlobGas = (*uint64)(jsonHead.ExcessBlobGas)
 	if jsonHead.L1BlockNumber != nil {
 		h.L1BlockNumber = sql.NullInt64{Int64: (*big.Int)(jsonHead.L1BlockNumber).Int64(), Valid: true}
 	}
@@ -341,6 +345,7 @@ func (h *Head) MarshalJSON() ([]byte, error) {
 		StateRoot        *common.Hash    `json:"stateRoot,omitempty"`
 		Difficulty       *hexutil.Big    `json:"difficulty,omitempty"`
 		TotalDifficulty  *hexutil.Big    `json:"totalDifficulty,omitempty"`
+		ExcessBlobGas    *hexutil.Uint64 `json:"excessBlobGas,omitempty"`
 	}
 
 	var jsonHead head
@@ -366,6 +371,7 @@ func (h *Head) MarshalJSON() ([]byte, error) {
 	}
 	jsonHead.Difficulty = (*hexutil.Big)(h.Difficulty)
 	jsonHead.TotalDifficulty = (*hexutil.Big)(h.TotalDifficulty)
+	jsonHead.ExcessBlobGas = (*hexutil.Uint64)(h.ExcessBlobGas)
 	return json.Marshal(jsonHead)
 }
 
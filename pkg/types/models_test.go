@@ -337,6 +337,16 @@ func TestHead_UnmarshalJSON(t *testing.T) {
 				StateRoot:        common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
 			},
 		},
+		{"cancun",
+			`{"number":"0x100","hash":"0x41800b5c3f1717687d85fc9018faac0a6e90b39deaa0b99e7fe4fe796ddeb26a","parentHash":"0x41941023680923e0fe4d74a34bdac8141f2540e3ae90623718e47d66d1ca4a2d","timestamp":"0x58318da2","baseFeePerGas":"0x7","blobGasUsed":"0x20000","excessBlobGas":"0x4b00000"}`,
+			&Head{
+				Hash:          common.HexToHash("0x41800b5c3f1717687d85fc9018faac0a6e90b39deaa0b99e7fe4fe796ddeb26a"),
+				Number:        0x100,
+				ParentHash:    common.HexToHash("0x41941023680923e0fe4d74a34bdac8141f2540e3ae90623718e47d66d1ca4a2d"),
+				Timestamp:     time.Unix(0x58318da2, 0).UTC(),
+				ExcessBlobGas: ptr(uint64(0x4b00000)),
+			},
+		},
 		{"not found",
 			`null`,
 			&Head{},
@@ -357,6 +367,7 @@ func TestHead_UnmarshalJSON(t *testing.T) {
 			assert.Equal(t, test.expected.ReceiptsRoot, head.ReceiptsRoot)
 			assert.Equal(t, test.expected.TransactionsRoot, head.TransactionsRoot)
 			assert.Equal(t, test.expected.StateRoot, head.StateRoot)
+			assert.Equal(t, test.expected.ExcessBlobGas, head.ExcessBlobGas)
 		})
 	}
 }
@@ -1262,3 +1273,5 @@ func TestHash_UnmarshalJSON(t *testing.T) {
 		assert.Contains(t, err.Error(), "hex string")
 	})
 }
+
+func ptr[T any](t T) *T { return &t }